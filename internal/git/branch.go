@@ -69,6 +69,66 @@ func GetBranches() ([]Branch, error) {
 	return branches, nil
 }
 
+// GetRemoteBranches returns the remote-tracking branches (refs/remotes/),
+// named like "origin/main". Symbolic refs such as origin/HEAD are skipped.
+func GetRemoteBranches() ([]Branch, error) {
+	output, err := Run("for-each-ref", "--format=%(refname:lstrip=2)|%(symref)|%(subject)", "refs/remotes/")
+	if err != nil {
+		return nil, err
+	}
+
+	var branches []Branch
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		parts := strings.SplitN(line, "|", 3)
+		if len(parts) < 3 || parts[1] != "" {
+			continue
+		}
+		branches = append(branches, Branch{
+			Name:       parts[0],
+			IsRemote:   true,
+			LastCommit: parts[2],
+		})
+	}
+
+	return branches, nil
+}
+
+// SplitRemoteBranch splits a remote-tracking branch name like "origin/feature"
+// into the remote and the branch on that remote. Configured remotes are
+// matched first, so remote names containing a slash are handled.
+func SplitRemoteBranch(name string) (remote, branch string) {
+	remotes, _ := GetRemotes()
+	for _, r := range remotes {
+		if strings.HasPrefix(name, r+"/") && len(r) > len(remote) {
+			remote = r
+		}
+	}
+	if remote == "" {
+		remote, branch, _ = strings.Cut(name, "/")
+		return remote, branch
+	}
+	return remote, strings.TrimPrefix(name, remote+"/")
+}
+
+// CheckoutRemoteBranch switches to a local branch tracking the remote branch,
+// creating it if it doesn't exist yet. An existing local branch with the same
+// name is checked out as is.
+func CheckoutRemoteBranch(name string) error {
+	_, branch := SplitRemoteBranch(name)
+	if _, err := Run("rev-parse", "--verify", "--quiet", "refs/heads/"+branch); err == nil {
+		return CheckoutBranch(branch)
+	}
+	_, err := Run("checkout", "-b", branch, "--track", name)
+	return err
+}
+
+// DeleteRemoteBranch deletes a branch on its remote (git push <remote> --delete)
+func DeleteRemoteBranch(name string) error {
+	remote, branch := SplitRemoteBranch(name)
+	_, err := Run("push", remote, "--delete", branch)
+	return err
+}
+
 // CheckoutBranch switches to the specified branch
 func CheckoutBranch(name string) error {
 	_, err := Run("checkout", name)
@@ -81,6 +141,36 @@ func CreateBranch(name string) error {
 	return err
 }
 
+// RenameBranch renames a local branch (git branch -m). Its upstream
+// configuration is kept.
+func RenameBranch(oldName, newName string) error {
+	_, err := Run("branch", "-m", oldName, newName)
+	return err
+}
+
+// RenameRemoteBranch renames a branch on a remote: the local branch newName
+// is pushed as newName, set as its upstream, and oldName is deleted on the remote
+func RenameRemoteBranch(remote, oldName, newName string) error {
+	if _, err := Run("push", "-u", remote, newName+":"+newName); err != nil {
+		return err
+	}
+	_, err := Run("push", remote, "--delete", oldName)
+	return err
+}
+
+// SetUpstream sets the upstream of a local branch to a remote-tracking
+// branch such as "origin/main"
+func SetUpstream(branch, upstream string) error {
+	_, err := Run("branch", "--set-upstream-to="+upstream, branch)
+	return err
+}
+
+// UnsetUpstream removes the upstream of a local branch
+func UnsetUpstream(branch string) error {
+	_, err := Run("branch", "--unset-upstream", branch)
+	return err
+}
+
 // DeleteBranch deletes a local branch
 func DeleteBranch(name string) error {
 	_, err := Run("branch", "-d", name)
@@ -2,6 +2,8 @@ package git
 
 import (
 	"os"
+	"os/exec"
+	"strings"
 	"testing"
 )
 
@@ -392,8 +394,8 @@ func TestGetBranches_BranchOnDifferentCommit(t *testing.T) {
 }
 
 func TestBranch_IsRemote(t *testing.T) {
-	// The IsRemote field is not currently set by GetBranches (only returns local branches)
-	// This test documents the current behavior
+	// GetBranches only returns local branches; remote-tracking branches
+	// come from GetRemoteBranches
 
 	repo := NewTestRepo(t)
 	defer repo.Cleanup()
@@ -469,3 +471,259 @@ func TestGetBranches_EmptyRepo(t *testing.T) {
 		t.Errorf("expected 0 branches in empty repo, got %d", len(branches))
 	}
 }
+
+// setupRemoteBranches pushes the current branch and a "feature" branch to a
+// bare remote, then deletes the local feature branch
+func setupRemoteBranches(t *testing.T, repo *TestRepo) string {
+	t.Helper()
+	repo.InitialCommit()
+	remoteDir := repo.SetupRemote()
+	t.Cleanup(func() { os.RemoveAll(remoteDir) })
+	repo.PushToRemote()
+	repo.CreateBranch("feature", true)
+	repo.CommitFile("feature.txt", "feature\n", "Feature commit")
+	repo.Git("push", "origin", "feature")
+	repo.Git("checkout", "-")
+	repo.Git("branch", "-D", "feature")
+	repo.Git("remote", "set-head", "origin", "--auto")
+	return remoteDir
+}
+
+func TestGetRemoteBranches(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	setupRemoteBranches(t, repo)
+
+	branches, err := GetRemoteBranches()
+	if err != nil {
+		t.Fatalf("GetRemoteBranches failed: %v", err)
+	}
+
+	names := make(map[string]Branch)
+	for _, b := range branches {
+		names[b.Name] = b
+		if !b.IsRemote {
+			t.Errorf("expected %s to be marked as remote", b.Name)
+		}
+	}
+	if _, ok := names["origin/"+GetBranch()]; !ok {
+		t.Errorf("expected origin/%s in %v", GetBranch(), branches)
+	}
+	if b, ok := names["origin/feature"]; !ok || b.LastCommit != "Feature commit" {
+		t.Errorf("expected origin/feature with its last commit, got %+v", b)
+	}
+	if _, ok := names["origin/HEAD"]; ok {
+		t.Error("origin/HEAD should be skipped")
+	}
+}
+
+func TestGetRemoteBranches_NoRemotes(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+
+	branches, err := GetRemoteBranches()
+	if err != nil {
+		t.Fatalf("GetRemoteBranches failed: %v", err)
+	}
+	if len(branches) != 0 {
+		t.Errorf("expected no remote branches, got %v", branches)
+	}
+}
+
+func TestSplitRemoteBranch(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+	repo.Git("remote", "add", "origin", "https://example.com/repo.git")
+	repo.Git("remote", "add", "team/fork", "https://example.com/fork.git")
+
+	tests := []struct {
+		name, remote, branch string
+	}{
+		{"origin/main", "origin", "main"},
+		{"origin/feature/login", "origin", "feature/login"},
+		{"team/fork/main", "team/fork", "main"},
+		{"unknown/topic", "unknown", "topic"},
+	}
+	for _, tt := range tests {
+		remote, branch := SplitRemoteBranch(tt.name)
+		if remote != tt.remote || branch != tt.branch {
+			t.Errorf("SplitRemoteBranch(%q) = %q, %q; want %q, %q", tt.name, remote, branch, tt.remote, tt.branch)
+		}
+	}
+}
+
+func TestCheckoutRemoteBranch(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	setupRemoteBranches(t, repo)
+
+	if err := CheckoutRemoteBranch("origin/feature"); err != nil {
+		t.Fatalf("CheckoutRemoteBranch failed: %v", err)
+	}
+	if GetBranch() != "feature" {
+		t.Errorf("expected to be on feature, got %q", GetBranch())
+	}
+	upstream := strings.TrimSpace(repo.Git("rev-parse", "--abbrev-ref", "feature@{upstream}"))
+	if upstream != "origin/feature" {
+		t.Errorf("expected feature to track origin/feature, got %q", upstream)
+	}
+}
+
+func TestCheckoutRemoteBranch_ExistingLocal(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	setupRemoteBranches(t, repo)
+	main := GetBranch()
+
+	// A local branch with the same name is reused, not recreated
+	repo.Git("checkout", "-b", "feature", "origin/feature")
+	repo.CommitFile("local.txt", "local\n", "Local commit")
+	repo.Git("checkout", main)
+
+	if err := CheckoutRemoteBranch("origin/feature"); err != nil {
+		t.Fatalf("CheckoutRemoteBranch failed: %v", err)
+	}
+	if GetBranch() != "feature" {
+		t.Errorf("expected to be on feature, got %q", GetBranch())
+	}
+	if !repo.FileExists("local.txt") {
+		t.Error("expected the existing local branch to be checked out")
+	}
+}
+
+func TestDeleteRemoteBranch(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	remoteDir := setupRemoteBranches(t, repo)
+
+	if err := DeleteRemoteBranch("origin/feature"); err != nil {
+		t.Fatalf("DeleteRemoteBranch failed: %v", err)
+	}
+
+	if _, err := repo.GitAllowFailure("rev-parse", "--verify", "refs/remotes/origin/feature"); err == nil {
+		t.Error("expected origin/feature to be removed locally")
+	}
+	cmd := exec.Command("git", "rev-parse", "--verify", "refs/heads/feature")
+	cmd.Dir = remoteDir
+	if err := cmd.Run(); err == nil {
+		t.Error("expected feature to be deleted on the remote")
+	}
+}
+
+func TestRenameBranch(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+	repo.CreateBranch("feature", false)
+
+	if err := RenameBranch("feature", "feature-renamed"); err != nil {
+		t.Fatalf("RenameBranch failed: %v", err)
+	}
+
+	branches, _ := GetBranches()
+	names := make(map[string]bool)
+	for _, b := range branches {
+		names[b.Name] = true
+	}
+	if names["feature"] || !names["feature-renamed"] {
+		t.Errorf("expected feature to be renamed, got %v", branches)
+	}
+}
+
+func TestRenameBranch_Existing(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+	repo.CreateBranch("one", false)
+	repo.CreateBranch("two", false)
+
+	if err := RenameBranch("one", "two"); err == nil {
+		t.Error("expected error renaming onto an existing branch")
+	}
+}
+
+func TestRenameRemoteBranch(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+	remoteDir := repo.SetupRemote()
+	defer os.RemoveAll(remoteDir)
+	repo.CreateBranch("old-name", true)
+	repo.PushToRemote()
+
+	if err := RenameBranch("old-name", "new-name"); err != nil {
+		t.Fatalf("RenameBranch failed: %v", err)
+	}
+	if err := RenameRemoteBranch("origin", "old-name", "new-name"); err != nil {
+		t.Fatalf("RenameRemoteBranch failed: %v", err)
+	}
+
+	remote := repo.GitIn(remoteDir, "branch", "--list")
+	if strings.Contains(remote, "old-name") || !strings.Contains(remote, "new-name") {
+		t.Errorf("expected the remote branch to be renamed, remote has:\n%s", remote)
+	}
+	if status := GetBranchStatus(); status.Remote != "origin/new-name" {
+		t.Errorf("expected upstream origin/new-name, got %q", status.Remote)
+	}
+}
+
+func TestSetAndUnsetUpstream(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+	remoteDir := repo.SetupRemote()
+	defer os.RemoveAll(remoteDir)
+	repo.Git("push", "origin", "HEAD:feature")
+	repo.Git("fetch", "origin")
+	repo.CreateBranch("feature", false)
+	repo.Git("checkout", "feature")
+	repo.CommitFile("ahead.txt", "ahead\n", "Ahead commit")
+	repo.Git("checkout", "-")
+
+	if err := SetUpstream("feature", "origin/feature"); err != nil {
+		t.Fatalf("SetUpstream failed: %v", err)
+	}
+	branch := findBranch(t, "feature")
+	if branch.Upstream != "origin/feature" || branch.Ahead != 1 {
+		t.Errorf("expected upstream origin/feature 1 ahead, got %+v", branch)
+	}
+
+	if err := UnsetUpstream("feature"); err != nil {
+		t.Fatalf("UnsetUpstream failed: %v", err)
+	}
+	branch = findBranch(t, "feature")
+	if branch.Upstream != "" || branch.Ahead != 0 {
+		t.Errorf("expected no upstream, got %+v", branch)
+	}
+}
+
+func TestSetUpstream_MissingRemoteBranch(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+	remoteDir := repo.SetupRemote()
+	defer os.RemoveAll(remoteDir)
+	repo.CreateBranch("feature", false)
+
+	if err := SetUpstream("feature", "origin/feature"); err == nil {
+		t.Error("expected error for an upstream that doesn't exist")
+	}
+}
+
+// findBranch returns the local branch with the given name
+func findBranch(t *testing.T, name string) Branch {
+	t.Helper()
+	branches, err := GetBranches()
+	if err != nil {
+		t.Fatalf("GetBranches failed: %v", err)
+	}
+	for _, b := range branches {
+		if b.Name == name {
+			return b
+		}
+	}
+	t.Fatalf("branch %s not found", name)
+	return Branch{}
+}
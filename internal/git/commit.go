@@ -0,0 +1,55 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// GetCommitTemplate returns the commit message template configured with
+// commit.template, falling back to a .gitmessage file in the repository
+// root or home directory. Returns "" if there is no template.
+func GetCommitTemplate() (string, error) {
+	// --path expands a leading ~ like git does
+	path, _ := RunAllowFailure("config", "--path", "commit.template")
+	path = strings.TrimSpace(path)
+	if path != "" {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(GetRepoRoot(), path)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+
+	candidates := []string{filepath.Join(GetRepoRoot(), ".gitmessage")}
+	if home, err := os.UserHomeDir(); err == nil {
+		candidates = append(candidates, filepath.Join(home, ".gitmessage"))
+	}
+	for _, candidate := range candidates {
+		if data, err := os.ReadFile(candidate); err == nil {
+			return string(data), nil
+		}
+	}
+	return "", nil
+}
+
+// CleanCommitMessage cleans up a commit message like git's default "strip"
+// mode: # comment lines and trailing whitespace are removed, runs of blank
+// lines are collapsed and leading/trailing blank lines are dropped
+func CleanCommitMessage(message string) string {
+	var lines []string
+	for _, line := range strings.Split(message, "\n") {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" && (len(lines) == 0 || lines[len(lines)-1] == "") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
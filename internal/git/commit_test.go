@@ -0,0 +1,71 @@
+package git
+
+import (
+	"testing"
+)
+
+func TestCleanCommitMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    string
+	}{
+		{"subject only", "Fix bug", "Fix bug"},
+		{"subject and body", "Fix bug\n\nLonger explanation.", "Fix bug\n\nLonger explanation."},
+		{"comments stripped", "# Please enter a message\nFix bug\n# comment\n\nBody\n", "Fix bug\n\nBody"},
+		{"trailing whitespace", "Fix bug  \n\nBody\t\n", "Fix bug\n\nBody"},
+		{"blank runs collapsed", "\n\nFix bug\n\n\n\nBody\n\n", "Fix bug\n\nBody"},
+		{"only comments", "# nothing here\n#\n", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CleanCommitMessage(tt.message); got != tt.want {
+				t.Errorf("CleanCommitMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetCommitTemplate(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	t.Setenv("HOME", t.TempDir())
+
+	repo.InitialCommit()
+
+	// No template
+	template, err := GetCommitTemplate()
+	if err != nil {
+		t.Fatalf("GetCommitTemplate failed: %v", err)
+	}
+	if template != "" {
+		t.Errorf("expected no template, got %q", template)
+	}
+
+	// .gitmessage in the repository root
+	repo.WriteFile(".gitmessage", "Subject\n\n# Explain why\n")
+	template, err = GetCommitTemplate()
+	if err != nil {
+		t.Fatalf("GetCommitTemplate failed: %v", err)
+	}
+	if template != "Subject\n\n# Explain why\n" {
+		t.Errorf("template = %q, want .gitmessage contents", template)
+	}
+
+	// commit.template takes precedence
+	repo.WriteFile("template.txt", "From config\n")
+	repo.Git("config", "commit.template", "template.txt")
+	template, err = GetCommitTemplate()
+	if err != nil {
+		t.Fatalf("GetCommitTemplate failed: %v", err)
+	}
+	if template != "From config\n" {
+		t.Errorf("template = %q, want commit.template contents", template)
+	}
+
+	// A configured template that doesn't exist is an error
+	repo.Git("config", "commit.template", "missing.txt")
+	if _, err := GetCommitTemplate(); err == nil {
+		t.Error("expected error for missing commit.template file")
+	}
+}
@@ -0,0 +1,186 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	conflictMarkerOurs   = "<<<<<<<"
+	conflictMarkerBase   = "|||||||"
+	conflictMarkerSplit  = "======="
+	conflictMarkerTheirs = ">>>>>>>"
+)
+
+// ConflictResolution selects which side of a conflict block to keep
+type ConflictResolution int
+
+const (
+	ResolveOurs ConflictResolution = iota
+	ResolveTheirs
+	ResolveBoth
+)
+
+// ConflictBlock represents a single <<<<<<< ... >>>>>>> region in a file
+type ConflictBlock struct {
+	StartLine   int      // 1-based line of the <<<<<<< marker
+	EndLine     int      // 1-based line of the >>>>>>> marker
+	OursLabel   string   // Text after <<<<<<< (e.g., "HEAD")
+	TheirsLabel string   // Text after >>>>>>> (e.g., "feature")
+	Ours        []string // Lines between <<<<<<< and ||||||| or =======
+	Base        []string // Lines between ||||||| and ======= (diff3 style only)
+	Theirs      []string // Lines between ======= and >>>>>>>
+}
+
+// ConflictFile holds the parsed conflict blocks of an unmerged file
+type ConflictFile struct {
+	Path        string // Path relative to repo root
+	DisplayPath string // Path relative to cwd (for display)
+	Exists      bool   // False when one side deleted the file
+	Blocks      []ConflictBlock
+}
+
+// HasMarkers returns true if any conflict blocks remain in the file
+func (c *ConflictFile) HasMarkers() bool {
+	return len(c.Blocks) > 0
+}
+
+func isConflictMarker(line, marker string) bool {
+	if !strings.HasPrefix(line, marker) {
+		return false
+	}
+	rest := line[len(marker):]
+	return rest == "" || rest[0] == ' ' || rest[0] == '\r'
+}
+
+func markerLabel(line, marker string) string {
+	return strings.TrimSpace(line[len(marker):])
+}
+
+// ParseConflicts extracts conflict blocks from file content.
+// Unterminated blocks are ignored.
+func ParseConflicts(content string) []ConflictBlock {
+	lines := strings.Split(content, "\n")
+
+	const (
+		stateNone = iota
+		stateOurs
+		stateBase
+		stateTheirs
+	)
+
+	var blocks []ConflictBlock
+	var current ConflictBlock
+	state := stateNone
+
+	for i, line := range lines {
+		switch state {
+		case stateNone:
+			if isConflictMarker(line, conflictMarkerOurs) {
+				current = ConflictBlock{
+					StartLine: i + 1,
+					OursLabel: markerLabel(line, conflictMarkerOurs),
+				}
+				state = stateOurs
+			}
+		case stateOurs, stateBase:
+			switch {
+			case isConflictMarker(line, conflictMarkerBase) && state == stateOurs:
+				state = stateBase
+			case isConflictMarker(line, conflictMarkerSplit):
+				state = stateTheirs
+			case state == stateOurs:
+				current.Ours = append(current.Ours, line)
+			default:
+				current.Base = append(current.Base, line)
+			}
+		case stateTheirs:
+			if isConflictMarker(line, conflictMarkerTheirs) {
+				current.EndLine = i + 1
+				current.TheirsLabel = markerLabel(line, conflictMarkerTheirs)
+				blocks = append(blocks, current)
+				state = stateNone
+			} else {
+				current.Theirs = append(current.Theirs, line)
+			}
+		}
+	}
+
+	return blocks
+}
+
+// GetConflictFile reads an unmerged file and parses its conflict blocks
+func GetConflictFile(path string) (*ConflictFile, error) {
+	result := &ConflictFile{
+		Path:        path,
+		DisplayPath: ToDisplayPath(path),
+	}
+
+	content, err := os.ReadFile(filepath.Join(GetRepoRoot(), path))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return result, nil
+		}
+		return nil, err
+	}
+
+	result.Exists = true
+	result.Blocks = ParseConflicts(string(content))
+	return result, nil
+}
+
+// ResolveConflict replaces one conflict block in the file with the chosen side
+func ResolveConflict(path string, blockIndex int, resolution ConflictResolution) error {
+	fullPath := filepath.Join(GetRepoRoot(), path)
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		return err
+	}
+
+	blocks := ParseConflicts(string(content))
+	if blockIndex < 0 || blockIndex >= len(blocks) {
+		return fmt.Errorf("conflict %d not found in %s", blockIndex+1, path)
+	}
+	block := blocks[blockIndex]
+
+	var replacement []string
+	switch resolution {
+	case ResolveOurs:
+		replacement = block.Ours
+	case ResolveTheirs:
+		replacement = block.Theirs
+	case ResolveBoth:
+		replacement = append(append([]string{}, block.Ours...), block.Theirs...)
+	}
+
+	lines := strings.Split(string(content), "\n")
+	var out []string
+	out = append(out, lines[:block.StartLine-1]...)
+	out = append(out, replacement...)
+	out = append(out, lines[block.EndLine:]...)
+
+	info, err := os.Stat(fullPath)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(fullPath, []byte(strings.Join(out, "\n")), info.Mode().Perm())
+}
+
+// MarkResolved stages a conflicted file once no conflict markers remain.
+// If the file was deleted as part of the resolution, the deletion is staged.
+func MarkResolved(path string) error {
+	conflict, err := GetConflictFile(path)
+	if err != nil {
+		return err
+	}
+	if conflict.HasMarkers() {
+		return fmt.Errorf("%s still contains %d conflict marker(s)", conflict.DisplayPath, len(conflict.Blocks))
+	}
+	if !conflict.Exists {
+		_, err := Run("rm", "--cached", "--quiet", "--", path)
+		return err
+	}
+	return StageFile(path)
+}
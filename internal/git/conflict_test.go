@@ -0,0 +1,183 @@
+package git
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConflicts(t *testing.T) {
+	content := "line 1\n<<<<<<< HEAD\nours\n=======\ntheirs 1\ntheirs 2\n>>>>>>> feature\nline 2\n"
+
+	blocks := ParseConflicts(content)
+	if len(blocks) != 1 {
+		t.Fatalf("expected 1 block, got %d", len(blocks))
+	}
+
+	b := blocks[0]
+	if b.StartLine != 2 || b.EndLine != 7 {
+		t.Errorf("expected lines 2-7, got %d-%d", b.StartLine, b.EndLine)
+	}
+	if b.OursLabel != "HEAD" || b.TheirsLabel != "feature" {
+		t.Errorf("unexpected labels %q/%q", b.OursLabel, b.TheirsLabel)
+	}
+	if len(b.Ours) != 1 || b.Ours[0] != "ours" {
+		t.Errorf("unexpected ours: %v", b.Ours)
+	}
+	if len(b.Theirs) != 2 {
+		t.Errorf("expected 2 theirs lines, got %v", b.Theirs)
+	}
+}
+
+func TestParseConflicts_Diff3(t *testing.T) {
+	content := "<<<<<<< HEAD\nours\n||||||| base\noriginal\n=======\ntheirs\n>>>>>>> feature\n"
+
+	blocks := ParseConflicts(content)
+	if len(blocks) != 1 {
+		t.Fatalf("expected 1 block, got %d", len(blocks))
+	}
+	if len(blocks[0].Base) != 1 || blocks[0].Base[0] != "original" {
+		t.Errorf("unexpected base: %v", blocks[0].Base)
+	}
+	if len(blocks[0].Ours) != 1 || blocks[0].Ours[0] != "ours" {
+		t.Errorf("unexpected ours: %v", blocks[0].Ours)
+	}
+}
+
+func TestParseConflicts_IgnoresUnterminated(t *testing.T) {
+	content := "<<<<<<< HEAD\nours\n=======\ntheirs\n"
+
+	if blocks := ParseConflicts(content); len(blocks) != 0 {
+		t.Errorf("expected no blocks for unterminated conflict, got %d", len(blocks))
+	}
+}
+
+func TestGetStatus_Conflicted(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	repo.SetupMergeConflict("file.txt", "base\n", "ours\n", "theirs\n")
+
+	status, err := GetStatus()
+	if err != nil {
+		t.Fatalf("GetStatus failed: %v", err)
+	}
+
+	if len(status.Conflicted) != 1 {
+		t.Fatalf("expected 1 conflicted file, got %d", len(status.Conflicted))
+	}
+	if len(status.Staged) != 0 || len(status.Unstaged) != 0 {
+		t.Errorf("conflicted file should not be staged or unstaged: %+v", status)
+	}
+
+	f := status.Conflicted[0]
+	if !f.IsConflicted() {
+		t.Error("expected IsConflicted to be true")
+	}
+	if !strings.Contains(f.StatusDescription(), "both modified") {
+		t.Errorf("unexpected description %q", f.StatusDescription())
+	}
+}
+
+func TestResolveConflict(t *testing.T) {
+	tests := []struct {
+		name       string
+		resolution ConflictResolution
+		want       string
+	}{
+		{"ours", ResolveOurs, "ours\n"},
+		{"theirs", ResolveTheirs, "theirs\n"},
+		{"both", ResolveBoth, "ours\ntheirs\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewTestRepo(t)
+			defer repo.Cleanup()
+
+			repo.SetupMergeConflict("file.txt", "base\n", "ours\n", "theirs\n")
+
+			if err := ResolveConflict("file.txt", 0, tt.resolution); err != nil {
+				t.Fatalf("ResolveConflict failed: %v", err)
+			}
+
+			if got := repo.ReadFile("file.txt"); got != tt.want {
+				t.Errorf("content = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveConflict_InvalidBlock(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	repo.SetupMergeConflict("file.txt", "base\n", "ours\n", "theirs\n")
+
+	if err := ResolveConflict("file.txt", 3, ResolveOurs); err == nil {
+		t.Error("expected error for out of range block")
+	}
+}
+
+func TestMarkResolved_RefusesWithMarkers(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	repo.SetupMergeConflict("file.txt", "base\n", "ours\n", "theirs\n")
+
+	if err := MarkResolved("file.txt"); err == nil {
+		t.Fatal("expected MarkResolved to fail while markers remain")
+	}
+
+	status, _ := GetStatus()
+	if len(status.Conflicted) != 1 {
+		t.Error("file should still be conflicted")
+	}
+}
+
+func TestMarkResolved(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	repo.SetupMergeConflict("file.txt", "base\n", "ours\n", "theirs\n")
+
+	if err := ResolveConflict("file.txt", 0, ResolveTheirs); err != nil {
+		t.Fatalf("ResolveConflict failed: %v", err)
+	}
+	if err := MarkResolved("file.txt"); err != nil {
+		t.Fatalf("MarkResolved failed: %v", err)
+	}
+
+	status, err := GetStatus()
+	if err != nil {
+		t.Fatalf("GetStatus failed: %v", err)
+	}
+	if len(status.Conflicted) != 0 {
+		t.Errorf("expected no conflicted files, got %d", len(status.Conflicted))
+	}
+	if len(status.Staged) != 1 {
+		t.Errorf("expected resolved file to be staged, got %d", len(status.Staged))
+	}
+}
+
+func TestMarkResolved_DeletedFile(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	repo.CommitFile("file.txt", "base\n", "base")
+	repo.Git("checkout", "-b", "theirs")
+	repo.Git("rm", "file.txt")
+	repo.Git("commit", "-m", "delete")
+	repo.Git("checkout", "-")
+	repo.CommitFile("file.txt", "ours\n", "ours change")
+	repo.GitAllowFailure("merge", "theirs")
+
+	repo.DeleteFile("file.txt")
+	if err := MarkResolved("file.txt"); err != nil {
+		t.Fatalf("MarkResolved failed: %v", err)
+	}
+
+	status, _ := GetStatus()
+	if len(status.Conflicted) != 0 {
+		t.Errorf("expected conflict to be resolved, got %+v", status.Conflicted)
+	}
+}
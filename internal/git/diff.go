@@ -1,6 +1,7 @@
 package git
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 )
@@ -34,6 +35,8 @@ type Hunk struct {
 	FileIndex       int        // Index of the file in the diff
 	HunkIndex       int        // Index of this hunk within the file
 	Staged          bool       // Whether this hunk is staged (true) or unstaged (false)
+	Submodule       bool       // Whether the hunk moves a submodule ("Subproject commit" lines)
+	Untracked       bool       // Whether the hunk adds an untracked file
 }
 
 // FileDiff represents the diff for a single file
@@ -171,6 +174,10 @@ func parseDiff(output string) *DiffResult {
 				lineType = LineContext
 			}
 
+			if lineType != LineContext && strings.HasPrefix(line[1:], "Subproject commit ") {
+				currentHunk.Submodule = true
+			}
+
 			currentHunk.Lines = append(currentHunk.Lines, DiffLine{
 				Type:    lineType,
 				Content: line,
@@ -189,6 +196,20 @@ func parseDiff(output string) *DiffResult {
 	return result
 }
 
+// ParseSubprojectLine parses a "+Subproject commit <sha>[-dirty]" line of a
+// submodule hunk. Dirty means the submodule has uncommitted changes.
+func ParseSubprojectLine(content string) (hash string, dirty bool, ok bool) {
+	if len(content) < 1 {
+		return "", false, false
+	}
+	rest, ok := strings.CutPrefix(content[1:], "Subproject commit ")
+	if !ok {
+		return "", false, false
+	}
+	hash, dirty = strings.CutSuffix(rest, "-dirty")
+	return hash, dirty, true
+}
+
 func parseInt(s string) int {
 	if s == "" {
 		return 0
@@ -320,6 +341,210 @@ func (h *Hunk) GeneratePatch(fileDiff *FileDiff) string {
 	return sb.String()
 }
 
+// HasChanges returns true if the hunk contains any added or removed lines
+func (h *Hunk) HasChanges() bool {
+	for _, line := range h.Lines {
+		if line.Type == LineAdded || line.Type == LineRemoved {
+			return true
+		}
+	}
+	return false
+}
+
+// GeneratePartialPatch generates a patch containing only the selected lines
+// of the hunk (selected holds indexes into h.Lines).
+//
+// When reverse is false the patch is meant to be applied forward (staging):
+// unselected removals become context and unselected additions are dropped.
+// When reverse is true the patch is meant to be applied with --reverse
+// (unstaging or discarding): unselected additions become context and
+// unselected removals are dropped. The @@ line counts are recomputed.
+func (h *Hunk) GeneratePartialPatch(fileDiff *FileDiff, selected map[int]bool, reverse bool) string {
+	var body []string
+	oldCount, newCount := 0, 0
+	dropped := false // whether the previous line was dropped
+	for i, line := range h.Lines {
+		content := line.Content
+		switch {
+		case content == "":
+			// Trailing empty line from splitting the diff output
+			continue
+		case strings.HasPrefix(content, "\\"):
+			// "\ No newline at end of file" belongs to the line before it
+			if !dropped {
+				body = append(body, content)
+			}
+			continue
+		}
+
+		dropped = false
+		switch line.Type {
+		case LineAdded:
+			switch {
+			case selected[i]:
+				newCount++
+			case reverse:
+				content = " " + content[1:]
+				oldCount++
+				newCount++
+			default:
+				dropped = true
+			}
+		case LineRemoved:
+			switch {
+			case selected[i]:
+				oldCount++
+			case !reverse:
+				content = " " + content[1:]
+				oldCount++
+				newCount++
+			default:
+				dropped = true
+			}
+		default:
+			oldCount++
+			newCount++
+		}
+		if !dropped {
+			body = append(body, content)
+		}
+	}
+
+	// The side the patch is applied to is unchanged, so its start line
+	// stays valid. The other side starts at the same place.
+	start := h.StartOld
+	if reverse {
+		start = h.StartNew
+	}
+	oldStart, newStart := start, start
+	if reverse {
+		oldStart = adjustHunkStart(start, newCount, oldCount)
+	} else {
+		newStart = adjustHunkStart(start, oldCount, newCount)
+	}
+
+	section := ""
+	if matches := hunkHeaderRegex.FindStringSubmatch(h.Header); len(matches) > 5 {
+		section = matches[5]
+	}
+
+	var sb strings.Builder
+	for _, headerLine := range fileDiff.Header {
+		sb.WriteString(headerLine)
+		sb.WriteString("\n")
+	}
+	sb.WriteString(fmt.Sprintf("@@ -%d,%d +%d,%d @@%s\n", oldStart, oldCount, newStart, newCount, section))
+	for _, line := range body {
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// Split breaks the hunk into smaller hunks, one per run of changed lines,
+// like the "s" command of git add -p. Context lines between two runs are
+// shared by both sub-hunks, so each one can be applied on its own.
+// A hunk with a single run of changes is returned unchanged.
+func (h *Hunk) Split() []Hunk {
+	// Find the runs of changed lines, skipping the trailing empty line
+	// left by splitting the diff output
+	type run struct{ start, end int } // [start, end) in h.Lines
+	var runs []run
+	var lines []DiffLine
+	for _, line := range h.Lines {
+		if line.Content == "" {
+			continue
+		}
+		changed := line.Type == LineAdded || line.Type == LineRemoved
+		if strings.HasPrefix(line.Content, "\\") && len(runs) > 0 && runs[len(runs)-1].end == len(lines) {
+			// "\ No newline at end of file" stays with the line before it
+			changed = true
+		}
+		if changed {
+			if len(runs) > 0 && runs[len(runs)-1].end == len(lines) {
+				runs[len(runs)-1].end++
+			} else {
+				runs = append(runs, run{len(lines), len(lines) + 1})
+			}
+		}
+		lines = append(lines, line)
+	}
+	if len(runs) < 2 {
+		return []Hunk{*h}
+	}
+
+	section := ""
+	if matches := hunkHeaderRegex.FindStringSubmatch(h.Header); len(matches) > 5 {
+		section = matches[5]
+	}
+
+	hunks := make([]Hunk, 0, len(runs))
+	for i := range runs {
+		// Leading context starts after the previous run, trailing context
+		// ends at the next one
+		from := 0
+		if i > 0 {
+			from = runs[i-1].end
+		}
+		to := len(lines)
+		if i < len(runs)-1 {
+			to = runs[i+1].start
+		}
+
+		// Count the lines on each side that come before this sub-hunk
+		startOld, startNew := h.StartOld, h.StartNew
+		for _, line := range lines[:from] {
+			countHunkLine(line, &startOld, &startNew)
+		}
+		countOld, countNew := 0, 0
+		for _, line := range lines[from:to] {
+			countHunkLine(line, &countOld, &countNew)
+		}
+		if countOld == 0 {
+			startOld--
+		}
+		if countNew == 0 {
+			startNew--
+		}
+
+		sub := *h
+		sub.Lines = append([]DiffLine(nil), lines[from:to]...)
+		sub.StartOld, sub.CountOld = startOld, countOld
+		sub.StartNew, sub.CountNew = startNew, countNew
+		sub.Header = fmt.Sprintf("@@ -%d,%d +%d,%d @@%s", startOld, countOld, startNew, countNew, section)
+		hunks = append(hunks, sub)
+	}
+	return hunks
+}
+
+// countHunkLine adds a hunk line to the old and new side line counts
+func countHunkLine(line DiffLine, oldCount, newCount *int) {
+	if strings.HasPrefix(line.Content, "\\") {
+		return
+	}
+	switch line.Type {
+	case LineAdded:
+		*newCount++
+	case LineRemoved:
+		*oldCount++
+	default:
+		*oldCount++
+		*newCount++
+	}
+}
+
+// adjustHunkStart converts the start line of one side of a hunk to the
+// other side. An empty side starts at the line before the change.
+func adjustHunkStart(start, count, otherCount int) int {
+	switch {
+	case count == 0 && otherCount > 0:
+		return start + 1
+	case count > 0 && otherCount == 0:
+		return start - 1
+	}
+	return start
+}
+
 // GetUntrackedFileDiff returns a diff for an untracked file (showing all content as additions)
 func GetUntrackedFileDiff(path string) *FileDiff {
 	// Use git diff --no-index to compare /dev/null with the file
@@ -337,6 +562,7 @@ func GetUntrackedFileDiff(path string) *FileDiff {
 		for i := range result.Files[0].Hunks {
 			result.Files[0].Hunks[i].FilePath = path
 			result.Files[0].Hunks[i].DisplayFilePath = ToDisplayPath(path)
+			result.Files[0].Hunks[i].Untracked = true
 		}
 		return &result.Files[0]
 	}
@@ -310,6 +310,9 @@ func TestGetUntrackedFileDiff(t *testing.T) {
 
 	// All lines should be additions
 	for _, hunk := range diff.Hunks {
+		if !hunk.Untracked {
+			t.Error("expected the hunk to be marked untracked")
+		}
 		for _, line := range hunk.Lines {
 			if line.Type == LineRemoved {
 				t.Error("unexpected removed line in untracked file diff")
@@ -623,3 +626,304 @@ func TestHunk_Metadata(t *testing.T) {
 		t.Errorf("expected hunk header to start with '@@', got %q", hunk.Header)
 	}
 }
+
+func TestHunk_GeneratePartialPatch(t *testing.T) {
+	fileDiff := &FileDiff{Header: []string{"diff --git a/f.txt b/f.txt", "--- a/f.txt", "+++ b/f.txt"}}
+	hunk := Hunk{
+		Header:   "@@ -1,3 +1,3 @@ func main()",
+		StartOld: 1, CountOld: 3, StartNew: 1, CountNew: 3,
+		Lines: []DiffLine{
+			{Type: LineContext, Content: " a"},
+			{Type: LineRemoved, Content: "-b"},
+			{Type: LineRemoved, Content: "-c"},
+			{Type: LineAdded, Content: "+B"},
+			{Type: LineAdded, Content: "+C"},
+			{Type: LineContext, Content: ""},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		selected map[int]bool
+		reverse  bool
+		want     string
+	}{
+		{
+			name:     "forward",
+			selected: map[int]bool{1: true, 3: true},
+			want:     "@@ -1,3 +1,3 @@ func main()\n a\n-b\n c\n+B\n",
+		},
+		{
+			name:     "forward additions only",
+			selected: map[int]bool{4: true},
+			want:     "@@ -1,3 +1,4 @@ func main()\n a\n b\n c\n+C\n",
+		},
+		{
+			name:     "reverse",
+			selected: map[int]bool{2: true, 4: true},
+			reverse:  true,
+			want:     "@@ -1,3 +1,3 @@ func main()\n a\n-c\n B\n+C\n",
+		},
+		{
+			name:     "reverse removals only",
+			selected: map[int]bool{1: true},
+			reverse:  true,
+			want:     "@@ -1,4 +1,3 @@ func main()\n a\n-b\n B\n C\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch := hunk.GeneratePartialPatch(fileDiff, tt.selected, tt.reverse)
+			want := "diff --git a/f.txt b/f.txt\n--- a/f.txt\n+++ b/f.txt\n" + tt.want
+			if patch != want {
+				t.Errorf("patch =\n%s\nwant:\n%s", patch, want)
+			}
+		})
+	}
+}
+
+func TestHunk_GeneratePartialPatch_NoNewline(t *testing.T) {
+	fileDiff := &FileDiff{Header: []string{"diff --git a/f.txt b/f.txt"}}
+	hunk := Hunk{
+		Header:   "@@ -1 +1,2 @@",
+		StartOld: 1, CountOld: 1, StartNew: 1, CountNew: 2,
+		Lines: []DiffLine{
+			{Type: LineContext, Content: " a"},
+			{Type: LineAdded, Content: "+b"},
+			{Type: LineContext, Content: `\ No newline at end of file`},
+		},
+	}
+
+	patch := hunk.GeneratePartialPatch(fileDiff, map[int]bool{}, false)
+	if strings.Contains(patch, "No newline") {
+		t.Errorf("marker for a dropped line should be dropped too:\n%s", patch)
+	}
+
+	patch = hunk.GeneratePartialPatch(fileDiff, map[int]bool{1: true}, false)
+	if !strings.Contains(patch, "+b\n\\ No newline at end of file\n") {
+		t.Errorf("marker should follow its line:\n%s", patch)
+	}
+}
+
+func TestHunk_GeneratePartialPatch_Stage(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	repo.CommitFile("test.txt", "line1\nline2\nline3\nline4\n", "initial")
+	repo.WriteFile("test.txt", "line1\nnew2\nline3\nnew4\n")
+
+	diff, err := GetDiff()
+	if err != nil {
+		t.Fatalf("GetDiff failed: %v", err)
+	}
+	if len(diff.Files) != 1 || len(diff.Files[0].Hunks) != 1 {
+		t.Fatal("expected a single hunk")
+	}
+	hunk := diff.Files[0].Hunks[0]
+
+	// Select only the first change (-line2 +new2)
+	selected := map[int]bool{}
+	for i, line := range hunk.Lines {
+		if line.Content == "-line2" || line.Content == "+new2" {
+			selected[i] = true
+		}
+	}
+
+	if err := StageHunk(hunk.GeneratePartialPatch(&diff.Files[0], selected, false)); err != nil {
+		t.Fatalf("StageHunk failed: %v", err)
+	}
+
+	staged := repo.Git("show", ":test.txt")
+	if staged != "line1\nnew2\nline3\nline4\n" {
+		t.Errorf("index content = %q", staged)
+	}
+}
+
+func TestHunk_GeneratePartialPatch_Unstage(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	repo.CommitFile("test.txt", "line1\nline2\nline3\n", "initial")
+	repo.WriteFile("test.txt", "line1\nline2\nadded\nline3\nalso added\n")
+	repo.Git("add", "test.txt")
+
+	diff, err := GetStagedDiff()
+	if err != nil {
+		t.Fatalf("GetStagedDiff failed: %v", err)
+	}
+	if len(diff.Files) != 1 || len(diff.Files[0].Hunks) != 1 {
+		t.Fatal("expected a single hunk")
+	}
+	hunk := diff.Files[0].Hunks[0]
+
+	selected := map[int]bool{}
+	for i, line := range hunk.Lines {
+		if line.Content == "+also added" {
+			selected[i] = true
+		}
+	}
+
+	if err := UnstageHunk(hunk.GeneratePartialPatch(&diff.Files[0], selected, true)); err != nil {
+		t.Fatalf("UnstageHunk failed: %v", err)
+	}
+
+	staged := repo.Git("show", ":test.txt")
+	if staged != "line1\nline2\nadded\nline3\n" {
+		t.Errorf("index content = %q", staged)
+	}
+}
+
+func TestHunk_GeneratePartialPatch_Discard(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	repo.CommitFile("test.txt", "line1\nline2\nline3\n", "initial")
+	repo.WriteFile("test.txt", "line1\nchanged\nline3\nextra\n")
+
+	diff, err := GetDiff()
+	if err != nil {
+		t.Fatalf("GetDiff failed: %v", err)
+	}
+	if len(diff.Files) != 1 || len(diff.Files[0].Hunks) != 1 {
+		t.Fatal("expected a single hunk")
+	}
+	hunk := diff.Files[0].Hunks[0]
+
+	// Discard the added trailing line but keep the modification
+	selected := map[int]bool{}
+	for i, line := range hunk.Lines {
+		if line.Content == "+extra" {
+			selected[i] = true
+		}
+	}
+
+	if err := DiscardHunk(hunk.GeneratePartialPatch(&diff.Files[0], selected, true)); err != nil {
+		t.Fatalf("DiscardHunk failed: %v", err)
+	}
+
+	content := repo.ReadFile("test.txt")
+	if content != "line1\nchanged\nline3\n" {
+		t.Errorf("content = %q", content)
+	}
+}
+
+func TestHunk_Split(t *testing.T) {
+	hunk := Hunk{
+		Header:   "@@ -1,7 +1,7 @@ func main()",
+		StartOld: 1, CountOld: 7, StartNew: 1, CountNew: 7,
+		FilePath: "f.txt",
+		Lines: []DiffLine{
+			{Type: LineContext, Content: " a"},
+			{Type: LineRemoved, Content: "-b"},
+			{Type: LineAdded, Content: "+B"},
+			{Type: LineContext, Content: " c"},
+			{Type: LineContext, Content: " d"},
+			{Type: LineAdded, Content: "+new"},
+			{Type: LineContext, Content: " e"},
+			{Type: LineRemoved, Content: "-f"},
+			{Type: LineContext, Content: " g"},
+			{Type: LineContext, Content: ""},
+		},
+	}
+
+	subs := hunk.Split()
+	if len(subs) != 3 {
+		t.Fatalf("expected 3 sub-hunks, got %d", len(subs))
+	}
+
+	want := []struct {
+		header string
+		lines  int
+	}{
+		{"@@ -1,4 +1,4 @@ func main()", 5},
+		{"@@ -3,3 +3,4 @@ func main()", 4},
+		{"@@ -5,3 +6,2 @@ func main()", 3},
+	}
+	for i, w := range want {
+		if subs[i].Header != w.header {
+			t.Errorf("sub-hunk %d header = %q, want %q", i, subs[i].Header, w.header)
+		}
+		if len(subs[i].Lines) != w.lines {
+			t.Errorf("sub-hunk %d has %d lines, want %d", i, len(subs[i].Lines), w.lines)
+		}
+		if subs[i].FilePath != "f.txt" {
+			t.Errorf("sub-hunk %d should keep the file path", i)
+		}
+	}
+	if subs[1].StartOld != 3 || subs[1].CountOld != 3 || subs[1].StartNew != 3 || subs[1].CountNew != 4 {
+		t.Errorf("sub-hunk 1 range = -%d,%d +%d,%d", subs[1].StartOld, subs[1].CountOld, subs[1].StartNew, subs[1].CountNew)
+	}
+}
+
+func TestHunk_SplitSingleChange(t *testing.T) {
+	hunk := Hunk{
+		Header: "@@ -1,3 +1,3 @@",
+		Lines: []DiffLine{
+			{Type: LineContext, Content: " a"},
+			{Type: LineRemoved, Content: "-b"},
+			{Type: LineAdded, Content: "+B"},
+			{Type: LineContext, Content: " c"},
+		},
+	}
+
+	subs := hunk.Split()
+	if len(subs) != 1 || subs[0].Header != hunk.Header {
+		t.Errorf("hunk with one change should not be split, got %d sub-hunks", len(subs))
+	}
+}
+
+func TestHunk_SplitApply(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	repo.CommitFile("test.txt", "1\n2\n3\n4\n5\n6\n7\n8\n", "initial")
+	repo.WriteFile("test.txt", "1\ntwo\n3\n4\n5\nsix\n7\n8\n")
+
+	diff, err := GetDiff()
+	if err != nil {
+		t.Fatalf("GetDiff failed: %v", err)
+	}
+	if len(diff.Files) != 1 || len(diff.Files[0].Hunks) != 1 {
+		t.Fatal("expected a single hunk")
+	}
+	fileDiff := &diff.Files[0]
+	subs := fileDiff.Hunks[0].Split()
+	if len(subs) != 2 {
+		t.Fatalf("expected 2 sub-hunks, got %d", len(subs))
+	}
+
+	// Stage the second change only
+	if err := StageHunk(subs[1].GeneratePatch(fileDiff)); err != nil {
+		t.Fatalf("StageHunk failed: %v", err)
+	}
+	if staged := repo.Git("show", ":test.txt"); staged != "1\n2\n3\n4\n5\nsix\n7\n8\n" {
+		t.Errorf("index content = %q", staged)
+	}
+
+	// Discard the first change only
+	if err := DiscardHunk(subs[0].GeneratePatch(fileDiff)); err != nil {
+		t.Fatalf("DiscardHunk failed: %v", err)
+	}
+	if content := repo.ReadFile("test.txt"); content != "1\n2\n3\n4\n5\nsix\n7\n8\n" {
+		t.Errorf("content = %q", content)
+	}
+
+	// Unstage a sub-hunk of a staged hunk
+	repo.WriteFile("test.txt", "1\ntwo\n3\n4\n5\nsix\n7\n8\n")
+	repo.Git("add", "test.txt")
+	staged, err := GetStagedDiff()
+	if err != nil {
+		t.Fatalf("GetStagedDiff failed: %v", err)
+	}
+	subs = staged.Files[0].Hunks[0].Split()
+	if len(subs) != 2 {
+		t.Fatalf("expected 2 staged sub-hunks, got %d", len(subs))
+	}
+	if err := UnstageHunk(subs[0].GeneratePatch(&staged.Files[0])); err != nil {
+		t.Fatalf("UnstageHunk failed: %v", err)
+	}
+	if index := repo.Git("show", ":test.txt"); index != "1\n2\n3\n4\n5\nsix\n7\n8\n" {
+		t.Errorf("index content = %q", index)
+	}
+}
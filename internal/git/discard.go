@@ -0,0 +1,330 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DiscardedRef records what go-on-git discarded. Each discard is a commit
+// whose parent is the previous one, so they stay reachable and aren't
+// garbage collected.
+const DiscardedRef = "refs/go-on-git/discarded"
+
+// discardedPatch is the name of the patch in the tree of a discarded hunk
+const discardedPatch = "discarded.patch"
+
+// DiscardKind is what a discard threw away
+type DiscardKind int
+
+const (
+	DiscardedChanges   DiscardKind = iota // the changes to a tracked file
+	DiscardedUntracked                    // untracked files
+	DiscardedHunk                         // a hunk, or lines of a hunk
+)
+
+// String returns the name of the kind as recorded in the snapshot
+func (k DiscardKind) String() string {
+	switch k {
+	case DiscardedUntracked:
+		return "untracked"
+	case DiscardedHunk:
+		return "hunk"
+	default:
+		return "changes"
+	}
+}
+
+func parseDiscardKind(s string) DiscardKind {
+	switch s {
+	case "untracked":
+		return DiscardedUntracked
+	case "hunk":
+		return DiscardedHunk
+	default:
+		return DiscardedChanges
+	}
+}
+
+// Discarded is a snapshot taken before a discard
+type Discarded struct {
+	Hash string
+	Kind DiscardKind
+	Path string
+	Date time.Time
+}
+
+// Description describes what was discarded, e.g. "hunk in main.go"
+func (d Discarded) Description() string {
+	switch d.Kind {
+	case DiscardedUntracked:
+		return "untracked " + d.Path
+	case DiscardedHunk:
+		return "hunk in " + d.Path
+	default:
+		return "changes to " + d.Path
+	}
+}
+
+// GetDiscarded returns the snapshots of discarded work, newest first
+// (0 = no limit)
+func GetDiscarded(limit int) ([]Discarded, error) {
+	if _, err := Run("rev-parse", "--verify", "-q", DiscardedRef); err != nil {
+		return nil, nil
+	}
+	args := []string{"log", "--format=%H%x1f%ct%x1f%b%x1e"}
+	if limit > 0 {
+		args = append(args, fmt.Sprintf("-%d", limit))
+	}
+	args = append(args, DiscardedRef, "--")
+
+	output, err := Run(args...)
+	if err != nil {
+		return nil, err
+	}
+	return parseDiscarded(output), nil
+}
+
+func parseDiscarded(output string) []Discarded {
+	var discarded []Discarded
+	for _, record := range strings.Split(output, logRecordSep) {
+		record = strings.TrimLeft(record, "\n")
+		if record == "" {
+			continue
+		}
+		fields := strings.SplitN(record, logFieldSep, 3)
+		if len(fields) < 3 {
+			continue
+		}
+
+		d := Discarded{Hash: fields[0], Date: parseUnixTime(fields[1])}
+		for _, line := range strings.Split(fields[2], "\n") {
+			if kind, ok := strings.CutPrefix(line, "Kind: "); ok {
+				d.Kind = parseDiscardKind(kind)
+			} else if path, ok := strings.CutPrefix(line, "Path: "); ok {
+				d.Path = path
+			}
+		}
+		discarded = append(discarded, d)
+	}
+	return discarded
+}
+
+// snapshotFiles saves the working tree content of the files at paths
+// (paths that don't exist are recorded as deleted)
+func snapshotFiles(kind DiscardKind, path string, paths []string) (string, error) {
+	return snapshot(kind, path, func(dir string) ([]string, error) {
+		var entries []string
+		for _, p := range paths {
+			info, err := os.Lstat(filepath.Join(GetRepoRoot(), p))
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			mode, file := "100644", p
+			switch {
+			case info.Mode()&os.ModeSymlink != 0:
+				// The blob of a symlink is its target
+				target, err := os.Readlink(filepath.Join(GetRepoRoot(), p))
+				if err != nil {
+					return nil, err
+				}
+				mode, file = "120000", filepath.Join(dir, fmt.Sprintf("link-%d", len(entries)))
+				if err := os.WriteFile(file, []byte(target), 0644); err != nil {
+					return nil, err
+				}
+			case info.Mode()&0111 != 0:
+				mode = "100755"
+			}
+			hash, err := Run("hash-object", "-w", "--no-filters", "--", file)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, fmt.Sprintf("%s,%s,%s", mode, strings.TrimSpace(hash), p))
+		}
+		return entries, nil
+	})
+}
+
+// snapshotPatch saves the patch of a hunk about to be discarded
+func snapshotPatch(path, patch string) (string, error) {
+	return snapshot(DiscardedHunk, path, func(dir string) ([]string, error) {
+		file := filepath.Join(dir, discardedPatch)
+		if err := os.WriteFile(file, []byte(patch), 0644); err != nil {
+			return nil, err
+		}
+		hash, err := Run("hash-object", "-w", "--no-filters", "--", file)
+		if err != nil {
+			return nil, err
+		}
+		return []string{"100644," + strings.TrimSpace(hash) + "," + discardedPatch}, nil
+	})
+}
+
+// snapshot commits the index entries returned by entries (mode,hash,path as
+// taken by update-index --cacheinfo) on top of DiscardedRef, and returns the
+// commit. The tree is built in a temporary index, leaving the real one alone.
+// The snapshot only shows up as discarded once passed to recordSnapshot.
+func snapshot(kind DiscardKind, path string, entries func(dir string) ([]string, error)) (string, error) {
+	dir, err := os.MkdirTemp("", "go-on-git-discard-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+
+	cacheinfo, err := entries(dir)
+	if err != nil {
+		return "", fmt.Errorf("snapshot before discarding: %w", err)
+	}
+
+	env := []string{"GIT_INDEX_FILE=" + filepath.Join(dir, "index")}
+	if len(cacheinfo) > 0 {
+		args := []string{"update-index", "--add"}
+		for _, info := range cacheinfo {
+			args = append(args, "--cacheinfo", info)
+		}
+		if _, err := RunWithEnv(env, args...); err != nil {
+			return "", fmt.Errorf("snapshot before discarding: %w", err)
+		}
+	}
+	tree, err := RunWithEnv(env, "write-tree")
+	if err != nil {
+		return "", fmt.Errorf("snapshot before discarding: %w", err)
+	}
+
+	message := fmt.Sprintf("Discard %s %s\n\nKind: %s\nPath: %s\n", kind, path, kind, path)
+	args := []string{"commit-tree", strings.TrimSpace(tree), "-m", message}
+	if parent, err := Run("rev-parse", "--verify", "-q", DiscardedRef); err == nil {
+		args = append(args, "-p", strings.TrimSpace(parent))
+	}
+	commit, err := RunWithEnv(internalIdentity, args...)
+	if err != nil {
+		return "", fmt.Errorf("snapshot before discarding: %w", err)
+	}
+	return strings.TrimSpace(commit), nil
+}
+
+// recordSnapshot adds a snapshot to DiscardedRef, once the discard it was
+// taken for has succeeded
+func recordSnapshot(path, commit string) error {
+	_, err := Run("update-ref", "-m", "discard "+path, DiscardedRef, commit)
+	return err
+}
+
+// patchPath returns the path of the file a patch applies to
+func patchPath(patch string) string {
+	for _, line := range strings.Split(patch, "\n") {
+		if path, ok := strings.CutPrefix(line, "+++ b/"); ok {
+			return path
+		}
+		if path, ok := strings.CutPrefix(line, "--- a/"); ok {
+			return path
+		}
+	}
+	return ""
+}
+
+// RestoreDiscarded puts discarded work back into the working tree. Files are
+// only overwritten when they have no unstaged changes, so restoring can't
+// lose work either.
+func RestoreDiscarded(d Discarded) error {
+	if d.Kind == DiscardedHunk {
+		patch, err := Run("cat-file", "blob", d.Hash+":"+discardedPatch)
+		if err != nil {
+			return err
+		}
+		cmd := exec.Command("git", "apply")
+		cmd.Dir = GetRepoRoot()
+		cmd.Stdin = strings.NewReader(patch)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("git apply: %w: %s", err, stderr.String())
+		}
+		return nil
+	}
+
+	output, err := Run("ls-tree", "-r", "-z", d.Hash)
+	if err != nil {
+		return err
+	}
+	type file struct{ mode, hash, path string }
+	var files []file
+	for _, entry := range strings.Split(output, "\x00") {
+		info, path, ok := strings.Cut(entry, "\t")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(info)
+		if len(fields) < 3 {
+			continue
+		}
+		files = append(files, file{mode: fields[0], hash: fields[2], path: path})
+	}
+
+	// A tracked file missing from the snapshot had been deleted
+	if len(files) == 0 {
+		if err := checkRestorable(d.Path); err != nil {
+			return err
+		}
+		if err := os.Remove(filepath.Join(GetRepoRoot(), d.Path)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	for _, f := range files {
+		if err := checkRestorable(f.path); err != nil {
+			return err
+		}
+	}
+	for _, f := range files {
+		content, err := Run("cat-file", "blob", f.hash)
+		if err != nil {
+			return err
+		}
+		if err := writeWorktreeFile(f.path, f.mode, content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkRestorable refuses to restore over a file with unstaged changes or
+// an untracked file
+func checkRestorable(path string) error {
+	output, err := Run("status", "--porcelain=v1", "--", path)
+	if err != nil {
+		return err
+	}
+	for _, line := range strings.Split(output, "\n") {
+		if len(line) > 1 && line[1] != ' ' {
+			return fmt.Errorf("'%s' has changes, discard or stash them before restoring", path)
+		}
+	}
+	return nil
+}
+
+func writeWorktreeFile(path, mode, content string) error {
+	full := filepath.Join(GetRepoRoot(), path)
+	if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+		return err
+	}
+	if err := os.Remove(full); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	switch mode {
+	case "120000":
+		return os.Symlink(content, full)
+	case "100755":
+		return os.WriteFile(full, []byte(content), 0755)
+	default:
+		return os.WriteFile(full, []byte(content), 0644)
+	}
+}
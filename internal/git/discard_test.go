@@ -0,0 +1,223 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGetDiscardedNone(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+
+	discarded, err := GetDiscarded(0)
+	if err != nil {
+		t.Fatalf("GetDiscarded failed: %v", err)
+	}
+	if len(discarded) != 0 {
+		t.Errorf("expected nothing discarded, got %d", len(discarded))
+	}
+}
+
+func TestRestoreDiscardedFile(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.CommitFile("test.txt", "original\n", "initial")
+	repo.WriteFile("test.txt", "precious work\n")
+
+	if err := DiscardFile("test.txt"); err != nil {
+		t.Fatalf("DiscardFile failed: %v", err)
+	}
+
+	discarded, err := GetDiscarded(0)
+	if err != nil {
+		t.Fatalf("GetDiscarded failed: %v", err)
+	}
+	if len(discarded) != 1 {
+		t.Fatalf("expected 1 snapshot, got %d", len(discarded))
+	}
+	d := discarded[0]
+	if d.Kind != DiscardedChanges || d.Path != "test.txt" || d.Date.IsZero() {
+		t.Errorf("unexpected snapshot: %+v", d)
+	}
+	if d.Description() != "changes to test.txt" {
+		t.Errorf("unexpected description %q", d.Description())
+	}
+	// The snapshot is kept out of the branches and the index
+	if log := repo.Git("log", "--oneline", "--branches"); strings.Count(log, "\n") != 1 {
+		t.Errorf("the snapshot should not be on a branch, got %q", log)
+	}
+	if status := repo.Git("status", "--porcelain"); status != "" {
+		t.Errorf("expected a clean status after the discard, got %q", status)
+	}
+
+	if err := RestoreDiscarded(d); err != nil {
+		t.Fatalf("RestoreDiscarded failed: %v", err)
+	}
+	if content := repo.ReadFile("test.txt"); content != "precious work\n" {
+		t.Errorf("expected the discarded content back, got %q", content)
+	}
+
+	// Restoring again would overwrite the file's changes
+	if err := RestoreDiscarded(d); err == nil || !strings.Contains(err.Error(), "has changes") {
+		t.Errorf("expected a 'has changes' error, got %v", err)
+	}
+}
+
+func TestRestoreDiscardedDeletion(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.CommitFile("test.txt", "original\n", "initial")
+	repo.DeleteFile("test.txt")
+
+	if err := DiscardFile("test.txt"); err != nil {
+		t.Fatalf("DiscardFile failed: %v", err)
+	}
+	discarded, err := GetDiscarded(1)
+	if err != nil || len(discarded) != 1 {
+		t.Fatalf("GetDiscarded = %v, %v", discarded, err)
+	}
+
+	if err := RestoreDiscarded(discarded[0]); err != nil {
+		t.Fatalf("RestoreDiscarded failed: %v", err)
+	}
+	if repo.FileExists("test.txt") {
+		t.Error("restoring a discarded deletion should delete the file again")
+	}
+}
+
+func TestRestoreDiscardedUntracked(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+	repo.WriteFile("notes/a.txt", "a\n")
+	repo.WriteFile("notes/b.sh", "echo b\n")
+	if err := os.Chmod(filepath.Join(repo.Dir, "notes/b.sh"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// The status shows the untracked directory, not its files
+	if err := DiscardUntracked("notes/"); err != nil {
+		t.Fatalf("DiscardUntracked failed: %v", err)
+	}
+	if repo.FileExists("notes/a.txt") || repo.FileExists("notes/b.sh") {
+		t.Fatal("the untracked files should be removed")
+	}
+
+	discarded, err := GetDiscarded(0)
+	if err != nil || len(discarded) != 1 {
+		t.Fatalf("GetDiscarded = %v, %v", discarded, err)
+	}
+	if discarded[0].Kind != DiscardedUntracked || discarded[0].Path != "notes/" {
+		t.Errorf("unexpected snapshot: %+v", discarded[0])
+	}
+
+	if err := RestoreDiscarded(discarded[0]); err != nil {
+		t.Fatalf("RestoreDiscarded failed: %v", err)
+	}
+	if repo.ReadFile("notes/a.txt") != "a\n" || repo.ReadFile("notes/b.sh") != "echo b\n" {
+		t.Error("the untracked files should be restored")
+	}
+	info, err := os.Stat(filepath.Join(repo.Dir, "notes/b.sh"))
+	if err != nil || info.Mode()&0111 == 0 {
+		t.Error("the executable bit should be restored")
+	}
+
+	// An untracked file that came back in the meantime isn't overwritten
+	if err := RestoreDiscarded(discarded[0]); err == nil {
+		t.Error("expected an error restoring over untracked files")
+	}
+}
+
+func TestRestoreDiscardedHunk(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.CommitFile("test.txt", "line1\nline2\nline3\n", "initial")
+	repo.WriteFile("test.txt", "line1\nmodified\nline3\n")
+
+	diff, err := GetDiff()
+	if err != nil {
+		t.Fatalf("GetDiff failed: %v", err)
+	}
+	if err := DiscardHunk(diff.Files[0].Hunks[0].GeneratePatch(&diff.Files[0])); err != nil {
+		t.Fatalf("DiscardHunk failed: %v", err)
+	}
+
+	discarded, err := GetDiscarded(0)
+	if err != nil || len(discarded) != 1 {
+		t.Fatalf("GetDiscarded = %v, %v", discarded, err)
+	}
+	if discarded[0].Kind != DiscardedHunk || discarded[0].Path != "test.txt" {
+		t.Errorf("unexpected snapshot: %+v", discarded[0])
+	}
+
+	if err := RestoreDiscarded(discarded[0]); err != nil {
+		t.Fatalf("RestoreDiscarded failed: %v", err)
+	}
+	if content := repo.ReadFile("test.txt"); content != "line1\nmodified\nline3\n" {
+		t.Errorf("expected the hunk back, got %q", content)
+	}
+}
+
+func TestDiscardedKeepsEverySnapshot(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+
+	for _, name := range []string{"one.txt", "two.txt", "three.txt"} {
+		repo.WriteFile(name, name)
+		if err := DiscardUntracked(name); err != nil {
+			t.Fatalf("DiscardUntracked failed: %v", err)
+		}
+	}
+
+	discarded, err := GetDiscarded(0)
+	if err != nil {
+		t.Fatalf("GetDiscarded failed: %v", err)
+	}
+	if len(discarded) != 3 || discarded[0].Path != "three.txt" || discarded[2].Path != "one.txt" {
+		t.Fatalf("expected the 3 snapshots newest first, got %+v", discarded)
+	}
+
+	// Restoring an older snapshot brings back only its files
+	if err := RestoreDiscarded(discarded[2]); err != nil {
+		t.Fatalf("RestoreDiscarded failed: %v", err)
+	}
+	if !repo.FileExists("one.txt") || repo.FileExists("two.txt") {
+		t.Error("only one.txt should be restored")
+	}
+}
+
+func TestFailedDiscardLeavesNoSnapshot(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.CommitFile("test.txt", "line1\nline2\nline3\n", "initial")
+	repo.WriteFile("test.txt", "line1\nmodified\nline3\n")
+
+	diff, err := GetDiff()
+	if err != nil {
+		t.Fatalf("GetDiff failed: %v", err)
+	}
+	patch := diff.Files[0].Hunks[0].GeneratePatch(&diff.Files[0])
+	if err := DiscardHunk(patch); err != nil {
+		t.Fatalf("DiscardHunk failed: %v", err)
+	}
+
+	// The hunk is already gone, so discarding it again fails
+	if err := DiscardHunk(patch); err == nil {
+		t.Fatal("expected discarding the hunk twice to fail")
+	}
+	if err := DiscardFile("missing.txt"); err == nil {
+		t.Fatal("expected discarding a missing file to fail")
+	}
+
+	discarded, err := GetDiscarded(0)
+	if err != nil {
+		t.Fatalf("GetDiscarded failed: %v", err)
+	}
+	if len(discarded) != 1 {
+		t.Errorf("failed discards should not be recorded, got %d snapshots", len(discarded))
+	}
+}
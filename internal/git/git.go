@@ -11,31 +11,41 @@ import (
 )
 
 var (
-	repoRoot     string
-	repoRootOnce sync.Once
-	gitMu        sync.Mutex // serializes all git operations
+	repoRoot       string
+	repoRootCached bool
+	repoRootMu     sync.Mutex // guards repoRoot, which changes when switching worktrees
+	gitMu          sync.Mutex // serializes all git operations
 )
 
 // GetRepoRoot returns the git repository root directory.
 // The result is cached for efficiency.
 func GetRepoRoot() string {
-	repoRootOnce.Do(func() {
+	repoRootMu.Lock()
+	defer repoRootMu.Unlock()
+
+	if !repoRootCached {
+		repoRoot = ""
 		cmd := exec.Command("git", "rev-parse", "--show-toplevel")
-		output, err := cmd.Output()
-		if err != nil {
-			repoRoot = ""
-			return
+		if output, err := cmd.Output(); err == nil {
+			repoRoot = strings.TrimSpace(string(output))
 		}
-		repoRoot = strings.TrimSpace(string(output))
-	})
+		repoRootCached = true
+	}
 	return repoRoot
 }
 
-// ResetRepoRoot clears the cached repository root.
-// This is primarily for testing purposes.
+// ResetRepoRoot clears the cached repository root, so that it is looked up
+// again from the working directory. Used when switching worktrees and by tests.
 func ResetRepoRoot() {
-	repoRootOnce = sync.Once{}
+	repoRootMu.Lock()
+	defer repoRootMu.Unlock()
+	resetRepoRootLocked()
+}
+
+// resetRepoRootLocked clears the cached repository root. The caller holds repoRootMu.
+func resetRepoRootLocked() {
 	repoRoot = ""
+	repoRootCached = false
 }
 
 // IsLocked returns true if a git operation is in progress (index.lock exists)
@@ -73,13 +83,31 @@ func ToDisplayPath(repoRelativePath string) string {
 	return relPath
 }
 
+// internalIdentity is used for the commits go-on-git makes for its own
+// bookkeeping (discard snapshots, notes), so they work without user.name set
+var internalIdentity = []string{
+	"GIT_AUTHOR_NAME=go-on-git",
+	"GIT_AUTHOR_EMAIL=go-on-git@localhost",
+	"GIT_COMMITTER_NAME=go-on-git",
+	"GIT_COMMITTER_EMAIL=go-on-git@localhost",
+}
+
 // Run executes a git command and returns the output
 func Run(args ...string) (string, error) {
+	return RunWithEnv(nil, args...)
+}
+
+// RunWithEnv executes a git command with extra environment variables
+// (e.g. "GIT_EDITOR=true") and returns the output
+func RunWithEnv(env []string, args ...string) (string, error) {
 	gitMu.Lock()
 	defer gitMu.Unlock()
 
 	cmd := exec.Command("git", args...)
 	cmd.Dir = GetRepoRoot()
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
@@ -152,36 +180,48 @@ func UnstageAll() error {
 
 // StashAll stashes all changes with an optional message
 func StashAll(message string) error {
-	if message == "" {
-		_, err := Run("stash", "push")
-		return err
-	}
-	_, err := Run("stash", "push", "-m", message)
-	return err
+	return StashPush(nil, message, StashOptions{})
 }
 
 // StashFiles stashes specific files with an optional message
 func StashFiles(paths []string, message string) error {
-	args := []string{"stash", "push"}
-	if message != "" {
-		args = append(args, "-m", message)
-	}
-	args = append(args, "--")
-	args = append(args, paths...)
-	_, err := Run(args...)
-	return err
+	return StashPush(paths, message, StashOptions{})
 }
 
-// DiscardFile discards changes to a tracked file
+// DiscardFile discards changes to a tracked file, and records them under
+// DiscardedRef
 func DiscardFile(path string) error {
-	_, err := Run("restore", "--", path)
-	return err
+	snapshot, err := snapshotFiles(DiscardedChanges, path, []string{path})
+	if err != nil {
+		return err
+	}
+	if _, err := Run("restore", "--", path); err != nil {
+		return err
+	}
+	return recordSnapshot(path, snapshot)
 }
 
-// DiscardUntracked removes an untracked file
+// DiscardUntracked removes an untracked file (or the untracked files in a
+// directory), and records them under DiscardedRef
 func DiscardUntracked(path string) error {
-	_, err := Run("clean", "-f", "--", path)
-	return err
+	output, err := Run("ls-files", "--others", "--exclude-standard", "-z", "--", path)
+	if err != nil {
+		return err
+	}
+	var paths []string
+	for _, p := range strings.Split(output, "\x00") {
+		if p != "" {
+			paths = append(paths, p)
+		}
+	}
+	snapshot, err := snapshotFiles(DiscardedUntracked, path, paths)
+	if err != nil {
+		return err
+	}
+	if _, err := Run("clean", "-f", "--", path); err != nil {
+		return err
+	}
+	return recordSnapshot(path, snapshot)
 }
 
 // StageHunk stages a specific hunk using patch mode
@@ -214,21 +254,93 @@ func UnstageHunk(patch string) error {
 	return nil
 }
 
-// DiscardHunk discards a specific hunk from the working tree
+// DiscardHunk discards a specific hunk from the working tree, and records
+// the patch under DiscardedRef
 func DiscardHunk(patch string) error {
+	path := patchPath(patch)
+	snapshot, err := snapshotPatch(path, patch)
+	if err != nil {
+		return err
+	}
+
 	cmd := exec.Command("git", "apply", "--reverse")
 	cmd.Dir = GetRepoRoot()
 	cmd.Stdin = strings.NewReader(patch)
 	var stderr bytes.Buffer
 	cmd.Stderr = &stderr
 
-	err := cmd.Run()
-	if err != nil {
+	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("git apply --reverse: %w: %s", err, stderr.String())
 	}
+	return recordSnapshot(path, snapshot)
+}
+
+// StageEditedHunk stages a hunk patch that was edited by hand (like the "e"
+// command of git add -p). Lines starting with # are ignored and the hunk
+// line counts are recomputed, so they don't need to be fixed by hand.
+// An empty patch means the edit was aborted, and nothing is staged.
+func StageEditedHunk(content string) error {
+	patch, err := cleanEditedPatch(content)
+	if err != nil || patch == "" {
+		return err
+	}
+
+	// Check first so a broken edit can't leave the index half-updated
+	for _, args := range [][]string{
+		{"apply", "--cached", "--recount", "--check"},
+		{"apply", "--cached", "--recount"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = GetRepoRoot()
+		cmd.Stdin = strings.NewReader(patch)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("edited hunk does not apply: %w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+	}
 	return nil
 }
 
+// cleanEditedPatch strips comment lines from an edited patch and checks that
+// it still looks like a patch. Returns "" if nothing is left.
+func cleanEditedPatch(content string) (string, error) {
+	var lines []string
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	patch := strings.TrimRight(strings.Join(lines, "\n"), "\n")
+	if strings.TrimSpace(patch) == "" {
+		return "", nil
+	}
+
+	hasHeader, hasHunk := false, false
+	for _, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "diff --git"):
+			hasHeader = true
+		case strings.HasPrefix(line, "@@"):
+			hasHunk = true
+		case !hasHunk || line == "":
+		case strings.HasPrefix(line, " "), strings.HasPrefix(line, "+"),
+			strings.HasPrefix(line, "-"), strings.HasPrefix(line, "\\"):
+		default:
+			return "", fmt.Errorf("edited hunk has an invalid line (must start with ' ', '+' or '-'): %q", line)
+		}
+	}
+	if !hasHeader {
+		return "", fmt.Errorf("edited hunk is missing the diff header")
+	}
+	if !hasHunk {
+		return "", fmt.Errorf("edited hunk is missing the @@ line")
+	}
+	return patch + "\n", nil
+}
+
 // IsGitRepo checks if the current directory is a git repository
 func IsGitRepo() bool {
 	_, err := Run("rev-parse", "--git-dir")
@@ -252,6 +364,12 @@ type BranchStatus struct {
 	Behind  int
 }
 
+// HeadPushed returns true if HEAD is already on the upstream branch,
+// so rewriting it would rewrite published history
+func (s BranchStatus) HeadPushed() bool {
+	return s.Remote != "" && s.Ahead == 0
+}
+
 // Push pushes to the remote
 func Push() error {
 	_, err := Run("push")
@@ -264,6 +382,54 @@ func PushSetUpstream(remote, branch string) error {
 	return err
 }
 
+// Fetch downloads objects and refs from a remote. With prune, remote-tracking
+// branches that no longer exist on the remote are deleted.
+func Fetch(remote string, prune bool) error {
+	args := []string{"fetch"}
+	if prune {
+		args = append(args, "--prune")
+	}
+	args = append(args, remote)
+	_, err := Run(args...)
+	return err
+}
+
+// PullMode selects how Pull integrates the upstream branch
+type PullMode int
+
+const (
+	PullMerge  PullMode = iota // Merge the upstream branch (pull --no-rebase)
+	PullRebase                 // Rebase onto the upstream branch (pull --rebase)
+	PullFFOnly                 // Only fast-forward (pull --ff-only)
+)
+
+// String returns the name of the pull mode
+func (p PullMode) String() string {
+	switch p {
+	case PullRebase:
+		return "rebase"
+	case PullFFOnly:
+		return "ff-only"
+	default:
+		return "merge"
+	}
+}
+
+// Pull fetches the upstream of the current branch and integrates it
+func Pull(mode PullMode) error {
+	args := []string{"pull"}
+	switch mode {
+	case PullRebase:
+		args = append(args, "--rebase")
+	case PullFFOnly:
+		args = append(args, "--ff-only")
+	default:
+		args = append(args, "--no-rebase")
+	}
+	_, err := Run(args...)
+	return err
+}
+
 // GetRemotes returns the list of configured remotes
 func GetRemotes() ([]string, error) {
 	output, err := Run("remote")
@@ -283,6 +449,19 @@ func Commit(message string) error {
 	return err
 }
 
+// AmendCommit replaces the HEAD commit with the staged changes and a new message
+func AmendCommit(message string) error {
+	_, err := Run("commit", "--amend", "-m", message)
+	return err
+}
+
+// AmendCommitNoEdit replaces the HEAD commit with the staged changes,
+// keeping its message
+func AmendCommitNoEdit() error {
+	_, err := Run("commit", "--amend", "--no-edit")
+	return err
+}
+
 // GetLog returns the raw git log output
 func GetLog(limit int) (string, error) {
 	return Run("log", fmt.Sprintf("-%d", limit))
@@ -391,6 +391,206 @@ func TestGetBranchStatusWithRemote(t *testing.T) {
 	}
 }
 
+// setupPullTest pushes the current branch to a bare remote and commits
+// upstream.txt to it from another clone, leaving the repo one commit behind
+// once fetched
+func setupPullTest(t *testing.T, repo *TestRepo) {
+	t.Helper()
+	repo.InitialCommit()
+	remoteDir := repo.SetupRemote()
+	t.Cleanup(func() { os.RemoveAll(remoteDir) })
+	repo.PushToRemote()
+
+	clone := repo.CloneRemote(remoteDir, GetBranch())
+	if err := os.WriteFile(clone+"/upstream.txt", []byte("upstream\n"), 0644); err != nil {
+		t.Fatalf("failed to write file in clone: %v", err)
+	}
+	repo.GitIn(clone, "add", "upstream.txt")
+	repo.GitIn(clone, "commit", "-m", "upstream commit")
+	repo.GitIn(clone, "push")
+}
+
+func TestFetch(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	setupPullTest(t, repo)
+
+	if status := GetBranchStatus(); status.Behind != 0 {
+		t.Fatalf("expected 0 behind before fetch, got %d", status.Behind)
+	}
+
+	if err := Fetch("origin", false); err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+
+	status := GetBranchStatus()
+	if status.Behind != 1 || status.Ahead != 0 {
+		t.Errorf("expected 1 behind and 0 ahead after fetch, got %d behind, %d ahead", status.Behind, status.Ahead)
+	}
+	if repo.FileExists("upstream.txt") {
+		t.Error("fetch should not update the working tree")
+	}
+}
+
+func TestFetchPrune(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	repo.InitialCommit()
+	remoteDir := repo.SetupRemote()
+	defer os.RemoveAll(remoteDir)
+	repo.PushToRemote()
+	repo.Git("push", "origin", "HEAD:feature")
+	repo.Git("fetch", "origin")
+
+	// Delete the branch on the remote behind our back
+	repo.GitIn(remoteDir, "branch", "-D", "feature")
+
+	if err := Fetch("origin", false); err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	if _, err := repo.GitAllowFailure("rev-parse", "--verify", "refs/remotes/origin/feature"); err != nil {
+		t.Error("fetch without prune should keep origin/feature")
+	}
+
+	if err := Fetch("origin", true); err != nil {
+		t.Fatalf("Fetch with prune failed: %v", err)
+	}
+	if _, err := repo.GitAllowFailure("rev-parse", "--verify", "refs/remotes/origin/feature"); err == nil {
+		t.Error("fetch with prune should delete origin/feature")
+	}
+}
+
+func TestFetchUnknownRemote(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+
+	if err := Fetch("nope", false); err == nil {
+		t.Error("expected error fetching from an unknown remote")
+	}
+}
+
+func TestPull(t *testing.T) {
+	for _, mode := range []PullMode{PullMerge, PullRebase, PullFFOnly} {
+		t.Run(mode.String(), func(t *testing.T) {
+			repo := NewTestRepo(t)
+			defer repo.Cleanup()
+			setupPullTest(t, repo)
+
+			if err := Pull(mode); err != nil {
+				t.Fatalf("Pull(%v) failed: %v", mode, err)
+			}
+			if !repo.FileExists("upstream.txt") {
+				t.Error("pull should bring in upstream.txt")
+			}
+			status := GetBranchStatus()
+			if status.Ahead != 0 || status.Behind != 0 {
+				t.Errorf("expected to be up to date, got %d ahead, %d behind", status.Ahead, status.Behind)
+			}
+		})
+	}
+}
+
+func TestPullDiverged(t *testing.T) {
+	tests := []struct {
+		mode        PullMode
+		wantErr     bool
+		wantParents int // parents of HEAD after the pull
+	}{
+		{PullMerge, false, 2},
+		{PullRebase, false, 1},
+		{PullFFOnly, true, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode.String(), func(t *testing.T) {
+			repo := NewTestRepo(t)
+			defer repo.Cleanup()
+			setupPullTest(t, repo)
+			repo.CommitFile("local.txt", "local\n", "local commit")
+
+			err := Pull(tt.mode)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Pull(%v) error = %v, wantErr %v", tt.mode, err, tt.wantErr)
+			}
+			parents := strings.Fields(repo.Git("rev-list", "--parents", "-n", "1", "HEAD"))
+			if len(parents)-1 != tt.wantParents {
+				t.Errorf("HEAD has %d parents, want %d", len(parents)-1, tt.wantParents)
+			}
+			if tt.wantErr {
+				return
+			}
+			status := GetBranchStatus()
+			if status.Ahead != 1 && tt.mode == PullRebase {
+				t.Errorf("expected the local commit to be rebased on top (1 ahead), got %d ahead", status.Ahead)
+			}
+			if status.Behind != 0 {
+				t.Errorf("expected 0 behind after pull, got %d", status.Behind)
+			}
+		})
+	}
+}
+
+func TestBranchStatusHeadPushed(t *testing.T) {
+	tests := []struct {
+		status BranchStatus
+		want   bool
+	}{
+		{BranchStatus{Name: "main"}, false},
+		{BranchStatus{Name: "main", Remote: "origin/main"}, true},
+		{BranchStatus{Name: "main", Remote: "origin/main", Behind: 2}, true},
+		{BranchStatus{Name: "main", Remote: "origin/main", Ahead: 1}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.status.HeadPushed(); got != tt.want {
+			t.Errorf("%+v HeadPushed() = %v, want %v", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestAmendCommit(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	repo.CommitFile("file.txt", "content", "original message")
+	repo.WriteFile("extra.txt", "extra")
+	repo.Git("add", "extra.txt")
+
+	if err := AmendCommit("new message"); err != nil {
+		t.Fatalf("AmendCommit failed: %v", err)
+	}
+
+	if count := strings.TrimSpace(repo.Git("rev-list", "--count", "HEAD")); count != "1" {
+		t.Errorf("expected amend to keep 1 commit, got %s", count)
+	}
+	if msg := strings.TrimSpace(repo.Git("log", "-1", "--format=%s")); msg != "new message" {
+		t.Errorf("message = %q, want %q", msg, "new message")
+	}
+	if files := repo.Git("show", "--name-only", "--format=", "HEAD"); !strings.Contains(files, "extra.txt") {
+		t.Errorf("amended commit should include staged file, got %q", files)
+	}
+}
+
+func TestAmendCommitNoEdit(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	repo.CommitFile("file.txt", "content", "keep this message")
+	repo.WriteFile("file.txt", "changed")
+	repo.Git("add", "file.txt")
+
+	if err := AmendCommitNoEdit(); err != nil {
+		t.Fatalf("AmendCommitNoEdit failed: %v", err)
+	}
+
+	if msg := strings.TrimSpace(repo.Git("log", "-1", "--format=%s")); msg != "keep this message" {
+		t.Errorf("message = %q, want original message", msg)
+	}
+	if content := repo.Git("show", "HEAD:file.txt"); content != "changed" {
+		t.Errorf("amended content = %q, want %q", content, "changed")
+	}
+}
+
 func TestGetLog(t *testing.T) {
 	repo := NewTestRepo(t)
 	defer repo.Cleanup()
@@ -538,3 +738,89 @@ func TestDiscardHunk(t *testing.T) {
 		t.Errorf("expected original content, got: %s", content)
 	}
 }
+
+func TestStageEditedHunk(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	repo.CommitFile("test.txt", "line1\nline2\nline3\n", "initial")
+	repo.WriteFile("test.txt", "line1\nchanged\nline3\n")
+
+	diff, err := GetDiff()
+	if err != nil {
+		t.Fatalf("GetDiff failed: %v", err)
+	}
+	patch := diff.Files[0].Hunks[0].GeneratePatch(&diff.Files[0])
+
+	// Keep the removal as context and stage a different addition; the
+	// @@ counts are now wrong and must be recounted
+	edited := strings.Replace(patch, "-line2\n+changed\n", " line2\n+inserted\n", 1)
+	edited += "# comment lines are ignored\n"
+
+	if err := StageEditedHunk(edited); err != nil {
+		t.Fatalf("StageEditedHunk failed: %v", err)
+	}
+	if staged := repo.Git("show", ":test.txt"); staged != "line1\nline2\ninserted\nline3\n" {
+		t.Errorf("index content = %q", staged)
+	}
+	if content := repo.ReadFile("test.txt"); content != "line1\nchanged\nline3\n" {
+		t.Errorf("working tree should be untouched, got %q", content)
+	}
+}
+
+func TestStageEditedHunkAborted(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	repo.CommitFile("test.txt", "line1\n", "initial")
+	repo.WriteFile("test.txt", "changed\n")
+
+	if err := StageEditedHunk("# everything deleted\n\n"); err != nil {
+		t.Fatalf("empty edit should abort without error, got %v", err)
+	}
+	staged, err := GetStagedDiff()
+	if err != nil {
+		t.Fatalf("GetStagedDiff failed: %v", err)
+	}
+	if !staged.IsEmpty() {
+		t.Error("nothing should be staged after an aborted edit")
+	}
+}
+
+func TestStageEditedHunkInvalid(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	repo.CommitFile("test.txt", "line1\nline2\n", "initial")
+	repo.WriteFile("test.txt", "line1\nchanged\n")
+
+	diff, err := GetDiff()
+	if err != nil {
+		t.Fatalf("GetDiff failed: %v", err)
+	}
+	patch := diff.Files[0].Hunks[0].GeneratePatch(&diff.Files[0])
+
+	tests := []struct {
+		name   string
+		edited string
+	}{
+		{"invalid line", strings.Replace(patch, "+changed", "changed", 1)},
+		{"missing hunk header", strings.Replace(patch, "@@", "", 2)},
+		{"does not apply", strings.Replace(patch, " line1", " other", 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := StageEditedHunk(tt.edited); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+
+	staged, err := GetStagedDiff()
+	if err != nil {
+		t.Fatalf("GetStagedDiff failed: %v", err)
+	}
+	if !staged.IsEmpty() {
+		t.Error("a failed edit should not stage anything")
+	}
+}
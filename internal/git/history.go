@@ -0,0 +1,56 @@
+package git
+
+import "fmt"
+
+// CherryPick applies the changes of the given commits on top of HEAD, in
+// the order given (oldest first). It stops with conflicts like git does,
+// leaving a cherry-pick in progress.
+func CherryPick(hashes ...string) error {
+	if len(hashes) == 0 {
+		return fmt.Errorf("no commits to cherry-pick")
+	}
+	args := append([]string{"cherry-pick"}, hashes...)
+	_, err := Run(args...)
+	return err
+}
+
+// Revert creates commits undoing the given commits, in the order given
+// (newest first so later changes are undone before the ones they build on)
+func Revert(hashes ...string) error {
+	if len(hashes) == 0 {
+		return fmt.Errorf("no commits to revert")
+	}
+	args := append([]string{"revert", "--no-edit"}, hashes...)
+	_, err := Run(args...)
+	return err
+}
+
+// ResetMode selects what git reset does to the index and working tree
+type ResetMode int
+
+const (
+	ResetSoft  ResetMode = iota // keep the index and working tree
+	ResetMixed                  // reset the index, keep the working tree
+	ResetHard                   // reset the index and working tree
+	ResetKeep                   // reset the index and working tree, but keep uncommitted changes
+)
+
+// String returns the reset mode as named by git reset
+func (m ResetMode) String() string {
+	switch m {
+	case ResetSoft:
+		return "soft"
+	case ResetHard:
+		return "hard"
+	case ResetKeep:
+		return "keep"
+	default:
+		return "mixed"
+	}
+}
+
+// Reset moves the current branch to the given commit
+func Reset(hash string, mode ResetMode) error {
+	_, err := Run("reset", "--"+mode.String(), hash)
+	return err
+}
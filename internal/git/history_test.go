@@ -0,0 +1,106 @@
+package git
+
+import (
+	"strings"
+	"testing"
+)
+
+// commitHashes returns the hashes of the commits on a branch, newest first
+func commitHashes(repo *TestRepo, ref string) []string {
+	repo.T.Helper()
+	return strings.Fields(repo.Git("log", "--format=%H", ref))
+}
+
+func TestCherryPick(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+	repo.CreateBranch("feature", true)
+	repo.CommitFile("one.txt", "one\n", "One")
+	repo.CommitFile("two.txt", "two\n", "Two")
+	feature := commitHashes(repo, "feature")
+	repo.Git("checkout", "-")
+
+	// Oldest first, so the picks keep their original order
+	if err := CherryPick(feature[1], feature[0]); err != nil {
+		t.Fatalf("CherryPick failed: %v", err)
+	}
+	log := repo.Git("log", "--format=%s")
+	if !strings.HasPrefix(log, "Two\nOne\n") {
+		t.Errorf("expected both commits picked in order, got:\n%s", log)
+	}
+}
+
+func TestCherryPickConflict(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	setupConflictingBranches(repo)
+
+	if err := CherryPick("feature"); err == nil {
+		t.Fatal("expected the cherry-pick to stop with conflicts")
+	}
+	if op := GetOperation(); op.Kind != OperationCherryPick {
+		t.Errorf("expected a cherry-pick in progress, got %+v", op)
+	}
+}
+
+func TestCherryPickNothing(t *testing.T) {
+	if err := CherryPick(); err == nil {
+		t.Error("expected an error without commits")
+	}
+}
+
+func TestRevert(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+	repo.CommitFile("file.txt", "one\n", "One")
+	repo.CommitFile("file.txt", "two\n", "Two")
+	hashes := commitHashes(repo, "HEAD")
+
+	if err := Revert(hashes[0], hashes[1]); err != nil {
+		t.Fatalf("Revert failed: %v", err)
+	}
+	if repo.FileExists("file.txt") {
+		t.Error("expected both commits to be undone")
+	}
+	if subject := strings.TrimSpace(repo.Git("log", "-1", "--format=%s")); subject != `Revert "One"` {
+		t.Errorf("expected a revert commit, got %q", subject)
+	}
+}
+
+func TestReset(t *testing.T) {
+	tests := []struct {
+		mode     ResetMode
+		staged   bool
+		unstaged bool
+	}{
+		{ResetSoft, true, false},
+		{ResetMixed, false, true},
+		{ResetHard, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode.String(), func(t *testing.T) {
+			repo := NewTestRepo(t)
+			defer repo.Cleanup()
+			repo.InitialCommit()
+			repo.CommitFile("file.txt", "one\n", "One")
+			repo.CommitFile("file.txt", "two\n", "Two")
+			target := commitHashes(repo, "HEAD")[1]
+
+			if err := Reset(target, tt.mode); err != nil {
+				t.Fatalf("Reset failed: %v", err)
+			}
+			if head := commitHashes(repo, "HEAD")[0]; head != target {
+				t.Errorf("expected HEAD at %s, got %s", target, head)
+			}
+			status, _ := GetStatus()
+			if got := len(status.Staged) > 0; got != tt.staged {
+				t.Errorf("staged changes = %v, want %v", got, tt.staged)
+			}
+			if got := len(status.Unstaged) > 0; got != tt.unstaged {
+				t.Errorf("unstaged changes = %v, want %v", got, tt.unstaged)
+			}
+		})
+	}
+}
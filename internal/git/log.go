@@ -0,0 +1,206 @@
+package git
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RefType identifies the kind of ref decorating a commit
+type RefType int
+
+const (
+	RefHead RefType = iota // Detached HEAD
+	RefBranch
+	RefRemoteBranch
+	RefTag
+	RefOther
+)
+
+// Ref is a ref that points at a commit (e.g., a branch or tag)
+type Ref struct {
+	Name   string // Short name (e.g., "main", "origin/main", "v1.0")
+	Type   RefType
+	IsHead bool // True for the branch HEAD points to
+}
+
+// CommitInfo represents a single commit in the log
+type CommitInfo struct {
+	Hash           string
+	ShortHash      string
+	Parents        []string
+	AuthorName     string
+	AuthorEmail    string
+	AuthorDate     time.Time
+	CommitterName  string
+	CommitterEmail string
+	CommitDate     time.Time
+	Subject        string
+	Body           string
+	Refs           []Ref
+}
+
+// IsMerge returns true if the commit has more than one parent
+func (c CommitInfo) IsMerge() bool {
+	return len(c.Parents) > 1
+}
+
+// Message returns the full commit message (subject and body)
+func (c CommitInfo) Message() string {
+	if c.Body == "" {
+		return c.Subject
+	}
+	return c.Subject + "\n\n" + c.Body
+}
+
+// LogOptions controls which commits GetCommits returns
+type LogOptions struct {
+	Limit     int    // Maximum number of commits (0 = no limit)
+	Revision  string // Revision or range to list (empty = HEAD)
+	TopoOrder bool   // Show children before parents (needed for graph layout)
+}
+
+const (
+	logFieldSep  = "\x1f"
+	logRecordSep = "\x1e"
+)
+
+// Fields: hash, short hash, parents, author name/email/date,
+// committer name/email/date, decorations, subject, body
+var logFormat = strings.Join([]string{
+	"%H", "%h", "%P", "%an", "%ae", "%at", "%cn", "%ce", "%ct", "%D", "%s", "%b",
+}, "%x1f") + "%x1e"
+
+// GetCommits returns commits from the log, newest first
+func GetCommits(opts LogOptions) ([]CommitInfo, error) {
+	if opts.Revision == "" && !hasCommits() {
+		return nil, nil
+	}
+
+	args := []string{"log", "--decorate=full", "--format=" + logFormat}
+	if opts.TopoOrder {
+		args = append(args, "--topo-order")
+	}
+	if opts.Limit > 0 {
+		args = append(args, fmt.Sprintf("-%d", opts.Limit))
+	}
+	if opts.Revision != "" {
+		args = append(args, opts.Revision)
+	}
+	args = append(args, "--")
+
+	output, err := Run(args...)
+	if err != nil {
+		return nil, err
+	}
+	return parseCommits(output), nil
+}
+
+func parseCommits(output string) []CommitInfo {
+	var commits []CommitInfo
+	for _, record := range strings.Split(output, logRecordSep) {
+		record = strings.TrimLeft(record, "\n")
+		if record == "" {
+			continue
+		}
+
+		fields := strings.Split(record, logFieldSep)
+		if len(fields) < 12 {
+			continue
+		}
+
+		commit := CommitInfo{
+			Hash:           fields[0],
+			ShortHash:      fields[1],
+			Parents:        strings.Fields(fields[2]),
+			AuthorName:     fields[3],
+			AuthorEmail:    fields[4],
+			AuthorDate:     parseUnixTime(fields[5]),
+			CommitterName:  fields[6],
+			CommitterEmail: fields[7],
+			CommitDate:     parseUnixTime(fields[8]),
+			Refs:           parseDecorations(fields[9]),
+			Subject:        fields[10],
+			Body:           strings.TrimRight(fields[11], "\n"),
+		}
+		commits = append(commits, commit)
+	}
+	return commits
+}
+
+func parseUnixTime(s string) time.Time {
+	secs, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(secs, 0)
+}
+
+// parseDecorations parses %D output produced with --decorate=full
+// (e.g., "HEAD -> refs/heads/main, tag: refs/tags/v1.0, refs/remotes/origin/main")
+func parseDecorations(s string) []Ref {
+	var refs []Ref
+	for _, part := range strings.Split(s, ", ") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		var ref Ref
+		if strings.HasPrefix(part, "HEAD -> ") {
+			ref.IsHead = true
+			part = strings.TrimPrefix(part, "HEAD -> ")
+		}
+		part = strings.TrimPrefix(part, "tag: ")
+
+		switch {
+		case part == "HEAD":
+			ref.Name, ref.Type = "HEAD", RefHead
+		case strings.HasPrefix(part, "refs/heads/"):
+			ref.Name, ref.Type = strings.TrimPrefix(part, "refs/heads/"), RefBranch
+		case strings.HasPrefix(part, "refs/remotes/"):
+			ref.Name, ref.Type = strings.TrimPrefix(part, "refs/remotes/"), RefRemoteBranch
+		case strings.HasPrefix(part, "refs/tags/"):
+			ref.Name, ref.Type = strings.TrimPrefix(part, "refs/tags/"), RefTag
+		default:
+			ref.Name, ref.Type = strings.TrimPrefix(part, "refs/"), RefOther
+		}
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// CommitDetail holds a commit along with its diffstat and changes
+type CommitDetail struct {
+	Commit CommitInfo
+	Stat   string      // Output of --stat
+	Diff   *DiffResult // Changes introduced by the commit (against its first parent)
+}
+
+// GetCommitDetail returns the metadata, diffstat and diff for a single commit.
+// Merge commits are diffed against their first parent.
+func GetCommitDetail(hash string) (*CommitDetail, error) {
+	commits, err := GetCommits(LogOptions{Limit: 1, Revision: hash})
+	if err != nil {
+		return nil, err
+	}
+	if len(commits) == 0 {
+		return nil, fmt.Errorf("commit %s not found", hash)
+	}
+
+	stat, err := Run("show", "--format=", "--stat", "-m", "--first-parent", hash, "--")
+	if err != nil {
+		return nil, err
+	}
+
+	patch, err := Run("show", "--format=", "--patch", "-m", "--first-parent", hash, "--")
+	if err != nil {
+		return nil, err
+	}
+
+	return &CommitDetail{
+		Commit: commits[0],
+		Stat:   strings.Trim(stat, "\n"),
+		Diff:   parseDiff(patch),
+	}, nil
+}
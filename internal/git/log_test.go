@@ -0,0 +1,201 @@
+package git
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGetCommits(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	repo.CommitFile("file1.txt", "content1", "First commit")
+	repo.CommitFile("file2.txt", "content2", "Second commit\n\nWith a body\nover two lines")
+	repo.CommitFile("file3.txt", "content3", "Third commit")
+
+	commits, err := GetCommits(LogOptions{Limit: 2})
+	if err != nil {
+		t.Fatalf("GetCommits failed: %v", err)
+	}
+	if len(commits) != 2 {
+		t.Fatalf("expected 2 commits, got %d", len(commits))
+	}
+
+	if commits[0].Subject != "Third commit" {
+		t.Errorf("commits[0].Subject = %q, want 'Third commit'", commits[0].Subject)
+	}
+	if commits[1].Subject != "Second commit" {
+		t.Errorf("commits[1].Subject = %q, want 'Second commit'", commits[1].Subject)
+	}
+	if commits[1].Body != "With a body\nover two lines" {
+		t.Errorf("commits[1].Body = %q", commits[1].Body)
+	}
+
+	head := strings.TrimSpace(repo.Git("rev-parse", "HEAD"))
+	if commits[0].Hash != head {
+		t.Errorf("commits[0].Hash = %q, want %q", commits[0].Hash, head)
+	}
+	if !strings.HasPrefix(head, commits[0].ShortHash) {
+		t.Errorf("ShortHash %q should prefix %q", commits[0].ShortHash, head)
+	}
+	if len(commits[0].Parents) != 1 || commits[0].Parents[0] != commits[1].Hash {
+		t.Errorf("commits[0].Parents = %v, want [%s]", commits[0].Parents, commits[1].Hash)
+	}
+	if commits[0].AuthorName != "Test User" || commits[0].AuthorEmail != "test@example.com" {
+		t.Errorf("author = %q <%q>", commits[0].AuthorName, commits[0].AuthorEmail)
+	}
+	if commits[0].AuthorDate.IsZero() || commits[0].CommitDate.IsZero() {
+		t.Error("dates should be parsed")
+	}
+}
+
+func TestGetCommits_Revision(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	repo.InitialCommit()
+	repo.CreateBranch("feature", true)
+	repo.CommitFile("feature.txt", "feature", "Feature commit")
+	repo.Git("checkout", "-")
+
+	commits, err := GetCommits(LogOptions{Revision: "HEAD..feature"})
+	if err != nil {
+		t.Fatalf("GetCommits failed: %v", err)
+	}
+	if len(commits) != 1 || commits[0].Subject != "Feature commit" {
+		t.Errorf("expected only 'Feature commit', got %+v", commits)
+	}
+}
+
+func TestGetCommits_NoCommits(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	commits, err := GetCommits(LogOptions{})
+	if err != nil {
+		t.Fatalf("GetCommits failed: %v", err)
+	}
+	if len(commits) != 0 {
+		t.Errorf("expected no commits, got %d", len(commits))
+	}
+}
+
+func TestGetCommits_Refs(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	repo.InitialCommit()
+	repo.Git("tag", "v1.0")
+	repo.CreateBranch("other", false)
+
+	commits, err := GetCommits(LogOptions{Limit: 1})
+	if err != nil {
+		t.Fatalf("GetCommits failed: %v", err)
+	}
+
+	branch := GetBranch()
+	want := map[Ref]bool{
+		{Name: branch, Type: RefBranch, IsHead: true}: true,
+		{Name: "other", Type: RefBranch}:              true,
+		{Name: "v1.0", Type: RefTag}:                  true,
+	}
+	if len(commits[0].Refs) != len(want) {
+		t.Fatalf("refs = %+v, want %d refs", commits[0].Refs, len(want))
+	}
+	for _, ref := range commits[0].Refs {
+		if !want[ref] {
+			t.Errorf("unexpected ref %+v", ref)
+		}
+	}
+}
+
+func TestParseDecorations(t *testing.T) {
+	refs := parseDecorations("HEAD -> refs/heads/main, tag: refs/tags/v1, refs/remotes/origin/main, refs/stash")
+	want := []Ref{
+		{Name: "main", Type: RefBranch, IsHead: true},
+		{Name: "v1", Type: RefTag},
+		{Name: "origin/main", Type: RefRemoteBranch},
+		{Name: "stash", Type: RefOther},
+	}
+	if len(refs) != len(want) {
+		t.Fatalf("got %d refs, want %d: %+v", len(refs), len(want), refs)
+	}
+	for i := range want {
+		if refs[i] != want[i] {
+			t.Errorf("refs[%d] = %+v, want %+v", i, refs[i], want[i])
+		}
+	}
+
+	refs = parseDecorations("HEAD, refs/heads/main")
+	if len(refs) != 2 || refs[0] != (Ref{Name: "HEAD", Type: RefHead}) {
+		t.Errorf("detached HEAD should be its own ref, got %+v", refs)
+	}
+
+	if refs := parseDecorations(""); len(refs) != 0 {
+		t.Errorf("expected no refs for empty decoration, got %+v", refs)
+	}
+}
+
+func TestGetCommitDetail(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	repo.CommitFile("file.txt", "line1\nline2\n", "First commit")
+	repo.CommitFile("file.txt", "line1\nchanged\n", "Change line two")
+
+	detail, err := GetCommitDetail("HEAD")
+	if err != nil {
+		t.Fatalf("GetCommitDetail failed: %v", err)
+	}
+
+	if detail.Commit.Subject != "Change line two" {
+		t.Errorf("Subject = %q, want 'Change line two'", detail.Commit.Subject)
+	}
+	if !strings.Contains(detail.Stat, "file.txt") {
+		t.Errorf("Stat should mention file.txt, got %q", detail.Stat)
+	}
+	if len(detail.Diff.Files) != 1 || len(detail.Diff.Files[0].Hunks) != 1 {
+		t.Fatalf("expected 1 file with 1 hunk, got %+v", detail.Diff.Files)
+	}
+	if detail.Diff.Files[0].Path != "file.txt" {
+		t.Errorf("Path = %q, want file.txt", detail.Diff.Files[0].Path)
+	}
+}
+
+func TestGetCommitDetail_RootCommit(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	repo.CommitFile("file.txt", "content\n", "Root commit")
+
+	detail, err := GetCommitDetail("HEAD")
+	if err != nil {
+		t.Fatalf("GetCommitDetail failed: %v", err)
+	}
+	if len(detail.Diff.Files) != 1 {
+		t.Errorf("expected 1 file in root commit diff, got %d", len(detail.Diff.Files))
+	}
+}
+
+func TestGetCommitDetail_Merge(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	repo.InitialCommit()
+	repo.CreateBranch("feature", true)
+	repo.CommitFile("feature.txt", "feature\n", "Feature commit")
+	repo.Git("checkout", "-")
+	repo.CommitFile("main.txt", "main\n", "Main commit")
+	repo.Git("merge", "--no-ff", "-m", "Merge feature", "feature")
+
+	detail, err := GetCommitDetail("HEAD")
+	if err != nil {
+		t.Fatalf("GetCommitDetail failed: %v", err)
+	}
+	if !detail.Commit.IsMerge() {
+		t.Error("expected merge commit")
+	}
+	if len(detail.Diff.Files) != 1 || detail.Diff.Files[0].Path != "feature.txt" {
+		t.Errorf("merge should be diffed against first parent, got %+v", detail.Diff.Files)
+	}
+}
@@ -0,0 +1,68 @@
+package git
+
+// MergeMode selects how Merge brings in the other branch
+type MergeMode int
+
+const (
+	MergeNoFF   MergeMode = iota // Always create a merge commit (--no-ff)
+	MergeFFOnly                  // Only fast-forward (--ff-only)
+	MergeSquash                  // Stage the combined changes without committing (--squash)
+)
+
+// String returns the name of the merge mode
+func (m MergeMode) String() string {
+	switch m {
+	case MergeFFOnly:
+		return "ff-only"
+	case MergeSquash:
+		return "squash"
+	default:
+		return "no-ff"
+	}
+}
+
+// Merge merges branch into HEAD. A merge that stops on conflicts returns an
+// error and leaves the conflicted files in the working tree.
+func Merge(branch string, mode MergeMode) error {
+	args := []string{"merge"}
+	switch mode {
+	case MergeFFOnly:
+		args = append(args, "--ff-only")
+	case MergeSquash:
+		args = append(args, "--squash")
+	default:
+		args = append(args, "--no-ff", "--no-edit")
+	}
+	args = append(args, branch)
+	_, err := Run(args...)
+	return err
+}
+
+// MergeAbort abandons a merge that stopped on conflicts. A squash merge
+// doesn't record MERGE_HEAD, so it is undone with reset --merge instead.
+func MergeAbort() error {
+	if _, err := Run("rev-parse", "--quiet", "--verify", "MERGE_HEAD"); err != nil {
+		_, err := Run("reset", "--merge")
+		return err
+	}
+	_, err := Run("merge", "--abort")
+	return err
+}
+
+// Rebase replays the commits of HEAD on top of upstream
+func Rebase(upstream string) error {
+	_, err := Run("rebase", upstream)
+	return err
+}
+
+// RebaseAbort abandons a rebase and restores the original branch
+func RebaseAbort() error {
+	_, err := Run("rebase", "--abort")
+	return err
+}
+
+// HasConflicts returns true if there are unmerged files
+func HasConflicts() bool {
+	status, err := GetStatus()
+	return err == nil && len(status.Conflicted) > 0
+}
@@ -0,0 +1,169 @@
+package git
+
+import (
+	"strings"
+	"testing"
+)
+
+// setupDivergedBranches creates a feature branch with one commit and a
+// commit on the original branch, leaving the original branch checked out
+func setupDivergedBranches(repo *TestRepo) {
+	repo.T.Helper()
+	repo.InitialCommit()
+	repo.CreateBranch("feature", true)
+	repo.CommitFile("feature.txt", "feature\n", "Feature commit")
+	repo.Git("checkout", "-")
+	repo.CommitFile("main.txt", "main\n", "Main commit")
+}
+
+func headParents(repo *TestRepo) int {
+	repo.T.Helper()
+	return len(strings.Fields(repo.Git("rev-list", "--parents", "-n", "1", "HEAD"))) - 1
+}
+
+func TestMergeNoFF(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+	repo.CreateBranch("feature", true)
+	repo.CommitFile("feature.txt", "feature\n", "Feature commit")
+	repo.Git("checkout", "-")
+
+	// Could fast-forward, but --no-ff still creates a merge commit
+	if err := Merge("feature", MergeNoFF); err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if headParents(repo) != 2 {
+		t.Error("expected a merge commit")
+	}
+	if !repo.FileExists("feature.txt") {
+		t.Error("expected feature.txt to be merged")
+	}
+}
+
+func TestMergeFFOnly(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	setupDivergedBranches(repo)
+
+	if err := Merge("feature", MergeFFOnly); err == nil {
+		t.Fatal("expected --ff-only to fail on diverged branches")
+	}
+
+	repo.Git("reset", "--hard", "HEAD~1")
+	if err := Merge("feature", MergeFFOnly); err != nil {
+		t.Fatalf("Merge --ff-only failed: %v", err)
+	}
+	if headParents(repo) != 1 {
+		t.Error("expected a fast-forward")
+	}
+}
+
+func TestMergeSquash(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	setupDivergedBranches(repo)
+
+	if err := Merge("feature", MergeSquash); err != nil {
+		t.Fatalf("Merge --squash failed: %v", err)
+	}
+	status, _ := GetStatus()
+	if len(status.Staged) != 1 || status.Staged[0].Path != "feature.txt" {
+		t.Errorf("expected feature.txt to be staged, got %+v", status.Staged)
+	}
+	if subject := strings.TrimSpace(repo.Git("log", "-1", "--format=%s")); subject != "Main commit" {
+		t.Errorf("squash should not commit, HEAD is %q", subject)
+	}
+}
+
+func TestMergeConflictAndAbort(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+	repo.CommitFile("file.txt", "base\n", "base")
+	repo.CreateBranch("feature", true)
+	repo.CommitFile("file.txt", "theirs\n", "theirs")
+	repo.Git("checkout", "-")
+	repo.CommitFile("file.txt", "ours\n", "ours")
+
+	if err := Merge("feature", MergeNoFF); err == nil {
+		t.Fatal("expected the merge to stop with conflicts")
+	}
+	if !HasConflicts() {
+		t.Fatal("expected conflicts")
+	}
+
+	if err := MergeAbort(); err != nil {
+		t.Fatalf("MergeAbort failed: %v", err)
+	}
+	if HasConflicts() {
+		t.Error("expected no conflicts after abort")
+	}
+	if content := repo.ReadFile("file.txt"); content != "ours\n" {
+		t.Errorf("expected file restored to ours, got %q", content)
+	}
+}
+
+func TestMergeSquashConflictAbort(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+	repo.CommitFile("file.txt", "base\n", "base")
+	repo.CreateBranch("feature", true)
+	repo.CommitFile("file.txt", "theirs\n", "theirs")
+	repo.Git("checkout", "-")
+	repo.CommitFile("file.txt", "ours\n", "ours")
+
+	if err := Merge("feature", MergeSquash); err == nil {
+		t.Fatal("expected the squash merge to stop with conflicts")
+	}
+	if err := MergeAbort(); err != nil {
+		t.Fatalf("MergeAbort failed: %v", err)
+	}
+	if HasConflicts() {
+		t.Error("expected no conflicts after abort")
+	}
+	if content := repo.ReadFile("file.txt"); content != "ours\n" {
+		t.Errorf("expected file restored to ours, got %q", content)
+	}
+}
+
+func TestRebase(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	setupDivergedBranches(repo)
+
+	if err := Rebase("feature"); err != nil {
+		t.Fatalf("Rebase failed: %v", err)
+	}
+	log := repo.Git("log", "--format=%s")
+	if !strings.HasPrefix(log, "Main commit\nFeature commit\n") {
+		t.Errorf("expected Main commit replayed on top of feature, got:\n%s", log)
+	}
+}
+
+func TestRebaseConflictAndAbort(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+	repo.CommitFile("file.txt", "base\n", "base")
+	repo.CreateBranch("feature", true)
+	repo.CommitFile("file.txt", "theirs\n", "theirs")
+	repo.Git("checkout", "-")
+	repo.CommitFile("file.txt", "ours\n", "ours")
+	head := repo.Git("rev-parse", "HEAD")
+
+	if err := Rebase("feature"); err == nil {
+		t.Fatal("expected the rebase to stop with conflicts")
+	}
+	if !HasConflicts() {
+		t.Fatal("expected conflicts")
+	}
+
+	if err := RebaseAbort(); err != nil {
+		t.Fatalf("RebaseAbort failed: %v", err)
+	}
+	if got := repo.Git("rev-parse", "HEAD"); got != head {
+		t.Errorf("expected HEAD restored after abort")
+	}
+}
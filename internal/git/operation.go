@@ -0,0 +1,189 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// OperationKind is a multi-step git operation that can be underway
+type OperationKind int
+
+const (
+	OperationNone OperationKind = iota
+	OperationMerge
+	OperationRebase
+	OperationCherryPick
+	OperationRevert
+	OperationBisect
+)
+
+// String returns the git command name of the operation
+func (k OperationKind) String() string {
+	switch k {
+	case OperationMerge:
+		return "merge"
+	case OperationRebase:
+		return "rebase"
+	case OperationCherryPick:
+		return "cherry-pick"
+	case OperationRevert:
+		return "revert"
+	case OperationBisect:
+		return "bisect"
+	default:
+		return ""
+	}
+}
+
+// Operation describes the operation in progress in the repository
+type Operation struct {
+	Kind   OperationKind
+	Branch string // branch being rebased
+	Step   int    // current rebase step (0 if unknown)
+	Total  int    // number of rebase steps (0 if unknown)
+	Squash bool   // merge --squash waiting to be committed
+}
+
+// InProgress returns true if an operation is underway
+func (o Operation) InProgress() bool {
+	return o.Kind != OperationNone
+}
+
+// CanContinue returns true if the operation has a continue step
+// (a bisect is moved along by marking commits instead)
+func (o Operation) CanContinue() bool {
+	return o.InProgress() && o.Kind != OperationBisect
+}
+
+// CanSkip returns true if the current step of the operation can be skipped
+func (o Operation) CanSkip() bool {
+	return o.InProgress() && o.Kind != OperationMerge
+}
+
+// String returns the operation with its progress, e.g. "rebase 3/7"
+func (o Operation) String() string {
+	name := o.Kind.String()
+	if o.Squash {
+		name = "merge --squash"
+	}
+	if o.Total > 0 {
+		return fmt.Sprintf("%s %d/%d", name, o.Step, o.Total)
+	}
+	return name
+}
+
+// GetGitDir returns the absolute path of the .git directory
+// (which is elsewhere for worktrees and submodules)
+func GetGitDir() (string, error) {
+	output, err := Run("rev-parse", "--absolute-git-dir")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(output), nil
+}
+
+// GetOperation detects the operation in progress from the state files git
+// leaves in the git dir
+func GetOperation() Operation {
+	gitDir, err := GetGitDir()
+	if err != nil {
+		return Operation{}
+	}
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(gitDir, name))
+		return err == nil
+	}
+
+	// A rebase can stop on a commit it is cherry-picking, so check it first
+	for _, dir := range []string{"rebase-merge", "rebase-apply"} {
+		if !exists(dir) {
+			continue
+		}
+		op := Operation{Kind: OperationRebase}
+		op.Branch = strings.TrimPrefix(readStateFile(gitDir, dir, "head-name"), "refs/heads/")
+		step, total := "msgnum", "end"
+		if dir == "rebase-apply" {
+			step, total = "next", "last"
+		}
+		op.Step, _ = strconv.Atoi(readStateFile(gitDir, dir, step))
+		op.Total, _ = strconv.Atoi(readStateFile(gitDir, dir, total))
+		return op
+	}
+
+	switch {
+	case exists("CHERRY_PICK_HEAD"):
+		return Operation{Kind: OperationCherryPick}
+	case exists("REVERT_HEAD"):
+		return Operation{Kind: OperationRevert}
+	case exists("MERGE_HEAD"):
+		return Operation{Kind: OperationMerge}
+	case exists("SQUASH_MSG"):
+		return Operation{Kind: OperationMerge, Squash: true}
+	case exists("BISECT_LOG"):
+		return Operation{Kind: OperationBisect}
+	}
+	return Operation{}
+}
+
+// readStateFile reads a file from the git dir, trimmed ("" if missing)
+func readStateFile(gitDir string, path ...string) string {
+	data, err := os.ReadFile(filepath.Join(append([]string{gitDir}, path...)...))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// noEditor keeps git from opening an editor for the commit message when
+// continuing; the prepared message is used as is
+var noEditor = []string{"GIT_EDITOR=true"}
+
+// ContinueOperation continues the operation once its conflicts are resolved
+func ContinueOperation(op Operation) error {
+	var err error
+	switch op.Kind {
+	case OperationMerge:
+		// Commits with the prepared MERGE_MSG (or SQUASH_MSG)
+		_, err = Run("commit", "--no-edit")
+	case OperationRebase, OperationCherryPick, OperationRevert:
+		_, err = RunWithEnv(noEditor, op.Kind.String(), "--continue")
+	default:
+		err = fmt.Errorf("%s has no continue step", op.Kind)
+	}
+	return err
+}
+
+// AbortOperation abandons the operation and restores the state from before it started
+func AbortOperation(op Operation) error {
+	var err error
+	switch op.Kind {
+	case OperationMerge:
+		err = MergeAbort()
+	case OperationRebase:
+		err = RebaseAbort()
+	case OperationCherryPick, OperationRevert:
+		_, err = Run(op.Kind.String(), "--abort")
+	case OperationBisect:
+		_, err = Run("bisect", "reset")
+	default:
+		err = fmt.Errorf("no operation in progress")
+	}
+	return err
+}
+
+// SkipOperation skips the current commit of the operation
+func SkipOperation(op Operation) error {
+	var err error
+	switch op.Kind {
+	case OperationRebase, OperationCherryPick, OperationRevert:
+		_, err = RunWithEnv(noEditor, op.Kind.String(), "--skip")
+	case OperationBisect:
+		_, err = Run("bisect", "skip")
+	default:
+		err = fmt.Errorf("%s can't be skipped", op.Kind)
+	}
+	return err
+}
@@ -0,0 +1,208 @@
+package git
+
+import (
+	"strings"
+	"testing"
+)
+
+// setupConflictingBranches creates a feature branch and a commit on the
+// original branch that both change file.txt, leaving the original branch
+// checked out
+func setupConflictingBranches(repo *TestRepo) {
+	repo.T.Helper()
+	repo.InitialCommit()
+	repo.CommitFile("file.txt", "base\n", "base")
+	repo.CreateBranch("feature", true)
+	repo.CommitFile("file.txt", "theirs\n", "theirs")
+	repo.Git("checkout", "-")
+	repo.CommitFile("file.txt", "ours\n", "ours")
+}
+
+func TestOperationString(t *testing.T) {
+	tests := []struct {
+		op   Operation
+		want string
+	}{
+		{Operation{}, ""},
+		{Operation{Kind: OperationMerge}, "merge"},
+		{Operation{Kind: OperationMerge, Squash: true}, "merge --squash"},
+		{Operation{Kind: OperationRebase, Step: 3, Total: 7}, "rebase 3/7"},
+		{Operation{Kind: OperationCherryPick}, "cherry-pick"},
+		{Operation{Kind: OperationBisect}, "bisect"},
+	}
+	for _, tt := range tests {
+		if got := tt.op.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestGetOperationNone(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+
+	if op := GetOperation(); op.InProgress() {
+		t.Errorf("expected no operation, got %q", op)
+	}
+}
+
+func TestGetOperationMerge(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	setupConflictingBranches(repo)
+	repo.GitAllowFailure("merge", "feature")
+
+	op := GetOperation()
+	if op.Kind != OperationMerge || op.Squash {
+		t.Fatalf("expected a merge, got %+v", op)
+	}
+	if op.CanSkip() {
+		t.Error("a merge can't be skipped")
+	}
+
+	repo.WriteFile("file.txt", "resolved\n")
+	repo.Git("add", "file.txt")
+	if err := ContinueOperation(op); err != nil {
+		t.Fatalf("ContinueOperation failed: %v", err)
+	}
+	if GetOperation().InProgress() {
+		t.Error("expected the merge to be finished")
+	}
+	if headParents(repo) != 2 {
+		t.Error("expected a merge commit")
+	}
+}
+
+func TestGetOperationSquashMerge(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	setupConflictingBranches(repo)
+	repo.GitAllowFailure("merge", "--squash", "feature")
+
+	op := GetOperation()
+	if op.Kind != OperationMerge || !op.Squash {
+		t.Fatalf("expected a squash merge, got %+v", op)
+	}
+	if err := AbortOperation(op); err != nil {
+		t.Fatalf("AbortOperation failed: %v", err)
+	}
+	if content := repo.ReadFile("file.txt"); content != "ours\n" {
+		t.Errorf("expected file restored to ours, got %q", content)
+	}
+}
+
+func TestGetOperationRebase(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	setupConflictingBranches(repo)
+	repo.CommitFile("other.txt", "other\n", "other")
+	branch := strings.TrimSpace(repo.Git("branch", "--show-current"))
+	repo.GitAllowFailure("rebase", "feature")
+
+	op := GetOperation()
+	if op.Kind != OperationRebase {
+		t.Fatalf("expected a rebase, got %+v", op)
+	}
+	if op.Branch != branch {
+		t.Errorf("expected branch %q, got %q", branch, op.Branch)
+	}
+	if op.String() != "rebase 1/2" {
+		t.Errorf("expected rebase 1/2, got %q", op)
+	}
+
+	// Skipping the conflicting commit applies the other one cleanly
+	if err := SkipOperation(op); err != nil {
+		t.Fatalf("SkipOperation failed: %v", err)
+	}
+	if GetOperation().InProgress() {
+		t.Error("expected the rebase to be finished")
+	}
+	if content := repo.ReadFile("file.txt"); content != "theirs\n" {
+		t.Errorf("expected the conflicting commit to be skipped, got %q", content)
+	}
+}
+
+func TestGetOperationRebaseContinue(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	setupConflictingBranches(repo)
+	repo.GitAllowFailure("rebase", "feature")
+
+	repo.WriteFile("file.txt", "resolved\n")
+	repo.Git("add", "file.txt")
+	// GIT_EDITOR=true keeps the original message without opening an editor
+	if err := ContinueOperation(GetOperation()); err != nil {
+		t.Fatalf("ContinueOperation failed: %v", err)
+	}
+	if subject := strings.TrimSpace(repo.Git("log", "-1", "--format=%s")); subject != "ours" {
+		t.Errorf("expected the replayed commit to keep its message, got %q", subject)
+	}
+}
+
+func TestGetOperationCherryPick(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	setupConflictingBranches(repo)
+	repo.GitAllowFailure("cherry-pick", "feature")
+
+	op := GetOperation()
+	if op.Kind != OperationCherryPick {
+		t.Fatalf("expected a cherry-pick, got %+v", op)
+	}
+	if err := AbortOperation(op); err != nil {
+		t.Fatalf("AbortOperation failed: %v", err)
+	}
+	if GetOperation().InProgress() {
+		t.Error("expected the cherry-pick to be aborted")
+	}
+	if content := repo.ReadFile("file.txt"); content != "ours\n" {
+		t.Errorf("expected file restored to ours, got %q", content)
+	}
+}
+
+func TestGetOperationRevert(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+	repo.CommitFile("file.txt", "one\n", "one")
+	repo.CommitFile("file.txt", "two\n", "two")
+	repo.GitAllowFailure("revert", "HEAD~1")
+
+	op := GetOperation()
+	if op.Kind != OperationRevert {
+		t.Fatalf("expected a revert, got %+v", op)
+	}
+	if err := SkipOperation(op); err != nil {
+		t.Fatalf("SkipOperation failed: %v", err)
+	}
+	if GetOperation().InProgress() {
+		t.Error("expected the revert to be finished")
+	}
+}
+
+func TestGetOperationBisect(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+	repo.CommitFile("file.txt", "one\n", "one")
+	repo.CommitFile("file.txt", "two\n", "two")
+	repo.Git("bisect", "start", "HEAD", "HEAD~2")
+
+	op := GetOperation()
+	if op.Kind != OperationBisect {
+		t.Fatalf("expected a bisect, got %+v", op)
+	}
+	if op.CanContinue() {
+		t.Error("a bisect has no continue step")
+	}
+	if err := ContinueOperation(op); err == nil {
+		t.Error("expected an error continuing a bisect")
+	}
+	if err := AbortOperation(op); err != nil {
+		t.Fatalf("AbortOperation failed: %v", err)
+	}
+	if GetOperation().InProgress() {
+		t.Error("expected the bisect to be reset")
+	}
+}
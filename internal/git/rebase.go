@@ -0,0 +1,131 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// RebaseAction is what an interactive rebase does with a commit
+type RebaseAction int
+
+const (
+	RebasePick   RebaseAction = iota
+	RebaseReword              // pick with a new message
+	RebaseEdit                // pick and stop to amend
+	RebaseSquash              // meld into the previous commit, combining messages
+	RebaseFixup               // meld into the previous commit, keeping its message
+	RebaseDrop
+)
+
+// String returns the todo list command for the action
+func (a RebaseAction) String() string {
+	switch a {
+	case RebaseReword:
+		return "reword"
+	case RebaseEdit:
+		return "edit"
+	case RebaseSquash:
+		return "squash"
+	case RebaseFixup:
+		return "fixup"
+	case RebaseDrop:
+		return "drop"
+	default:
+		return "pick"
+	}
+}
+
+// RebaseStep is one line of an interactive rebase todo list
+type RebaseStep struct {
+	Action  RebaseAction
+	Commit  CommitInfo
+	Message string // new message for RebaseReword
+}
+
+// GetRebaseCommits returns the commits an interactive rebase onto base
+// replays, oldest first. Merge commits are left out, as git rebase -i
+// does without --rebase-merges.
+func GetRebaseCommits(base string) ([]CommitInfo, error) {
+	commits, err := GetCommits(LogOptions{Revision: base + "..HEAD", TopoOrder: true})
+	if err != nil {
+		return nil, err
+	}
+	var result []CommitInfo
+	for i := len(commits) - 1; i >= 0; i-- {
+		if !commits[i].IsMerge() {
+			result = append(result, commits[i])
+		}
+	}
+	return result, nil
+}
+
+// InteractiveRebase runs git rebase -i onto base with the given todo list
+// instead of opening an editor. Reworded commits get their new message from
+// an exec line after the pick, so no editor is needed for them either.
+//
+// Like Rebase, it returns an error when the rebase stops on conflicts. An
+// edit step stops the rebase without an error; check GetOperation.
+func InteractiveRebase(base string, steps []RebaseStep) error {
+	if len(steps) == 0 {
+		return fmt.Errorf("nothing to rebase")
+	}
+	for _, step := range steps {
+		if step.Action == RebaseDrop {
+			continue
+		}
+		if step.Action == RebaseSquash || step.Action == RebaseFixup {
+			return fmt.Errorf("cannot %s %s without a previous commit", step.Action, step.Commit.ShortHash)
+		}
+		break
+	}
+
+	gitDir, err := GetGitDir()
+	if err != nil {
+		return err
+	}
+	// Reword messages must outlive a stop on conflicts, so they are kept in
+	// the git dir and cleared at the start of the next rebase
+	dir := filepath.Join(gitDir, "go-on-git", "rebase")
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	var todo strings.Builder
+	for i, step := range steps {
+		if step.Action != RebaseReword {
+			fmt.Fprintf(&todo, "%s %s %s\n", step.Action, step.Commit.Hash, step.Commit.Subject)
+			continue
+		}
+		msgFile := filepath.Join(dir, fmt.Sprintf("message-%d", i))
+		if err := os.WriteFile(msgFile, []byte(step.Message), 0o644); err != nil {
+			return err
+		}
+		// A pick followed by an amend, so git doesn't ask for the message
+		fmt.Fprintf(&todo, "pick %s %s\n", step.Commit.Hash, step.Commit.Subject)
+		fmt.Fprintf(&todo, "exec git commit --amend --quiet --cleanup=strip -F %s\n", shellQuote(msgFile))
+	}
+
+	todoFile := filepath.Join(dir, "todo")
+	if err := os.WriteFile(todoFile, []byte(todo.String()), 0o644); err != nil {
+		return err
+	}
+
+	env := []string{
+		// git runs the sequence editor with the todo list path as its argument
+		"GIT_SEQUENCE_EDITOR=cp " + shellQuote(todoFile),
+		// Accept the combined message of squashes as is
+		"GIT_EDITOR=true",
+	}
+	_, err = RunWithEnv(env, "rebase", "-i", base)
+	return err
+}
+
+// shellQuote quotes s for use as a single word in a POSIX shell command
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
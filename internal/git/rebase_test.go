@@ -0,0 +1,163 @@
+package git
+
+import (
+	"strings"
+	"testing"
+)
+
+// setupRebaseCommits creates three commits on top of the initial commit and
+// returns the initial commit hash and the commits to rebase, oldest first
+func setupRebaseCommits(t *testing.T, repo *TestRepo) (string, []CommitInfo) {
+	t.Helper()
+	repo.InitialCommit()
+	base := strings.TrimSpace(repo.Git("rev-parse", "HEAD"))
+	repo.CommitFile("one.txt", "one\n", "One")
+	repo.CommitFile("two.txt", "two\n", "Two")
+	repo.CommitFile("three.txt", "three\n", "Three")
+
+	commits, err := GetRebaseCommits(base)
+	if err != nil {
+		t.Fatalf("GetRebaseCommits failed: %v", err)
+	}
+	return base, commits
+}
+
+func subjects(repo *TestRepo, rev string) string {
+	repo.T.Helper()
+	return strings.TrimSpace(repo.Git("log", "--format=%s", rev))
+}
+
+func TestGetRebaseCommits(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	_, commits := setupRebaseCommits(t, repo)
+
+	if len(commits) != 3 {
+		t.Fatalf("expected 3 commits, got %d", len(commits))
+	}
+	if commits[0].Subject != "One" || commits[2].Subject != "Three" {
+		t.Errorf("expected commits oldest first, got %q ... %q", commits[0].Subject, commits[2].Subject)
+	}
+}
+
+func TestInteractiveRebaseReorderAndDrop(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	base, commits := setupRebaseCommits(t, repo)
+
+	steps := []RebaseStep{
+		{Action: RebasePick, Commit: commits[2]},
+		{Action: RebaseDrop, Commit: commits[1]},
+		{Action: RebasePick, Commit: commits[0]},
+	}
+	if err := InteractiveRebase(base, steps); err != nil {
+		t.Fatalf("InteractiveRebase failed: %v", err)
+	}
+	if got := subjects(repo, base+"..HEAD"); got != "One\nThree" {
+		t.Errorf("expected Three then One, got:\n%s", got)
+	}
+	if repo.FileExists("two.txt") {
+		t.Error("expected the dropped commit to be gone")
+	}
+}
+
+func TestInteractiveRebaseRewordSquashFixup(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	base, commits := setupRebaseCommits(t, repo)
+
+	steps := []RebaseStep{
+		{Action: RebaseReword, Commit: commits[0], Message: "First\n\nWith a body"},
+		{Action: RebaseFixup, Commit: commits[1]},
+		{Action: RebaseSquash, Commit: commits[2]},
+	}
+	if err := InteractiveRebase(base, steps); err != nil {
+		t.Fatalf("InteractiveRebase failed: %v", err)
+	}
+	if got := strings.Count(subjects(repo, base+"..HEAD"), "\n"); got != 0 {
+		t.Errorf("expected a single commit, got %d more", got)
+	}
+	message := repo.Git("log", "-1", "--format=%B")
+	if !strings.HasPrefix(message, "First\n\nWith a body\n") || !strings.Contains(message, "Three") {
+		t.Errorf("expected the reworded message combined with the squashed one, got:\n%s", message)
+	}
+	for _, name := range []string{"one.txt", "two.txt", "three.txt"} {
+		if !repo.FileExists(name) {
+			t.Errorf("expected %s to be kept", name)
+		}
+	}
+}
+
+func TestInteractiveRebaseEditStops(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	base, commits := setupRebaseCommits(t, repo)
+
+	steps := []RebaseStep{
+		{Action: RebaseEdit, Commit: commits[0]},
+		{Action: RebasePick, Commit: commits[1]},
+		{Action: RebasePick, Commit: commits[2]},
+	}
+	if err := InteractiveRebase(base, steps); err != nil {
+		t.Fatalf("InteractiveRebase failed: %v", err)
+	}
+	op := GetOperation()
+	if op.Kind != OperationRebase || op.Step != 1 || op.Total != 3 {
+		t.Fatalf("expected the rebase to stop at 1/3, got %+v", op)
+	}
+	if err := ContinueOperation(op); err != nil {
+		t.Fatalf("ContinueOperation failed: %v", err)
+	}
+	if GetOperation().InProgress() {
+		t.Error("expected the rebase to be finished")
+	}
+}
+
+func TestInteractiveRebaseConflictKeepsReword(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+	base := strings.TrimSpace(repo.Git("rev-parse", "HEAD"))
+	repo.CommitFile("file.txt", "one\n", "One")
+	repo.CommitFile("file.txt", "two\n", "Two")
+	commits, _ := GetRebaseCommits(base)
+
+	// Picking Two without One conflicts (file.txt doesn't exist yet)
+	steps := []RebaseStep{
+		{Action: RebaseReword, Commit: commits[1], Message: "Reworded"},
+		{Action: RebaseDrop, Commit: commits[0]},
+	}
+	if err := InteractiveRebase(base, steps); err == nil {
+		t.Fatal("expected the rebase to stop with conflicts")
+	}
+	if !HasConflicts() {
+		t.Fatal("expected conflicts")
+	}
+
+	repo.WriteFile("file.txt", "two\n")
+	repo.Git("add", "file.txt")
+	if err := ContinueOperation(GetOperation()); err != nil {
+		t.Fatalf("ContinueOperation failed: %v", err)
+	}
+	if got := subjects(repo, base+"..HEAD"); got != "Reworded" {
+		t.Errorf("expected the new message after continuing, got %q", got)
+	}
+}
+
+func TestInteractiveRebaseSquashFirst(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	base, commits := setupRebaseCommits(t, repo)
+
+	steps := []RebaseStep{
+		{Action: RebaseDrop, Commit: commits[0]},
+		{Action: RebaseSquash, Commit: commits[1]},
+		{Action: RebasePick, Commit: commits[2]},
+	}
+	if err := InteractiveRebase(base, steps); err == nil {
+		t.Fatal("expected an error squashing without a previous commit")
+	}
+	if GetOperation().InProgress() {
+		t.Error("the rebase should not have started")
+	}
+}
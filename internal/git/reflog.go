@@ -0,0 +1,163 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ReflogEntry is one entry of the HEAD reflog
+type ReflogEntry struct {
+	Selector string // e.g. HEAD@{2}
+	Hash     string // commit HEAD pointed to after the operation
+	Action   string // e.g. "commit (amend)", "checkout", "reset"
+	Message  string // what the operation recorded, e.g. "moving from main to feature"
+	Subject  string // subject of the commit
+	Date     time.Time
+}
+
+// Fields: hash, reflog selector (as a date with --date=unix), reflog
+// subject, commit subject
+var reflogFormat = strings.Join([]string{"%H", "%gd", "%gs", "%s"}, "%x1f") + "%x1e"
+
+// GetReflog returns the HEAD reflog, newest first (0 = no limit)
+func GetReflog(limit int) ([]ReflogEntry, error) {
+	if !hasCommits() {
+		return nil, nil
+	}
+	args := []string{"log", "-g", "--date=unix", "--format=" + reflogFormat}
+	if limit > 0 {
+		args = append(args, fmt.Sprintf("-%d", limit))
+	}
+	args = append(args, "HEAD", "--")
+
+	output, err := Run(args...)
+	if err != nil {
+		return nil, err
+	}
+	return parseReflog(output), nil
+}
+
+func parseReflog(output string) []ReflogEntry {
+	var entries []ReflogEntry
+	for _, record := range strings.Split(output, logRecordSep) {
+		record = strings.TrimLeft(record, "\n")
+		if record == "" {
+			continue
+		}
+		fields := strings.Split(record, logFieldSep)
+		if len(fields) < 4 {
+			continue
+		}
+
+		// With --date=unix the selector is HEAD@{<time>}; the position
+		// gives the HEAD@{n} form
+		date := strings.TrimSuffix(strings.TrimPrefix(fields[1], "HEAD@{"), "}")
+		action, message, _ := strings.Cut(fields[2], ": ")
+		entries = append(entries, ReflogEntry{
+			Selector: fmt.Sprintf("HEAD@{%d}", len(entries)),
+			Hash:     fields[0],
+			Action:   action,
+			Message:  message,
+			Subject:  fields[3],
+			Date:     parseUnixTime(date),
+		})
+	}
+	return entries
+}
+
+// CheckoutCommit checks out a commit with a detached HEAD
+func CheckoutCommit(hash string) error {
+	_, err := Run("checkout", "--detach", hash)
+	return err
+}
+
+// CreateBranchAt creates a branch pointing at a commit, without checking it out
+func CreateBranchAt(name, hash string) error {
+	if name == "" {
+		return fmt.Errorf("branch name is empty")
+	}
+	_, err := Run("branch", name, hash)
+	return err
+}
+
+// UndoPlan describes how to undo the last operation recorded in the reflog
+type UndoPlan struct {
+	Entry    ReflogEntry // the operation being undone
+	Target   ReflogEntry // the state to go back to
+	Checkout string      // branch or commit to check out, for a checkout
+	Mode     ResetMode   // how to reset otherwise
+}
+
+// Command returns the git command the undo runs
+func (p UndoPlan) Command() string {
+	if p.Checkout != "" {
+		return "git checkout " + p.Checkout
+	}
+	return fmt.Sprintf("git reset --%s %s", p.Mode, ShortHash(p.Target.Hash))
+}
+
+// GetUndoPlan works out how to undo the last operation in the HEAD reflog:
+//   - a commit or amend is reset with --soft, keeping its changes staged
+//   - a checkout goes back to the branch or commit it moved from
+//   - a rebase or other multi-step operation goes back to before it started
+//   - anything else (reset, merge, pull, ...) is reset with --keep, which
+//     refuses to overwrite uncommitted changes
+func GetUndoPlan() (UndoPlan, error) {
+	entries, err := GetReflog(200)
+	if err != nil {
+		return UndoPlan{}, err
+	}
+	if len(entries) == 0 {
+		return UndoPlan{}, fmt.Errorf("nothing to undo")
+	}
+	return planUndo(entries)
+}
+
+func planUndo(entries []ReflogEntry) (UndoPlan, error) {
+	entry := entries[0]
+	plan := UndoPlan{Entry: entry, Mode: ResetKeep}
+
+	// Go back to before the start of an operation made of several steps
+	start := 0
+	if strings.HasSuffix(entry.Action, "(finish)") {
+		for i, e := range entries {
+			if strings.HasSuffix(e.Action, "(start)") {
+				start = i
+				break
+			}
+		}
+	}
+	if start+1 >= len(entries) {
+		return UndoPlan{}, fmt.Errorf("nothing to undo before '%s'", entry.Action)
+	}
+	plan.Target = entries[start+1]
+
+	switch {
+	case entry.Action == "checkout":
+		from, _, ok := strings.Cut(strings.TrimPrefix(entry.Message, "moving from "), " to ")
+		if !ok || from == "" {
+			return UndoPlan{}, fmt.Errorf("can't tell where '%s' moved from", entry.Message)
+		}
+		plan.Checkout = from
+	case entry.Action == "commit" || entry.Action == "commit (amend)":
+		plan.Mode = ResetSoft
+	}
+	return plan, nil
+}
+
+// Undo runs an undo plan from GetUndoPlan
+func Undo(plan UndoPlan) error {
+	if plan.Checkout != "" {
+		return CheckoutBranch(plan.Checkout)
+	}
+	return Reset(plan.Target.Hash, plan.Mode)
+}
+
+// ShortHash abbreviates a commit hash for display
+func ShortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
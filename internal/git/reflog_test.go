@@ -0,0 +1,217 @@
+package git
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGetReflog(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+	repo.CommitFile("file.txt", "content\n", "Add file")
+	repo.Git("checkout", "-b", "feature")
+
+	entries, err := GetReflog(0)
+	if err != nil {
+		t.Fatalf("GetReflog failed: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(entries))
+	}
+
+	checkout := entries[0]
+	if checkout.Selector != "HEAD@{0}" || checkout.Action != "checkout" {
+		t.Errorf("unexpected first entry: %+v", checkout)
+	}
+	if !strings.Contains(checkout.Message, "to feature") {
+		t.Errorf("unexpected checkout message %q", checkout.Message)
+	}
+	commit := entries[1]
+	if commit.Selector != "HEAD@{1}" || commit.Action != "commit" || commit.Subject != "Add file" {
+		t.Errorf("unexpected second entry: %+v", commit)
+	}
+	if commit.Date.IsZero() {
+		t.Error("expected the entry date to be parsed")
+	}
+
+	limited, err := GetReflog(1)
+	if err != nil {
+		t.Fatalf("GetReflog failed: %v", err)
+	}
+	if len(limited) != 1 {
+		t.Errorf("expected 1 entry with a limit, got %d", len(limited))
+	}
+}
+
+func TestGetReflogNoCommits(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	entries, err := GetReflog(0)
+	if err != nil {
+		t.Fatalf("GetReflog failed: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("expected no entries, got %d", len(entries))
+	}
+}
+
+func TestCheckoutCommitAndCreateBranchAt(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+	first := strings.TrimSpace(repo.Git("rev-parse", "HEAD"))
+	repo.CommitFile("file.txt", "content\n", "Second")
+
+	if err := CreateBranchAt("old", first); err != nil {
+		t.Fatalf("CreateBranchAt failed: %v", err)
+	}
+	if got := strings.TrimSpace(repo.Git("rev-parse", "old")); got != first {
+		t.Errorf("old = %s, want %s", got, first)
+	}
+	if err := CreateBranchAt("", first); err == nil {
+		t.Error("expected an error for an empty name")
+	}
+
+	if err := CheckoutCommit(first); err != nil {
+		t.Fatalf("CheckoutCommit failed: %v", err)
+	}
+	if _, err := repo.GitAllowFailure("symbolic-ref", "-q", "HEAD"); err == nil {
+		t.Error("expected a detached HEAD")
+	}
+}
+
+func TestUndoCommit(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+	before := strings.TrimSpace(repo.Git("rev-parse", "HEAD"))
+	repo.CommitFile("file.txt", "content\n", "Oops")
+
+	plan, err := GetUndoPlan()
+	if err != nil {
+		t.Fatalf("GetUndoPlan failed: %v", err)
+	}
+	if plan.Mode != ResetSoft || plan.Target.Hash != before {
+		t.Fatalf("unexpected plan: %+v", plan)
+	}
+	if plan.Command() != "git reset --soft "+before[:7] {
+		t.Errorf("unexpected command %q", plan.Command())
+	}
+
+	if err := Undo(plan); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if head := strings.TrimSpace(repo.Git("rev-parse", "HEAD")); head != before {
+		t.Errorf("HEAD = %s, want %s", head, before)
+	}
+	// The commit's changes stay staged
+	if status := repo.Git("status", "--porcelain"); !strings.Contains(status, "A  file.txt") {
+		t.Errorf("expected file.txt to be staged, got %q", status)
+	}
+}
+
+func TestUndoAmend(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+	repo.CommitFile("file.txt", "content\n", "Original")
+	original := strings.TrimSpace(repo.Git("rev-parse", "HEAD"))
+	repo.Git("commit", "--amend", "-m", "Amended")
+
+	plan, err := GetUndoPlan()
+	if err != nil {
+		t.Fatalf("GetUndoPlan failed: %v", err)
+	}
+	if plan.Entry.Action != "commit (amend)" || plan.Mode != ResetSoft {
+		t.Fatalf("unexpected plan: %+v", plan)
+	}
+	if err := Undo(plan); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if head := strings.TrimSpace(repo.Git("rev-parse", "HEAD")); head != original {
+		t.Errorf("HEAD = %s, want the commit before the amend %s", head, original)
+	}
+}
+
+func TestUndoCheckout(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+	main := strings.TrimSpace(repo.Git("branch", "--show-current"))
+	repo.Git("checkout", "-b", "feature")
+
+	plan, err := GetUndoPlan()
+	if err != nil {
+		t.Fatalf("GetUndoPlan failed: %v", err)
+	}
+	if plan.Checkout != main || plan.Command() != "git checkout "+main {
+		t.Fatalf("unexpected plan: %+v", plan)
+	}
+	if err := Undo(plan); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if branch := strings.TrimSpace(repo.Git("branch", "--show-current")); branch != main {
+		t.Errorf("current branch = %s, want %s", branch, main)
+	}
+}
+
+func TestUndoHardReset(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+	repo.CommitFile("file.txt", "content\n", "Keep me")
+	before := strings.TrimSpace(repo.Git("rev-parse", "HEAD"))
+	repo.Git("reset", "--hard", "HEAD~1")
+
+	plan, err := GetUndoPlan()
+	if err != nil {
+		t.Fatalf("GetUndoPlan failed: %v", err)
+	}
+	if plan.Entry.Action != "reset" || plan.Mode != ResetKeep || plan.Target.Hash != before {
+		t.Fatalf("unexpected plan: %+v", plan)
+	}
+	if err := Undo(plan); err != nil {
+		t.Fatalf("Undo failed: %v", err)
+	}
+	if !repo.FileExists("file.txt") {
+		t.Error("undoing the reset should bring the file back")
+	}
+}
+
+func TestUndoInitialCommit(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+
+	if _, err := GetUndoPlan(); err == nil {
+		t.Error("expected an error when only the initial commit is in the reflog")
+	}
+}
+
+func TestPlanUndoRebase(t *testing.T) {
+	entries := []ReflogEntry{
+		{Action: "rebase (finish)", Hash: "c"},
+		{Action: "rebase (pick)", Hash: "b"},
+		{Action: "rebase (start)", Hash: "a"},
+		{Action: "commit", Hash: "before"},
+	}
+
+	plan, err := planUndo(entries)
+	if err != nil {
+		t.Fatalf("planUndo failed: %v", err)
+	}
+	if plan.Target.Hash != "before" || plan.Mode != ResetKeep {
+		t.Errorf("expected to go back to before the rebase started, got %+v", plan)
+	}
+}
+
+func TestShortHash(t *testing.T) {
+	if got := ShortHash("0123456789abcdef"); got != "0123456" {
+		t.Errorf("ShortHash = %q, want 0123456", got)
+	}
+	if got := ShortHash("abc"); got != "abc" {
+		t.Errorf("ShortHash = %q, want a short hash unchanged", got)
+	}
+}
@@ -1,23 +1,233 @@
 package git
 
 import (
+	"bytes"
 	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
+	"time"
 )
 
+// stashNotesRef holds a note on each stash made by go-on-git recording the
+// options it was made with, since --keep-index leaves no trace in the stash
+const stashNotesRef = "refs/notes/go-on-git/stash"
+
 // Stash represents a git stash entry
 type Stash struct {
 	Index   int
+	Hash    string
 	Message string
 	Branch  string
-	Date    string
+	Date    time.Time
+	Files   int          // number of files in the stash, untracked ones included
+	Options StashOptions // how the stash was made
+}
+
+// StashOptions are the modes of git stash push
+type StashOptions struct {
+	IncludeUntracked bool // --include-untracked
+	KeepIndex        bool // --keep-index: staged changes stay in the working tree too
+	Staged           bool // --staged: only stash staged changes
+}
+
+// stashFlags are the option names, as passed to git stash push and recorded
+// in the notes
+var stashFlags = []struct {
+	name string
+	flag func(o *StashOptions) *bool
+}{
+	{"include-untracked", func(o *StashOptions) *bool { return &o.IncludeUntracked }},
+	{"keep-index", func(o *StashOptions) *bool { return &o.KeepIndex }},
+	{"staged", func(o *StashOptions) *bool { return &o.Staged }},
+}
+
+// flags returns the options as flag names, e.g. ["include-untracked"]
+func (o StashOptions) flags() []string {
+	var names []string
+	for _, f := range stashFlags {
+		if *f.flag(&o) {
+			names = append(names, f.name)
+		}
+	}
+	return names
+}
+
+// String describes the options, e.g. "include untracked, keep index"
+// (empty for a plain stash)
+func (o StashOptions) String() string {
+	return strings.ReplaceAll(strings.Join(o.flags(), ", "), "-", " ")
+}
+
+func parseStashOptions(note string) StashOptions {
+	var o StashOptions
+	for _, name := range strings.Fields(note) {
+		for _, f := range stashFlags {
+			if f.name == name {
+				*f.flag(&o) = true
+			}
+		}
+	}
+	return o
+}
+
+// StashPush stashes the changes to paths (all changes if empty) with an
+// optional message
+func StashPush(paths []string, message string, opts StashOptions) error {
+	if opts.Staged && opts.IncludeUntracked {
+		return fmt.Errorf("can't stash only staged changes and include untracked files")
+	}
+
+	args := []string{"stash", "push"}
+	for _, flag := range opts.flags() {
+		args = append(args, "--"+flag)
+	}
+	if message != "" {
+		args = append(args, "-m", message)
+	}
+	if len(paths) > 0 {
+		args = append(args, "--")
+		args = append(args, paths...)
+	}
+
+	before, _ := Run("rev-parse", "-q", "--verify", "refs/stash")
+	if _, err := Run(args...); err != nil {
+		return err
+	}
+	after, err := Run("rev-parse", "-q", "--verify", "refs/stash")
+	if err != nil || after == before || opts == (StashOptions{}) {
+		// Nothing was stashed, or nothing to record
+		return nil
+	}
+	_, err = RunWithEnv(internalIdentity, "notes", "--ref="+stashNotesRef, "add", "-f",
+		"-m", strings.Join(opts.flags(), " "), strings.TrimSpace(after))
+	return err
+}
+
+// StashHunks stashes unstaged hunks, given as patches against the index
+// (like git stash push -p). The stash holds the index as it is plus the
+// patches, which are then removed from the working tree; the index and the
+// other changes are left alone.
+func StashHunks(patches []string, message string) error {
+	if len(patches) == 0 {
+		return nil
+	}
+	patch := strings.Join(patches, "")
+
+	head, err := Run("rev-parse", "--verify", "-q", "HEAD")
+	if err != nil {
+		return fmt.Errorf("can't stash before the initial commit")
+	}
+	head = strings.TrimSpace(head)
+	// Fail before stashing anything if the hunks can't be removed
+	if err := applyPatch(nil, patch, "--reverse", "--check"); err != nil {
+		return err
+	}
+
+	branch := GetBranch()
+	if branch == "" {
+		branch = "(no branch)"
+	}
+	subject, err := Run("log", "-1", "--format=%h %s", "HEAD")
+	if err != nil {
+		return err
+	}
+	indexMessage := fmt.Sprintf("index on %s: %s", branch, strings.TrimSpace(subject))
+	if message == "" {
+		message = fmt.Sprintf("WIP on %s: %s", branch, strings.TrimSpace(subject))
+	} else {
+		message = fmt.Sprintf("On %s: %s", branch, message)
+	}
+
+	// A stash is a commit of the working tree whose parents are HEAD and a
+	// commit of the index
+	identity := stashIdentity()
+	indexTree, err := Run("write-tree")
+	if err != nil {
+		return err
+	}
+	indexTree = strings.TrimSpace(indexTree)
+	indexCommit, err := RunWithEnv(identity, "commit-tree", indexTree, "-p", head, "-m", indexMessage)
+	if err != nil {
+		return err
+	}
+
+	// The working tree of the stash is the index plus the hunks, built in a
+	// temporary index
+	dir, err := os.MkdirTemp("", "go-on-git-stash-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	env := []string{"GIT_INDEX_FILE=" + filepath.Join(dir, "index")}
+	if _, err := RunWithEnv(env, "read-tree", indexTree); err != nil {
+		return err
+	}
+	if err := applyPatch(env, patch, "--cached"); err != nil {
+		return err
+	}
+	tree, err := RunWithEnv(env, "write-tree")
+	if err != nil {
+		return err
+	}
+	commit, err := RunWithEnv(identity, "commit-tree", strings.TrimSpace(tree), "-p", head, "-p", strings.TrimSpace(indexCommit), "-m", message)
+	if err != nil {
+		return err
+	}
+
+	if _, err := RunWithEnv(identity, "stash", "store", "-m", message, strings.TrimSpace(commit)); err != nil {
+		return err
+	}
+	return applyPatch(nil, patch, "--reverse")
+}
+
+// stashIdentity returns the identity git stash falls back to, "git stash
+// <git@stash>", for the parts of the user's identity that aren't set, so
+// stashes can be committed without user.name or user.email
+func stashIdentity() []string {
+	if _, err := Run("var", "GIT_COMMITTER_IDENT"); err == nil {
+		return nil
+	}
+	var env []string
+	fallback := func(config, value string, vars ...string) {
+		if set, _ := Run("config", config); strings.TrimSpace(set) != "" {
+			return
+		}
+		for _, v := range vars {
+			if os.Getenv(v) == "" {
+				env = append(env, v+"="+value)
+			}
+		}
+	}
+	fallback("user.name", "git stash", "GIT_AUTHOR_NAME", "GIT_COMMITTER_NAME")
+	fallback("user.email", "git@stash", "GIT_AUTHOR_EMAIL", "GIT_COMMITTER_EMAIL")
+	return env
+}
+
+// applyPatch runs git apply with args on patch
+func applyPatch(env []string, patch string, args ...string) error {
+	cmd := exec.Command("git", append([]string{"apply"}, args...)...)
+	cmd.Dir = GetRepoRoot()
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	cmd.Stdin = strings.NewReader(patch)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s: %w: %s", strings.Join(cmd.Args, " "), err, stderr.String())
+	}
+	return nil
 }
 
 // GetStashes returns all stash entries
 func GetStashes() ([]Stash, error) {
-	// Format: stash@{0}: On branch_name: message
-	// or: stash@{0}: WIP on branch_name: hash message
-	output, err := Run("stash", "list", "--format=%gd|%s")
+	// Reflog subject format: On branch_name: message
+	// or: WIP on branch_name: hash message
+	// (the reflog subject, unlike the commit subject, follows a rename)
+	output, err := Run("stash", "list", "--notes="+stashNotesRef, "--format=%gd%x1f%H%x1f%ct%x1f%P%x1f%gs%x1f%N%x1e")
 	if err != nil {
 		return nil, err
 	}
@@ -27,24 +237,30 @@ func GetStashes() ([]Stash, error) {
 	}
 
 	var stashes []Stash
-	lines := strings.Split(strings.TrimSpace(output), "\n")
-
-	for _, line := range lines {
-		if line == "" {
+	var parents [][]string
+	for _, record := range strings.Split(output, logRecordSep) {
+		record = strings.TrimLeft(record, "\n")
+		if record == "" {
 			continue
 		}
 
-		parts := strings.SplitN(line, "|", 2)
-		if len(parts) < 2 {
+		fields := strings.SplitN(record, logFieldSep, 6)
+		if len(fields) < 6 {
 			continue
 		}
 
 		// Parse index from stash@{N}
 		var index int
-		fmt.Sscanf(parts[0], "stash@{%d}", &index)
+		fmt.Sscanf(fields[0], "stash@{%d}", &index)
+
+		// The untracked files are a third parent, even for stashes made elsewhere
+		options := parseStashOptions(fields[5])
+		if len(strings.Fields(fields[3])) > 2 {
+			options.IncludeUntracked = true
+		}
 
 		// Parse message - format is usually "On branch: message" or "WIP on branch: hash message"
-		message := parts[1]
+		message := fields[4]
 		branch := ""
 
 		if strings.HasPrefix(message, "On ") {
@@ -72,29 +288,165 @@ func GetStashes() ([]Stash, error) {
 
 		stashes = append(stashes, Stash{
 			Index:   index,
+			Hash:    fields[1],
 			Message: message,
 			Branch:  branch,
+			Date:    parseUnixTime(fields[2]),
+			Options: options,
 		})
+		parents = append(parents, strings.Fields(fields[3]))
 	}
 
+	// The file counts are only informative, so the list is shown without them
+	// if they can't be computed
+	_ = countStashFiles(stashes, parents)
 	return stashes, nil
 }
 
-// GetStashDiff returns the diff for a specific stash
-func GetStashDiff(index int) (*CombinedDiffResult, error) {
+// countStashFiles sets the number of files changed in the index or the
+// working tree of each stash, plus its untracked files. parents holds the
+// parents of each stash. All the stashes are diffed by a single git diff-tree.
+func countStashFiles(stashes []Stash, parents [][]string) error {
+	// Each input line diffs a commit against the parents that follow it.
+	// Diffing the working tree against both HEAD and the index covers the
+	// files changed in either, and the untracked files are the whole tree
+	// of the root commit holding them.
+	var input strings.Builder
+	owner := make(map[string]int) // commit diffed -> index in stashes
+	for i, stash := range stashes {
+		if len(parents[i]) < 2 {
+			continue
+		}
+		owner[stash.Hash] = i
+		fmt.Fprintf(&input, "%s %s\n%s %s\n", stash.Hash, parents[i][0], stash.Hash, parents[i][1])
+		if len(parents[i]) > 2 {
+			owner[parents[i][2]] = i
+			fmt.Fprintf(&input, "%s\n", parents[i][2])
+		}
+	}
+	if len(owner) == 0 {
+		return nil
+	}
+
+	output, err := runWithInput(input.String(), "diff-tree", "--stdin", "--root", "-r", "--name-only", "-z")
+	if err != nil {
+		return err
+	}
+
+	// The output is the commit of each diff followed by its paths
+	files := make([]map[string]bool, len(stashes))
+	current := -1
+	for _, token := range strings.Split(output, "\x00") {
+		token = strings.TrimPrefix(token, "\n")
+		if i, ok := owner[token]; ok {
+			current = i
+			continue
+		}
+		if token == "" || current < 0 {
+			continue
+		}
+		if files[current] == nil {
+			files[current] = make(map[string]bool)
+		}
+		files[current][token] = true
+	}
+	for i := range stashes {
+		stashes[i].Files = len(files[i])
+	}
+	return nil
+}
+
+// runWithInput executes a git command that reads input from stdin
+func runWithInput(input string, args ...string) (string, error) {
+	gitMu.Lock()
+	defer gitMu.Unlock()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = GetRepoRoot()
+	cmd.Stdin = strings.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, stderr.String())
+	}
+	return stdout.String(), nil
+}
+
+// StashPart is one of the parts a stash records
+type StashPart int
+
+const (
+	StashIndex     StashPart = iota // staged changes
+	StashWorktree                   // unstaged changes
+	StashUntracked                  // untracked files
+)
+
+// StashParts are the parts of a stash, in the order they are shown
+var StashParts = []StashPart{StashIndex, StashWorktree, StashUntracked}
+
+// StashDiff is the content of a stash, split in its parts
+type StashDiff struct {
+	Index     *DiffResult // the stashed index against the commit the stash was made on
+	Worktree  *DiffResult // the stashed working tree against the stashed index
+	Untracked *DiffResult // the untracked files, if they were stashed
+}
+
+// Part returns the diff of a part of the stash
+func (d *StashDiff) Part(part StashPart) *DiffResult {
+	switch part {
+	case StashIndex:
+		return d.Index
+	case StashWorktree:
+		return d.Worktree
+	default:
+		return d.Untracked
+	}
+}
+
+// IsEmpty returns true if the stash changes nothing
+func (d *StashDiff) IsEmpty() bool {
+	for _, part := range StashParts {
+		if diff := d.Part(part); diff != nil && !diff.IsEmpty() {
+			return false
+		}
+	}
+	return true
+}
+
+// GetStashDiff returns the diff for a specific stash, split in its index,
+// working tree and untracked parts (git stash show -p merges the first two
+// and leaves out the untracked files)
+func GetStashDiff(index int) (*StashDiff, error) {
 	stashRef := fmt.Sprintf("stash@{%d}", index)
-	output, err := Run("stash", "show", "-p", stashRef)
+	if _, err := Run("rev-parse", "--verify", "-q", stashRef); err != nil {
+		return nil, fmt.Errorf("no stash %s", stashRef)
+	}
+
+	indexOutput, err := Run("diff", stashRef+"^1", stashRef+"^2")
 	if err != nil {
 		return nil, err
 	}
+	worktreeOutput, err := Run("diff", stashRef+"^2", stashRef)
+	if err != nil {
+		return nil, err
+	}
+	diff := &StashDiff{
+		Index:     parseDiff(indexOutput),
+		Worktree:  parseDiff(worktreeOutput),
+		Untracked: &DiffResult{},
+	}
 
-	// Parse as unstaged diff (stash shows what would be applied)
-	diff := parseDiff(output)
-
-	return &CombinedDiffResult{
-		StagedDiff:   &DiffResult{},
-		UnstagedDiff: diff,
-	}, nil
+	// The untracked files are a parentless commit
+	if _, err := Run("rev-parse", "--verify", "-q", stashRef+"^3"); err == nil {
+		output, err := Run("show", "--format=", stashRef+"^3")
+		if err != nil {
+			return nil, err
+		}
+		diff.Untracked = parseDiff(output)
+	}
+	return diff, nil
 }
 
 // ApplyStash applies a stash without removing it
@@ -117,3 +469,104 @@ func DropStash(index int) error {
 	_, err := Run("stash", "drop", stashRef)
 	return err
 }
+
+// StashBranch creates a branch at the commit a stash was made on, checks it
+// out and pops the stash onto it
+func StashBranch(index int, branch string) error {
+	stashRef := fmt.Sprintf("stash@{%d}", index)
+	_, err := Run("stash", "branch", branch, stashRef)
+	return err
+}
+
+// RenameStash changes the message of a stash, keeping its place in the list.
+// The stashes down to it are dropped and stored again, the renamed one with
+// the new message.
+func RenameStash(index int, message string) error {
+	output, err := Run("stash", "list", fmt.Sprintf("-%d", index+1), "--format=%H%x1f%gs%x1e")
+	if err != nil {
+		return err
+	}
+	type entry struct{ hash, subject string }
+	var entries []entry
+	for _, record := range strings.Split(output, logRecordSep) {
+		record = strings.TrimLeft(record, "\n")
+		if hash, subject, ok := strings.Cut(record, logFieldSep); ok {
+			entries = append(entries, entry{hash, subject})
+		}
+	}
+	if len(entries) != index+1 {
+		return fmt.Errorf("no stash stash@{%d}", index)
+	}
+
+	// Keep the "On branch:" prefix git stash uses
+	branch := "(no branch)"
+	if rest, ok := strings.CutPrefix(entries[index].subject, "WIP on "); ok {
+		branch, _, _ = strings.Cut(rest, ": ")
+	} else if rest, ok := strings.CutPrefix(entries[index].subject, "On "); ok {
+		branch, _, _ = strings.Cut(rest, ": ")
+	}
+	renamed := fmt.Sprintf("On %s: %s", branch, message)
+
+	// Every stash up to the renamed one is dropped and stored again on top.
+	// If git fails partway through, the stashes no longer in the list are
+	// stored back under their old messages and the error lists every hash.
+	hashes := make([]string, len(entries))
+	for i, e := range entries {
+		hashes[i] = e.hash
+	}
+	identity := stashIdentity()
+	fail := func(err error, pending []entry) error {
+		for i := len(pending) - 1; i >= 0; i-- {
+			RunWithEnv(identity, "stash", "store", "-m", pending[i].subject, pending[i].hash)
+		}
+		return fmt.Errorf("renaming stash@{%d}: %w (stashes %s)", index, err, strings.Join(hashes, " "))
+	}
+
+	for i := range entries {
+		if _, err := Run("stash", "drop", "-q", "stash@{0}"); err != nil {
+			return fail(err, entries[:i])
+		}
+	}
+	for i := len(entries) - 1; i >= 0; i-- {
+		subject := entries[i].subject
+		if i == index {
+			subject = renamed
+		}
+		if _, err := RunWithEnv(identity, "stash", "store", "-m", subject, entries[i].hash); err != nil {
+			return fail(err, entries[:i+1])
+		}
+	}
+	return nil
+}
+
+// ApplyStashPatch applies the patch of hunks picked from a stash to the
+// working tree
+func ApplyStashPatch(patch string) error {
+	return applyPatch(nil, patch)
+}
+
+// GetStashCompareDiff returns how the files a stash changes differ between
+// the working tree and the stash, i.e. what the working tree would need to
+// become the stashed version (untracked files aren't compared)
+func GetStashCompareDiff(index int) (*DiffResult, error) {
+	stashRef := fmt.Sprintf("stash@{%d}", index)
+	output, err := Run("diff", "--name-only", "-z", stashRef+"^1", stashRef)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, path := range strings.Split(output, "\x00") {
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+	if len(paths) == 0 {
+		return &DiffResult{}, nil
+	}
+
+	output, err = Run(append([]string{"diff", "-R", stashRef, "--"}, paths...)...)
+	if err != nil {
+		return nil, err
+	}
+	return parseDiff(output), nil
+}
@@ -1,6 +1,9 @@
 package git
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -154,9 +157,12 @@ func TestGetStashDiff(t *testing.T) {
 		t.Error("expected non-empty stash diff")
 	}
 
-	// Stash diff shows as unstaged diff (what would be applied)
-	if diff.UnstagedDiff == nil || diff.UnstagedDiff.IsEmpty() {
-		t.Error("expected unstaged diff portion to have content")
+	// Nothing was staged, so the change is in the working tree part
+	if diff.Worktree == nil || diff.Worktree.IsEmpty() {
+		t.Error("expected working tree part to have content")
+	}
+	if !diff.Index.IsEmpty() || !diff.Untracked.IsEmpty() {
+		t.Error("expected empty index and untracked parts")
 	}
 }
 
@@ -176,8 +182,8 @@ func TestGetStashDiff_MultipleFiles(t *testing.T) {
 		t.Fatalf("GetStashDiff failed: %v", err)
 	}
 
-	if len(diff.UnstagedDiff.Files) != 2 {
-		t.Errorf("expected 2 files in stash diff, got %d", len(diff.UnstagedDiff.Files))
+	if len(diff.Worktree.Files) != 2 {
+		t.Errorf("expected 2 files in stash diff, got %d", len(diff.Worktree.Files))
 	}
 }
 
@@ -533,3 +539,528 @@ func TestStash_MessageParsing(t *testing.T) {
 		t.Errorf("expected message to contain 'colons', got %q", stashes[0].Message)
 	}
 }
+
+func TestStashPushIncludeUntrackedFiles(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	repo.CommitFile("tracked.txt", "original", "initial")
+	repo.WriteFile("tracked.txt", "modified")
+	repo.WriteFile("new.txt", "untracked")
+	repo.WriteFile("other.txt", "left alone")
+
+	opts := StashOptions{IncludeUntracked: true}
+	if err := StashPush([]string{"tracked.txt", "new.txt"}, "with untracked", opts); err != nil {
+		t.Fatalf("StashPush failed: %v", err)
+	}
+
+	if repo.FileExists("new.txt") {
+		t.Error("the selected untracked file should be stashed")
+	}
+	if !repo.FileExists("other.txt") {
+		t.Error("other untracked files should be left alone")
+	}
+
+	stashes, err := GetStashes()
+	if err != nil {
+		t.Fatalf("GetStashes failed: %v", err)
+	}
+	if len(stashes) != 1 || stashes[0].Options != opts {
+		t.Fatalf("expected the stash to record its options, got %+v", stashes)
+	}
+	if stashes[0].Options.String() != "include untracked" {
+		t.Errorf("unexpected description %q", stashes[0].Options.String())
+	}
+}
+
+func TestStashPushKeepIndex(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	repo.CommitFile("test.txt", "original", "initial")
+	repo.WriteFile("test.txt", "staged")
+	repo.Git("add", "test.txt")
+
+	if err := StashPush(nil, "", StashOptions{KeepIndex: true}); err != nil {
+		t.Fatalf("StashPush failed: %v", err)
+	}
+
+	// The staged change stays in the index and the working tree
+	if content := repo.ReadFile("test.txt"); content != "staged" {
+		t.Errorf("expected the staged content to be kept, got %q", content)
+	}
+	stashes, _ := GetStashes()
+	if len(stashes) != 1 || !stashes[0].Options.KeepIndex {
+		t.Errorf("expected a keep-index stash, got %+v", stashes)
+	}
+}
+
+func TestStashPushStaged(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	repo.CommitFile("a.txt", "a", "initial")
+	repo.CommitFile("b.txt", "b", "second")
+	repo.WriteFile("a.txt", "a staged")
+	repo.Git("add", "a.txt")
+	repo.WriteFile("b.txt", "b unstaged")
+
+	if err := StashPush(nil, "only staged", StashOptions{Staged: true}); err != nil {
+		t.Fatalf("StashPush failed: %v", err)
+	}
+
+	if content := repo.ReadFile("a.txt"); content != "a" {
+		t.Errorf("the staged change should be stashed, got %q", content)
+	}
+	if content := repo.ReadFile("b.txt"); content != "b unstaged" {
+		t.Errorf("the unstaged change should stay, got %q", content)
+	}
+	stashes, _ := GetStashes()
+	if len(stashes) != 1 || stashes[0].Options != (StashOptions{Staged: true}) {
+		t.Errorf("expected a staged stash, got %+v", stashes)
+	}
+
+	if err := StashPush(nil, "", StashOptions{Staged: true, IncludeUntracked: true}); err == nil {
+		t.Error("expected an error combining staged and include untracked")
+	}
+}
+
+func TestStashPushNothingToStash(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	repo.CommitFile("test.txt", "original", "initial")
+	repo.WriteFile("test.txt", "modified")
+	repo.Git("stash", "push", "-m", "plain")
+
+	// Nothing left to stash: the existing stash must not get the options
+	if err := StashPush(nil, "", StashOptions{KeepIndex: true}); err != nil {
+		t.Fatalf("StashPush failed: %v", err)
+	}
+	stashes, _ := GetStashes()
+	if len(stashes) != 1 || stashes[0].Options != (StashOptions{}) {
+		t.Errorf("expected the plain stash to stay plain, got %+v", stashes)
+	}
+}
+
+func TestGetStashes_UntrackedMadeElsewhere(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	repo.InitialCommit()
+	repo.WriteFile("new.txt", "untracked")
+	repo.Git("stash", "push", "--include-untracked")
+
+	stashes, _ := GetStashes()
+	if len(stashes) != 1 || !stashes[0].Options.IncludeUntracked {
+		t.Errorf("expected the untracked parent to be detected, got %+v", stashes)
+	}
+}
+
+func TestStashHunks(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	lines := []string{}
+	for i := 1; i <= 20; i++ {
+		lines = append(lines, fmt.Sprintf("line%d", i))
+	}
+	original := strings.Join(lines, "\n") + "\n"
+	repo.CommitFile("test.txt", original, "initial")
+	repo.CommitFile("other.txt", "other\n", "add other")
+
+	// Three hunks in test.txt, a staged change to other.txt
+	lines[0], lines[9], lines[19] = "first", "middle", "last"
+	repo.WriteFile("test.txt", strings.Join(lines, "\n")+"\n")
+	repo.WriteFile("other.txt", "staged\n")
+	repo.Git("add", "other.txt")
+
+	diff, err := GetDiff()
+	if err != nil {
+		t.Fatalf("GetDiff failed: %v", err)
+	}
+	file := &diff.Files[0]
+	if len(file.Hunks) != 3 {
+		t.Fatalf("expected 3 hunks, got %d", len(file.Hunks))
+	}
+	patches := []string{file.Hunks[0].GeneratePatch(file), file.Hunks[2].GeneratePatch(file)}
+
+	if err := StashHunks(patches, "two hunks"); err != nil {
+		t.Fatalf("StashHunks failed: %v", err)
+	}
+
+	// The unselected hunk and the index are untouched
+	lines[0], lines[19] = "line1", "line20"
+	if content := repo.ReadFile("test.txt"); content != strings.Join(lines, "\n")+"\n" {
+		t.Errorf("only the middle hunk should be left, got %q", content)
+	}
+	if staged := repo.Git("diff", "--cached", "--name-only"); staged != "other.txt\n" {
+		t.Errorf("the index should be untouched, got %q", staged)
+	}
+
+	stashes, err := GetStashes()
+	if err != nil || len(stashes) != 1 {
+		t.Fatalf("GetStashes = %v, %v", stashes, err)
+	}
+	if stashes[0].Message != "two hunks" || stashes[0].Branch == "" {
+		t.Errorf("unexpected stash: %+v", stashes[0])
+	}
+
+	// The stash holds exactly the selected hunks
+	stashed := repo.Git("diff", "stash@{0}^2", "stash@{0}")
+	if !strings.Contains(stashed, "+first") || !strings.Contains(stashed, "+last") || strings.Contains(stashed, "middle") {
+		t.Errorf("the stash should hold the first and last hunks, got:\n%s", stashed)
+	}
+
+	// Popping it back gives the full set of changes
+	repo.Git("checkout", "--", "test.txt")
+	if err := PopStash(0); err != nil {
+		t.Fatalf("PopStash failed: %v", err)
+	}
+	if content := repo.ReadFile("test.txt"); !strings.HasPrefix(content, "first\n") || !strings.HasSuffix(content, "last\n") {
+		t.Errorf("popping should bring the hunks back, got %q", content)
+	}
+}
+
+func TestStashHunksWithoutIdentity(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.CommitFile("test.txt", "line1\nline2\n", "initial")
+
+	// No user.name or user.email anywhere, like git stash supports
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("EMAIL", "")
+	repo.Git("config", "--unset", "user.name")
+	repo.Git("config", "--unset", "user.email")
+
+	repo.WriteFile("test.txt", "line1\nchanged\n")
+	diff, err := GetDiff()
+	if err != nil {
+		t.Fatalf("GetDiff failed: %v", err)
+	}
+	file := &diff.Files[0]
+	if err := StashHunks([]string{file.Hunks[0].GeneratePatch(file)}, ""); err != nil {
+		t.Fatalf("StashHunks failed: %v", err)
+	}
+	if author := repo.Git("log", "-1", "--format=%an <%ae>", "refs/stash"); author != "git stash <git@stash>\n" {
+		t.Errorf("stash author = %q, want the git stash fallback", author)
+	}
+}
+
+func TestStashHunksDefaultMessage(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	repo.CommitFile("test.txt", "line1\n", "initial")
+	repo.WriteFile("test.txt", "changed\n")
+
+	diff, err := GetDiff()
+	if err != nil {
+		t.Fatalf("GetDiff failed: %v", err)
+	}
+	file := &diff.Files[0]
+	if err := StashHunks([]string{file.Hunks[0].GeneratePatch(file)}, ""); err != nil {
+		t.Fatalf("StashHunks failed: %v", err)
+	}
+
+	if log := repo.Git("stash", "list", "--format=%s"); !strings.HasPrefix(log, "WIP on ") || !strings.Contains(log, "initial") {
+		t.Errorf("expected a WIP message like git stash, got %q", log)
+	}
+	if status := repo.Git("status", "--porcelain"); status != "" {
+		t.Errorf("expected a clean status, got %q", status)
+	}
+}
+
+func TestGetStashDiff_Parts(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	repo.CommitFile("staged.txt", "original\n", "initial")
+	repo.CommitFile("both.txt", "one\n", "second")
+	repo.WriteFile("staged.txt", "staged\n")
+	repo.WriteFile("both.txt", "two\n")
+	repo.Git("add", "staged.txt", "both.txt")
+	repo.WriteFile("both.txt", "three\n")
+	repo.WriteFile("new.txt", "untracked\n")
+	repo.Git("stash", "push", "--include-untracked")
+
+	diff, err := GetStashDiff(0)
+	if err != nil {
+		t.Fatalf("GetStashDiff failed: %v", err)
+	}
+
+	paths := func(d *DiffResult) string {
+		var names []string
+		for _, f := range d.Files {
+			names = append(names, f.Path)
+		}
+		return strings.Join(names, ",")
+	}
+	if got := paths(diff.Index); got != "both.txt,staged.txt" {
+		t.Errorf("index part = %q, want both.txt,staged.txt", got)
+	}
+	if got := paths(diff.Worktree); got != "both.txt" {
+		t.Errorf("working tree part = %q, want both.txt", got)
+	}
+	if got := paths(diff.Untracked); got != "new.txt" {
+		t.Errorf("untracked part = %q, want new.txt", got)
+	}
+	// The working tree part is against the stashed index
+	if hunk := diff.Worktree.Files[0].Hunks[0]; hunk.Lines[0].Content != "-two" {
+		t.Errorf("expected the working tree part to start from the index, got %+v", hunk.Lines)
+	}
+}
+
+func TestGetStashes_Metadata(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	repo.CommitFile("a.txt", "a\n", "initial")
+	repo.CommitFile("b.txt", "b\n", "second")
+	repo.WriteFile("a.txt", "changed\n")
+	repo.Git("add", "a.txt")
+	repo.WriteFile("b.txt", "changed\n")
+	repo.WriteFile("new.txt", "new\n")
+	repo.Git("stash", "push", "--include-untracked")
+
+	stashes, err := GetStashes()
+	if err != nil || len(stashes) != 1 {
+		t.Fatalf("GetStashes = %v, %v", stashes, err)
+	}
+	stash := stashes[0]
+	if stash.Hash != strings.TrimSpace(repo.Git("rev-parse", "stash@{0}")) {
+		t.Errorf("unexpected hash %q", stash.Hash)
+	}
+	if stash.Date.IsZero() {
+		t.Error("expected the stash date")
+	}
+	if stash.Files != 3 {
+		t.Errorf("Files = %d, want 3", stash.Files)
+	}
+}
+
+func TestGetStashes_FileCountsPerStash(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	repo.CommitFile("a.txt", "a\n", "initial")
+	repo.CommitFile("b.txt", "b\n", "second")
+	repo.WriteFile("a.txt", "staged\n")
+	repo.Git("add", "a.txt")
+	repo.Git("stash", "push")
+	repo.WriteFile("a.txt", "changed\n")
+	repo.WriteFile("b.txt", "changed\n")
+	repo.WriteFile("new.txt", "new\n")
+	repo.WriteFile("other.txt", "other\n")
+	repo.Git("stash", "push", "--include-untracked")
+
+	stashes, err := GetStashes()
+	if err != nil || len(stashes) != 2 {
+		t.Fatalf("GetStashes = %v, %v", stashes, err)
+	}
+	if stashes[0].Files != 4 {
+		t.Errorf("stash@{0} Files = %d, want 4", stashes[0].Files)
+	}
+	if stashes[1].Files != 1 {
+		t.Errorf("stash@{1} Files = %d, want 1", stashes[1].Files)
+	}
+}
+
+func TestGetStashes_FileCountFailure(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	repo.CommitFile("a.txt", "a\n", "initial")
+	repo.WriteFile("a.txt", "changed\n")
+	repo.WriteFile("new.txt", "new\n")
+	repo.Git("stash", "push", "--include-untracked")
+
+	// Without the tree of the stash git diff-tree fails, but the stash can
+	// still be listed
+	tree := strings.TrimSpace(repo.Git("rev-parse", "stash@{0}^{tree}"))
+	if err := os.Remove(filepath.Join(repo.Dir, ".git", "objects", tree[:2], tree[2:])); err != nil {
+		t.Fatal(err)
+	}
+
+	stashes, err := GetStashes()
+	if err != nil || len(stashes) != 1 {
+		t.Fatalf("GetStashes = %v, %v", stashes, err)
+	}
+	if stashes[0].Files != 0 {
+		t.Errorf("Files = %d, want 0 when the count fails", stashes[0].Files)
+	}
+}
+
+func TestStashBranch(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	repo.CommitFile("test.txt", "original\n", "initial")
+	repo.WriteFile("test.txt", "stashed\n")
+	repo.Git("stash", "push", "-m", "to branch")
+	repo.CommitFile("test.txt", "moved on\n", "second")
+
+	if err := StashBranch(0, "from-stash"); err != nil {
+		t.Fatalf("StashBranch failed: %v", err)
+	}
+
+	if branch := GetBranch(); branch != "from-stash" {
+		t.Errorf("expected to be on from-stash, got %q", branch)
+	}
+	if content := repo.ReadFile("test.txt"); content != "stashed\n" {
+		t.Errorf("expected the stash applied on the branch, got %q", content)
+	}
+	if stashes, _ := GetStashes(); len(stashes) != 0 {
+		t.Errorf("the stash should be dropped, got %d stashes", len(stashes))
+	}
+}
+
+func TestRenameStash(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	repo.CommitFile("test.txt", "original\n", "initial")
+	for _, msg := range []string{"oldest", "middle", "newest"} {
+		repo.WriteFile("test.txt", msg+"\n")
+		repo.Git("stash", "push", "-m", msg)
+	}
+	before, err := GetStashes()
+	if err != nil {
+		t.Fatalf("GetStashes failed: %v", err)
+	}
+
+	if err := RenameStash(1, "renamed"); err != nil {
+		t.Fatalf("RenameStash failed: %v", err)
+	}
+
+	after, err := GetStashes()
+	if err != nil || len(after) != 3 {
+		t.Fatalf("GetStashes = %v, %v", after, err)
+	}
+	for i, want := range []string{"newest", "renamed", "oldest"} {
+		if after[i].Message != want || after[i].Hash != before[i].Hash {
+			t.Errorf("stash@{%d} = %q (%s), want %q (%s)", i, after[i].Message, after[i].Hash, want, before[i].Hash)
+		}
+	}
+	if after[1].Branch == "" {
+		t.Error("the renamed stash should keep its branch")
+	}
+
+	if err := RenameStash(5, "missing"); err == nil {
+		t.Error("expected an error renaming a missing stash")
+	}
+}
+
+func TestRenameStashFailureRestoresStashes(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	repo.CommitFile("test.txt", "original\n", "initial")
+	for _, msg := range []string{"oldest", "middle", "newest"} {
+		repo.WriteFile("test.txt", msg+"\n")
+		repo.Git("stash", "push", "-m", msg)
+	}
+	before, err := GetStashes()
+	if err != nil {
+		t.Fatalf("GetStashes failed: %v", err)
+	}
+
+	// Reject the second update of refs/stash, i.e. storing stash@{0} again
+	// after the renamed stash is stored
+	hook := `#!/bin/sh
+[ "$1" = prepared ] || exit 0
+grep -q refs/stash || exit 0
+count="$(git rev-parse --git-dir)/stash-updates"
+n=$(cat "$count" 2>/dev/null || echo 0)
+n=$((n + 1))
+echo $n > "$count"
+[ $n -ne 2 ]
+`
+	hookPath := filepath.Join(repo.Dir, ".git", "hooks", "reference-transaction")
+	if err := os.WriteFile(hookPath, []byte(hook), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	err = RenameStash(1, "renamed")
+	if err == nil {
+		t.Fatal("expected RenameStash to fail")
+	}
+	for _, stash := range before[:2] {
+		if !strings.Contains(err.Error(), stash.Hash) {
+			t.Errorf("error %q should mention stash %s", err, stash.Hash)
+		}
+	}
+
+	after, err := GetStashes()
+	if err != nil || len(after) != 3 {
+		t.Fatalf("GetStashes = %v, %v", after, err)
+	}
+	for i, want := range []string{"newest", "renamed", "oldest"} {
+		if after[i].Message != want || after[i].Hash != before[i].Hash {
+			t.Errorf("stash@{%d} = %q (%s), want %q (%s)", i, after[i].Message, after[i].Hash, want, before[i].Hash)
+		}
+	}
+}
+
+func TestApplyStashPatch(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	repo.CommitFile("a.txt", "a\n", "initial")
+	repo.CommitFile("b.txt", "b\n", "second")
+	repo.WriteFile("a.txt", "stashed a\n")
+	repo.WriteFile("b.txt", "stashed b\n")
+	repo.Git("stash", "push")
+
+	diff, err := GetStashDiff(0)
+	if err != nil {
+		t.Fatalf("GetStashDiff failed: %v", err)
+	}
+	file := &diff.Worktree.Files[1]
+	if err := ApplyStashPatch(file.Hunks[0].GeneratePatch(file)); err != nil {
+		t.Fatalf("ApplyStashPatch failed: %v", err)
+	}
+
+	if repo.ReadFile("a.txt") != "a\n" || repo.ReadFile("b.txt") != "stashed b\n" {
+		t.Error("only the picked hunk should be applied")
+	}
+	if stashes, _ := GetStashes(); len(stashes) != 1 {
+		t.Error("the stash should be kept")
+	}
+}
+
+func TestGetStashCompareDiff(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	repo.CommitFile("a.txt", "a\n", "initial")
+	repo.CommitFile("b.txt", "b\n", "second")
+	repo.WriteFile("a.txt", "stashed\n")
+	repo.Git("stash", "push")
+	repo.WriteFile("a.txt", "working\n")
+	repo.WriteFile("b.txt", "unrelated\n")
+
+	diff, err := GetStashCompareDiff(0)
+	if err != nil {
+		t.Fatalf("GetStashCompareDiff failed: %v", err)
+	}
+
+	if len(diff.Files) != 1 || diff.Files[0].Path != "a.txt" {
+		t.Fatalf("expected only the stashed file, got %+v", diff.Files)
+	}
+	lines := diff.Files[0].Hunks[0].Lines
+	if lines[0].Content != "-working" || lines[1].Content != "+stashed" {
+		t.Errorf("expected the diff from the working tree to the stash, got %+v", lines)
+	}
+
+	// Applying the comparison makes the file match the stash
+	file := &diff.Files[0]
+	if err := ApplyStashPatch(file.Hunks[0].GeneratePatch(file)); err != nil {
+		t.Fatalf("ApplyStashPatch failed: %v", err)
+	}
+	if content := repo.ReadFile("a.txt"); content != "stashed\n" {
+		t.Errorf("expected the stashed content, got %q", content)
+	}
+}
@@ -12,16 +12,26 @@ type FileStatus struct {
 	WorkStatus          byte   // Status in the working tree
 	OriginalPath        string // For renamed files (repo-relative)
 	OriginalDisplayPath string // For renamed files (cwd-relative)
+	Submodule           SubmoduleState
 }
 
 // IsStaged returns true if the file has staged changes
 func (f FileStatus) IsStaged() bool {
-	return f.IndexStatus != ' ' && f.IndexStatus != '?'
+	return f.IndexStatus != ' ' && f.IndexStatus != '?' && !f.IsConflicted()
 }
 
 // IsUnstaged returns true if the file has unstaged changes
 func (f FileStatus) IsUnstaged() bool {
-	return f.WorkStatus != ' ' && f.WorkStatus != '?'
+	return f.WorkStatus != ' ' && f.WorkStatus != '?' && !f.IsConflicted()
+}
+
+// IsConflicted returns true if the file is unmerged (DD, AU, UD, UA, DU, AA, UU)
+func (f FileStatus) IsConflicted() bool {
+	if f.IndexStatus == 'U' || f.WorkStatus == 'U' {
+		return true
+	}
+	return (f.IndexStatus == 'A' && f.WorkStatus == 'A') ||
+		(f.IndexStatus == 'D' && f.WorkStatus == 'D')
 }
 
 // IsUntracked returns true if the file is untracked
@@ -34,6 +44,9 @@ func (f FileStatus) StatusDescription() string {
 	if f.IsUntracked() {
 		return "untracked"
 	}
+	if f.IsConflicted() {
+		return "unmerged: " + ConflictDescription(f.IndexStatus, f.WorkStatus)
+	}
 
 	var parts []string
 
@@ -54,7 +67,11 @@ func (f FileStatus) StatusDescription() string {
 	// Work tree status
 	switch f.WorkStatus {
 	case 'M':
-		parts = append(parts, "modified")
+		modified := "modified"
+		if state := f.Submodule.Description(); state != "" {
+			modified += " (" + state + ")"
+		}
+		parts = append(parts, modified)
 	case 'D':
 		parts = append(parts, "deleted")
 	}
@@ -62,11 +79,33 @@ func (f FileStatus) StatusDescription() string {
 	return strings.Join(parts, ", ")
 }
 
+// ConflictDescription returns git's wording for an unmerged status pair
+func ConflictDescription(indexStatus, workStatus byte) string {
+	switch string([]byte{indexStatus, workStatus}) {
+	case "DD":
+		return "both deleted"
+	case "AU":
+		return "added by us"
+	case "UD":
+		return "deleted by them"
+	case "UA":
+		return "added by them"
+	case "DU":
+		return "deleted by us"
+	case "AA":
+		return "both added"
+	case "UU":
+		return "both modified"
+	}
+	return "unmerged"
+}
+
 // StatusResult holds all file statuses grouped by type
 type StatusResult struct {
-	Staged    []FileStatus
-	Unstaged  []FileStatus
-	Untracked []FileStatus
+	Staged     []FileStatus
+	Conflicted []FileStatus
+	Unstaged   []FileStatus
+	Untracked  []FileStatus
 }
 
 // GetStatus returns the current git status
@@ -76,6 +115,14 @@ func GetStatus() (*StatusResult, error) {
 		return nil, err
 	}
 
+	var submodules map[string]SubmoduleState
+	if hasSubmodules() {
+		submodules, err = getSubmoduleStates()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	result := &StatusResult{}
 	lines := strings.Split(output, "\n")
 
@@ -110,11 +157,14 @@ func GetStatus() (*StatusResult, error) {
 			WorkStatus:          workStatus,
 			OriginalPath:        origPath,
 			OriginalDisplayPath: origDisplayPath,
+			Submodule:           submodules[path],
 		}
 
 		// Categorize the file
 		if fs.IsUntracked() {
 			result.Untracked = append(result.Untracked, fs)
+		} else if fs.IsConflicted() {
+			result.Conflicted = append(result.Conflicted, fs)
 		} else {
 			if fs.IsStaged() {
 				result.Staged = append(result.Staged, fs)
@@ -140,10 +190,13 @@ func (s *StatusResult) TotalFiles() int {
 	for _, f := range s.Untracked {
 		seen[f.Path] = true
 	}
+	for _, f := range s.Conflicted {
+		seen[f.Path] = true
+	}
 	return len(seen)
 }
 
 // IsEmpty returns true if there are no changes
 func (s *StatusResult) IsEmpty() bool {
-	return len(s.Staged) == 0 && len(s.Unstaged) == 0 && len(s.Untracked) == 0
+	return len(s.Staged) == 0 && len(s.Unstaged) == 0 && len(s.Untracked) == 0 && len(s.Conflicted) == 0
 }
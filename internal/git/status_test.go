@@ -369,6 +369,15 @@ func TestFileStatus_StatusDescription(t *testing.T) {
 			},
 			expected: "staged: modified, modified",
 		},
+		{
+			name: "submodule with new commits and untracked content",
+			status: FileStatus{
+				IndexStatus: ' ',
+				WorkStatus:  'M',
+				Submodule:   SubmoduleState{IsSubmodule: true, NewCommits: true, UntrackedContent: true},
+			},
+			expected: "modified (new commits, untracked content)",
+		},
 	}
 
 	for _, tt := range tests {
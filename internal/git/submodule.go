@@ -0,0 +1,168 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SubmoduleState is the working tree state of a submodule, as reported by
+// git status --porcelain=v2
+type SubmoduleState struct {
+	IsSubmodule      bool
+	NewCommits       bool // its HEAD differs from the commit recorded in the index
+	ModifiedContent  bool // tracked files are modified
+	UntrackedContent bool // untracked files are present
+}
+
+// Description returns git's wording for the state, e.g.
+// "new commits, modified content"
+func (s SubmoduleState) Description() string {
+	var parts []string
+	if s.NewCommits {
+		parts = append(parts, "new commits")
+	}
+	if s.ModifiedContent {
+		parts = append(parts, "modified content")
+	}
+	if s.UntrackedContent {
+		parts = append(parts, "untracked content")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// parseSubmoduleState parses the <sub> field of porcelain v2 ("N..." for
+// regular files, "S<c><m><u>" for submodules)
+func parseSubmoduleState(field string) SubmoduleState {
+	if len(field) != 4 || field[0] != 'S' {
+		return SubmoduleState{}
+	}
+	return SubmoduleState{
+		IsSubmodule:      true,
+		NewCommits:       field[1] == 'C',
+		ModifiedContent:  field[2] == 'M',
+		UntrackedContent: field[3] == 'U',
+	}
+}
+
+// hasSubmodules returns true if the repository declares submodules
+func hasSubmodules() bool {
+	root := GetRepoRoot()
+	if root == "" {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(root, ".gitmodules"))
+	return err == nil
+}
+
+// getSubmoduleStates returns the state of each changed submodule by path.
+// Porcelain v1 reports them as plain modified files, so this asks v2.
+func getSubmoduleStates() (map[string]SubmoduleState, error) {
+	output, err := Run("status", "--porcelain=v2")
+	if err != nil {
+		return nil, err
+	}
+
+	states := make(map[string]SubmoduleState)
+	for _, line := range strings.Split(output, "\n") {
+		// Ordinary (1), renamed (2) and unmerged (u) entries; the number of
+		// fields before the path differs
+		var fields []string
+		var path string
+		switch {
+		case strings.HasPrefix(line, "1 "):
+			fields = strings.SplitN(line, " ", 9)
+			if len(fields) == 9 {
+				path = fields[8]
+			}
+		case strings.HasPrefix(line, "2 "):
+			fields = strings.SplitN(line, " ", 10)
+			if len(fields) == 10 {
+				path, _, _ = strings.Cut(fields[9], "\t")
+			}
+		case strings.HasPrefix(line, "u "):
+			fields = strings.SplitN(line, " ", 11)
+			if len(fields) == 11 {
+				path = fields[10]
+			}
+		}
+		if path == "" {
+			continue
+		}
+		if state := parseSubmoduleState(fields[2]); state.IsSubmodule {
+			states[path] = state
+		}
+	}
+	return states, nil
+}
+
+// Submodule represents a submodule registered in the repository
+type Submodule struct {
+	Path        string // relative to the repository root
+	Commit      string // checked out commit, or the recorded one if not initialized
+	Describe    string // e.g. "heads/main" or "v1.0", empty if not initialized
+	Initialized bool
+	OutOfSync   bool // the checked out commit differs from the recorded one
+	Conflicted  bool
+}
+
+// GetSubmodules returns the submodules of the repository (not recursive)
+func GetSubmodules() ([]Submodule, error) {
+	if !hasSubmodules() {
+		return nil, nil
+	}
+	output, err := Run("submodule", "status")
+	if err != nil {
+		return nil, err
+	}
+	return parseSubmoduleStatus(output), nil
+}
+
+// parseSubmoduleStatus parses git submodule status lines:
+// "<flag><sha> <path> (<describe>)", where the flag is ' ', '-', '+' or 'U'
+func parseSubmoduleStatus(output string) []Submodule {
+	var submodules []Submodule
+	for _, line := range strings.Split(output, "\n") {
+		if len(line) < 2 {
+			continue
+		}
+		flag := line[0]
+		fields := strings.SplitN(line[1:], " ", 2)
+		if len(fields) < 2 {
+			continue
+		}
+		sm := Submodule{
+			Commit:      fields[0],
+			Initialized: flag != '-',
+			OutOfSync:   flag == '+',
+			Conflicted:  flag == 'U',
+		}
+		path := fields[1]
+		if i := strings.LastIndex(path, " ("); i >= 0 && strings.HasSuffix(path, ")") {
+			sm.Describe = path[i+2 : len(path)-1]
+			path = path[:i]
+		}
+		sm.Path = path
+		submodules = append(submodules, sm)
+	}
+	return submodules
+}
+
+// InitSubmodule registers a submodule's URL in .git/config
+func InitSubmodule(path string) error {
+	_, err := Run("submodule", "init", "--", path)
+	return err
+}
+
+// UpdateSubmodule clones a submodule if needed and checks out the commit
+// recorded in the superproject
+func UpdateSubmodule(path string) error {
+	_, err := Run("submodule", "update", "--init", "--", path)
+	return err
+}
+
+// SyncSubmodule copies a submodule's URL from .gitmodules to the configuration
+func SyncSubmodule(path string) error {
+	_, err := Run("submodule", "sync", "--", path)
+	return err
+}
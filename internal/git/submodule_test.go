@@ -0,0 +1,216 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setupSubmodule adds a one-commit repository as the submodule "lib" and
+// commits it. Returns the path of the submodule's upstream repository.
+func setupSubmodule(repo *TestRepo) string {
+	repo.T.Helper()
+
+	// Cloning from a local path is disabled by default for submodules, and
+	// the clones need an identity to commit
+	for i, kv := range [][2]string{
+		{"protocol.file.allow", "always"},
+		{"user.email", "test@example.com"},
+		{"user.name", "Test User"},
+	} {
+		repo.T.Setenv(fmt.Sprintf("GIT_CONFIG_KEY_%d", i), kv[0])
+		repo.T.Setenv(fmt.Sprintf("GIT_CONFIG_VALUE_%d", i), kv[1])
+	}
+	repo.T.Setenv("GIT_CONFIG_COUNT", "3")
+
+	libDir := repo.T.TempDir()
+	repo.GitIn(libDir, "init")
+	if err := os.WriteFile(filepath.Join(libDir, "lib.txt"), []byte("lib\n"), 0644); err != nil {
+		repo.T.Fatal(err)
+	}
+	repo.GitIn(libDir, "add", "lib.txt")
+	repo.GitIn(libDir, "commit", "-m", "Lib commit")
+
+	repo.Git("submodule", "add", libDir, "lib")
+	repo.Git("commit", "-m", "Add lib")
+	return libDir
+}
+
+func TestParseSubmoduleState(t *testing.T) {
+	tests := []struct {
+		field    string
+		expected SubmoduleState
+	}{
+		{"N...", SubmoduleState{}},
+		{"S...", SubmoduleState{IsSubmodule: true}},
+		{"SC..", SubmoduleState{IsSubmodule: true, NewCommits: true}},
+		{"S.MU", SubmoduleState{IsSubmodule: true, ModifiedContent: true, UntrackedContent: true}},
+	}
+
+	for _, tt := range tests {
+		if got := parseSubmoduleState(tt.field); got != tt.expected {
+			t.Errorf("parseSubmoduleState(%q) = %+v, want %+v", tt.field, got, tt.expected)
+		}
+	}
+}
+
+func TestParseSubmoduleStatus(t *testing.T) {
+	output := ` 1111111111111111111111111111111111111111 lib (heads/main)
+-2222222222222222222222222222222222222222 vendor/other
++3333333333333333333333333333333333333333 third party (v1.0-2-g3333333)
+`
+
+	submodules := parseSubmoduleStatus(output)
+	if len(submodules) != 3 {
+		t.Fatalf("expected 3 submodules, got %d", len(submodules))
+	}
+
+	if sm := submodules[0]; sm.Path != "lib" || sm.Describe != "heads/main" || !sm.Initialized || sm.OutOfSync {
+		t.Errorf("unexpected submodule: %+v", sm)
+	}
+	if sm := submodules[1]; sm.Path != "vendor/other" || sm.Initialized || sm.Describe != "" {
+		t.Errorf("expected an uninitialized submodule, got %+v", sm)
+	}
+	if sm := submodules[2]; sm.Path != "third party" || !sm.OutOfSync || sm.Describe != "v1.0-2-g3333333" {
+		t.Errorf("expected an out of sync submodule with a space in its path, got %+v", sm)
+	}
+}
+
+func TestGetSubmodulesNone(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+
+	submodules, err := GetSubmodules()
+	if err != nil {
+		t.Fatalf("GetSubmodules failed: %v", err)
+	}
+	if len(submodules) != 0 {
+		t.Errorf("expected no submodules, got %d", len(submodules))
+	}
+}
+
+func TestGetStatusSubmoduleStates(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+	setupSubmodule(repo)
+
+	// New commit and untracked content inside the submodule
+	libPath := filepath.Join(repo.Dir, "lib")
+	repo.WriteFile("lib/lib.txt", "changed\n")
+	repo.GitIn(libPath, "commit", "-am", "Change lib")
+	repo.WriteFile("lib/new.txt", "new\n")
+
+	status, err := GetStatus()
+	if err != nil {
+		t.Fatalf("GetStatus failed: %v", err)
+	}
+	if len(status.Unstaged) != 1 {
+		t.Fatalf("expected 1 unstaged entry, got %d", len(status.Unstaged))
+	}
+	lib := status.Unstaged[0]
+	expected := SubmoduleState{IsSubmodule: true, NewCommits: true, UntrackedContent: true}
+	if lib.Path != "lib" || lib.Submodule != expected {
+		t.Errorf("unexpected submodule status: %+v", lib)
+	}
+	if lib.StatusDescription() != "modified (new commits, untracked content)" {
+		t.Errorf("unexpected description %q", lib.StatusDescription())
+	}
+}
+
+func TestGetDiffSubmoduleHunk(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+	setupSubmodule(repo)
+	repo.WriteFile("lib/lib.txt", "changed\n")
+
+	diff, err := GetDiff()
+	if err != nil {
+		t.Fatalf("GetDiff failed: %v", err)
+	}
+	hunks := diff.GetAllHunks()
+	if len(hunks) != 1 || !hunks[0].Submodule {
+		t.Fatalf("expected 1 submodule hunk, got %+v", hunks)
+	}
+
+	var dirty bool
+	for _, line := range hunks[0].Lines {
+		if line.Type == LineAdded {
+			_, dirty, _ = ParseSubprojectLine(line.Content)
+		}
+	}
+	if !dirty {
+		t.Error("the new side of a modified submodule should be dirty")
+	}
+}
+
+func TestParseSubprojectLine(t *testing.T) {
+	hash, dirty, ok := ParseSubprojectLine("+Subproject commit abc123-dirty")
+	if !ok || hash != "abc123" || !dirty {
+		t.Errorf("got %q, %v, %v", hash, dirty, ok)
+	}
+	hash, dirty, ok = ParseSubprojectLine("-Subproject commit abc123")
+	if !ok || hash != "abc123" || dirty {
+		t.Errorf("got %q, %v, %v", hash, dirty, ok)
+	}
+	if _, _, ok := ParseSubprojectLine("+some text"); ok {
+		t.Error("expected a regular line not to parse")
+	}
+}
+
+func TestSubmoduleActions(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+	libDir := setupSubmodule(repo)
+
+	// A fresh clone has the submodule registered but not checked out
+	cloneDir := t.TempDir()
+	repo.Git("clone", repo.Dir, cloneDir)
+	if err := os.Chdir(cloneDir); err != nil {
+		t.Fatal(err)
+	}
+	ResetRepoRoot()
+
+	submodules, err := GetSubmodules()
+	if err != nil {
+		t.Fatalf("GetSubmodules failed: %v", err)
+	}
+	if len(submodules) != 1 || submodules[0].Initialized {
+		t.Fatalf("expected an uninitialized submodule, got %+v", submodules)
+	}
+
+	if err := InitSubmodule("lib"); err != nil {
+		t.Fatalf("InitSubmodule failed: %v", err)
+	}
+	if url := strings.TrimSpace(repo.GitIn(cloneDir, "config", "submodule.lib.url")); url != libDir {
+		t.Errorf("submodule.lib.url = %s, want %s", url, libDir)
+	}
+
+	if err := UpdateSubmodule("lib"); err != nil {
+		t.Fatalf("UpdateSubmodule failed: %v", err)
+	}
+	submodules, err = GetSubmodules()
+	if err != nil {
+		t.Fatalf("GetSubmodules failed: %v", err)
+	}
+	if !submodules[0].Initialized || submodules[0].OutOfSync {
+		t.Errorf("expected a checked out submodule, got %+v", submodules[0])
+	}
+	if _, err := os.Stat(filepath.Join(cloneDir, "lib", "lib.txt")); err != nil {
+		t.Error("the submodule should be checked out")
+	}
+
+	// Sync picks up a URL changed in .gitmodules
+	repo.GitIn(cloneDir, "config", "-f", ".gitmodules", "submodule.lib.url", "/elsewhere")
+	if err := SyncSubmodule("lib"); err != nil {
+		t.Fatalf("SyncSubmodule failed: %v", err)
+	}
+	if url := strings.TrimSpace(repo.GitIn(cloneDir, "config", "submodule.lib.url")); url != "/elsewhere" {
+		t.Errorf("submodule.lib.url = %s after sync, want /elsewhere", url)
+	}
+}
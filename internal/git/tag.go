@@ -0,0 +1,92 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Tag represents a tag and the commit it points to
+type Tag struct {
+	Name      string
+	Target    string // hash of the tagged commit
+	Annotated bool
+	Tagger    string    // annotated tags only
+	Date      time.Time // tagging date, or the commit date of a lightweight tag
+	Message   string    // annotated tags only
+}
+
+// Subject returns the first line of the tag message
+func (t Tag) Subject() string {
+	return strings.SplitN(t.Message, "\n", 2)[0]
+}
+
+// Fields: name, object type, peeled target (annotated), object, tagger,
+// date, message
+var tagFormat = strings.Join([]string{
+	"%(refname:short)", "%(objecttype)", "%(*objectname)", "%(objectname)",
+	"%(taggername)", "%(creatordate:unix)", "%(contents)",
+}, "%1f") + "%1e"
+
+// GetTags returns all tags, newest first
+func GetTags() ([]Tag, error) {
+	output, err := Run("for-each-ref", "--sort=-creatordate", "--format="+tagFormat, "refs/tags/")
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []Tag
+	for _, record := range strings.Split(output, logRecordSep) {
+		record = strings.TrimLeft(record, "\n")
+		if record == "" {
+			continue
+		}
+		fields := strings.Split(record, logFieldSep)
+		if len(fields) < 7 {
+			continue
+		}
+		tag := Tag{
+			Name:   fields[0],
+			Target: fields[3],
+			Date:   parseUnixTime(fields[5]),
+		}
+		if fields[1] == "tag" {
+			tag.Annotated = true
+			tag.Target = fields[2]
+			tag.Tagger = fields[4]
+			tag.Message = strings.TrimSpace(fields[6])
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+// CreateTag tags target (HEAD if empty). An empty message creates a
+// lightweight tag, otherwise an annotated one.
+func CreateTag(name, target, message string) error {
+	if name == "" {
+		return fmt.Errorf("tag name is empty")
+	}
+	args := []string{"tag"}
+	if message != "" {
+		args = append(args, "-a", "-m", message)
+	}
+	args = append(args, name)
+	if target != "" {
+		args = append(args, target)
+	}
+	_, err := Run(args...)
+	return err
+}
+
+// DeleteTag deletes a local tag
+func DeleteTag(name string) error {
+	_, err := Run("tag", "-d", name)
+	return err
+}
+
+// PushTag pushes a tag to a remote
+func PushTag(remote, name string) error {
+	_, err := Run("push", remote, "refs/tags/"+name)
+	return err
+}
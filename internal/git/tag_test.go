@@ -0,0 +1,112 @@
+package git
+
+import (
+	"strings"
+	"testing"
+)
+
+func findTag(tags []Tag, name string) (Tag, bool) {
+	for _, tag := range tags {
+		if tag.Name == name {
+			return tag, true
+		}
+	}
+	return Tag{}, false
+}
+
+func TestGetTagsEmpty(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+
+	tags, err := GetTags()
+	if err != nil {
+		t.Fatalf("GetTags failed: %v", err)
+	}
+	if len(tags) != 0 {
+		t.Errorf("expected no tags, got %d", len(tags))
+	}
+}
+
+func TestCreateTag(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+	first := strings.TrimSpace(repo.Git("rev-parse", "HEAD"))
+	repo.CommitFile("file.txt", "content\n", "Second")
+	head := strings.TrimSpace(repo.Git("rev-parse", "HEAD"))
+
+	if err := CreateTag("v1.0", first, ""); err != nil {
+		t.Fatalf("CreateTag lightweight failed: %v", err)
+	}
+	if err := CreateTag("v2.0", "", "Release 2.0\n\nNotes"); err != nil {
+		t.Fatalf("CreateTag annotated failed: %v", err)
+	}
+
+	tags, err := GetTags()
+	if err != nil {
+		t.Fatalf("GetTags failed: %v", err)
+	}
+	if len(tags) != 2 {
+		t.Fatalf("expected 2 tags, got %d", len(tags))
+	}
+
+	light, _ := findTag(tags, "v1.0")
+	if light.Annotated || light.Target != first || light.Message != "" || light.Date.IsZero() {
+		t.Errorf("unexpected lightweight tag: %+v", light)
+	}
+
+	annotated, _ := findTag(tags, "v2.0")
+	if !annotated.Annotated || annotated.Target != head {
+		t.Errorf("expected an annotated tag on HEAD, got %+v", annotated)
+	}
+	if annotated.Tagger != "Test User" || annotated.Subject() != "Release 2.0" || annotated.Message != "Release 2.0\n\nNotes" {
+		t.Errorf("unexpected annotated tag details: %+v", annotated)
+	}
+}
+
+func TestCreateTagErrors(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+
+	if err := CreateTag("", "", ""); err == nil {
+		t.Error("expected an error for an empty name")
+	}
+	if err := CreateTag("v1", "", ""); err != nil {
+		t.Fatalf("CreateTag failed: %v", err)
+	}
+	if err := CreateTag("v1", "", ""); err == nil {
+		t.Error("expected an error for an existing tag")
+	}
+}
+
+func TestDeleteTag(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+	repo.Git("tag", "v1")
+
+	if err := DeleteTag("v1"); err != nil {
+		t.Fatalf("DeleteTag failed: %v", err)
+	}
+	tags, _ := GetTags()
+	if len(tags) != 0 {
+		t.Errorf("expected the tag to be deleted, got %+v", tags)
+	}
+}
+
+func TestPushTag(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+	remoteDir := repo.SetupRemote()
+	repo.Git("tag", "-a", "v1", "-m", "Version 1")
+
+	if err := PushTag("origin", "v1"); err != nil {
+		t.Fatalf("PushTag failed: %v", err)
+	}
+	if out := repo.GitIn(remoteDir, "tag", "--list"); strings.TrimSpace(out) != "v1" {
+		t.Errorf("expected v1 on the remote, got %q", out)
+	}
+}
@@ -167,8 +167,46 @@ func (r *TestRepo) SetupRemote() string {
 	return remoteDir
 }
 
+// CloneRemote clones the remote into a second working copy, checks out
+// branch and returns the clone's directory (removed on test cleanup)
+func (r *TestRepo) CloneRemote(remoteDir, branch string) string {
+	r.T.Helper()
+	dir := r.T.TempDir()
+	r.Git("clone", remoteDir, dir)
+	r.GitIn(dir, "checkout", branch)
+	r.GitIn(dir, "config", "user.email", "other@example.com")
+	r.GitIn(dir, "config", "user.name", "Other User")
+	return dir
+}
+
+// GitIn runs a git command in another directory, such as a clone of the remote
+func (r *TestRepo) GitIn(dir string, args ...string) string {
+	r.T.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		r.T.Fatalf("git %v in %s failed: %v\n%s", args, dir, err, output)
+	}
+	return string(output)
+}
+
 // PushToRemote pushes the current branch to origin
 func (r *TestRepo) PushToRemote() {
 	r.T.Helper()
 	r.Git("push", "-u", "origin", "HEAD")
 }
+
+// SetupMergeConflict commits conflicting changes to a file on the current
+// branch and on a "theirs" branch, then merges to leave the file unmerged
+func (r *TestRepo) SetupMergeConflict(name, base, ours, theirs string) {
+	r.T.Helper()
+	r.CommitFile(name, base, "base")
+	r.Git("checkout", "-b", "theirs")
+	r.CommitFile(name, theirs, "theirs change")
+	r.Git("checkout", "-")
+	r.CommitFile(name, ours, "ours change")
+	if _, err := r.GitAllowFailure("merge", "theirs"); err == nil {
+		r.T.Fatal("expected merge to stop with conflicts")
+	}
+}
@@ -0,0 +1,143 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Worktree represents a working tree attached to the repository
+type Worktree struct {
+	Path           string
+	Head           string // checked out commit, empty for a bare repository
+	Branch         string // short branch name, empty when detached
+	Bare           bool
+	Detached       bool
+	Locked         bool
+	LockReason     string
+	Prunable       bool
+	PrunableReason string
+	Current        bool // the worktree go-on-git is running in
+}
+
+// GetWorktrees returns the main worktree followed by the linked ones
+func GetWorktrees() ([]Worktree, error) {
+	output, err := Run("worktree", "list", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+	return parseWorktrees(output, GetRepoRoot()), nil
+}
+
+// parseWorktrees parses the output of git worktree list --porcelain, where
+// each worktree is a block of "key value" lines separated by blank lines
+func parseWorktrees(output, root string) []Worktree {
+	var worktrees []Worktree
+	for _, block := range strings.Split(strings.TrimSpace(output), "\n\n") {
+		var wt Worktree
+		for _, line := range strings.Split(block, "\n") {
+			key, value, _ := strings.Cut(line, " ")
+			switch key {
+			case "worktree":
+				wt.Path = value
+			case "HEAD":
+				wt.Head = value
+			case "branch":
+				wt.Branch = strings.TrimPrefix(value, "refs/heads/")
+			case "bare":
+				wt.Bare = true
+			case "detached":
+				wt.Detached = true
+			case "locked":
+				wt.Locked = true
+				wt.LockReason = value
+			case "prunable":
+				wt.Prunable = true
+				wt.PrunableReason = value
+			}
+		}
+		if wt.Path == "" {
+			continue
+		}
+		wt.Current = root != "" && samePath(wt.Path, root)
+		worktrees = append(worktrees, wt)
+	}
+	return worktrees
+}
+
+// samePath compares two paths after resolving symlinks, since git may
+// report either form (e.g. /tmp and /private/tmp on macOS)
+func samePath(a, b string) bool {
+	if resolved, err := filepath.EvalSymlinks(a); err == nil {
+		a = resolved
+	}
+	if resolved, err := filepath.EvalSymlinks(b); err == nil {
+		b = resolved
+	}
+	return filepath.Clean(a) == filepath.Clean(b)
+}
+
+// AddWorktree checks out branch in a new worktree at path. With create, the
+// branch is created from HEAD first.
+func AddWorktree(path, branch string, create bool) error {
+	if path == "" {
+		return fmt.Errorf("worktree path is empty")
+	}
+	if branch == "" {
+		return fmt.Errorf("branch name is empty")
+	}
+	if create {
+		_, err := Run("worktree", "add", "-b", branch, "--", path)
+		return err
+	}
+	_, err := Run("worktree", "add", "--", path, branch)
+	return err
+}
+
+// RemoveWorktree removes a linked worktree. Force removes it even with
+// uncommitted changes or a lock.
+func RemoveWorktree(path string, force bool) error {
+	args := []string{"worktree", "remove"}
+	if force {
+		// Twice to also remove a locked worktree
+		args = append(args, "--force", "--force")
+	}
+	args = append(args, "--", path)
+	_, err := Run(args...)
+	return err
+}
+
+// PruneWorktrees removes the administrative files of worktrees whose
+// directories no longer exist
+func PruneWorktrees() error {
+	_, err := Run("worktree", "prune")
+	return err
+}
+
+// DefaultWorktreePath suggests a directory for a new worktree next to the
+// current one, e.g. ../repo-feature for the branch feature
+func DefaultWorktreePath(branch string) string {
+	root := GetRepoRoot()
+	if root == "" || branch == "" {
+		return ""
+	}
+	name := filepath.Base(root) + "-" + strings.ReplaceAll(branch, "/", "-")
+	return filepath.Join(filepath.Dir(root), name)
+}
+
+// SwitchWorktree moves the process into another worktree so that all
+// following git commands run there
+func SwitchWorktree(path string) error {
+	gitMu.Lock()
+	defer gitMu.Unlock()
+	// Hold the root lock across the chdir so no lookup caches the old root
+	repoRootMu.Lock()
+	defer repoRootMu.Unlock()
+
+	if err := os.Chdir(path); err != nil {
+		return fmt.Errorf("switch to worktree %s: %w", path, err)
+	}
+	resetRepoRootLocked()
+	return nil
+}
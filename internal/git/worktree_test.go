@@ -0,0 +1,219 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestParseWorktrees(t *testing.T) {
+	output := `worktree /repo
+HEAD 1111111111111111111111111111111111111111
+branch refs/heads/main
+
+worktree /repo-feature
+HEAD 2222222222222222222222222222222222222222
+branch refs/heads/feature/x
+locked moving to a new disk
+
+worktree /repo-detached
+HEAD 3333333333333333333333333333333333333333
+detached
+prunable gitdir file points to non-existent location
+`
+
+	worktrees := parseWorktrees(output, "/repo-feature")
+	if len(worktrees) != 3 {
+		t.Fatalf("expected 3 worktrees, got %d", len(worktrees))
+	}
+
+	main := worktrees[0]
+	if main.Path != "/repo" || main.Branch != "main" || main.Current {
+		t.Errorf("unexpected main worktree: %+v", main)
+	}
+
+	feature := worktrees[1]
+	if feature.Branch != "feature/x" || !feature.Current {
+		t.Errorf("unexpected feature worktree: %+v", feature)
+	}
+	if !feature.Locked || feature.LockReason != "moving to a new disk" {
+		t.Errorf("expected a locked worktree with a reason, got %+v", feature)
+	}
+
+	detached := worktrees[2]
+	if !detached.Detached || detached.Branch != "" {
+		t.Errorf("expected a detached worktree, got %+v", detached)
+	}
+	if !detached.Prunable || detached.PrunableReason == "" {
+		t.Errorf("expected a prunable worktree, got %+v", detached)
+	}
+}
+
+func TestAddAndRemoveWorktree(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+	repo.CreateBranch("existing", false)
+
+	newPath := filepath.Join(t.TempDir(), "new")
+	if err := AddWorktree(newPath, "feature", true); err != nil {
+		t.Fatalf("AddWorktree with a new branch failed: %v", err)
+	}
+	existingPath := filepath.Join(t.TempDir(), "existing")
+	if err := AddWorktree(existingPath, "existing", false); err != nil {
+		t.Fatalf("AddWorktree with an existing branch failed: %v", err)
+	}
+
+	worktrees, err := GetWorktrees()
+	if err != nil {
+		t.Fatalf("GetWorktrees failed: %v", err)
+	}
+	if len(worktrees) != 3 {
+		t.Fatalf("expected 3 worktrees, got %d", len(worktrees))
+	}
+	if !worktrees[0].Current {
+		t.Error("the main worktree should be the current one")
+	}
+	branches := map[string]bool{}
+	for _, wt := range worktrees[1:] {
+		branches[wt.Branch] = true
+		if wt.Current {
+			t.Errorf("%s should not be the current worktree", wt.Path)
+		}
+	}
+	if !branches["feature"] || !branches["existing"] {
+		t.Errorf("expected worktrees for feature and existing, got %v", branches)
+	}
+
+	// A dirty worktree is only removed with force
+	if err := os.WriteFile(filepath.Join(newPath, "dirty.txt"), []byte("dirty\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := RemoveWorktree(newPath, false); err == nil {
+		t.Error("expected removing a dirty worktree to fail")
+	}
+	if err := RemoveWorktree(newPath, true); err != nil {
+		t.Fatalf("RemoveWorktree with force failed: %v", err)
+	}
+	if _, err := os.Stat(newPath); !os.IsNotExist(err) {
+		t.Error("the worktree directory should be removed")
+	}
+}
+
+func TestAddWorktreeValidation(t *testing.T) {
+	if err := AddWorktree("", "feature", true); err == nil {
+		t.Error("expected an error for an empty path")
+	}
+	if err := AddWorktree("/tmp/wt", "", false); err == nil {
+		t.Error("expected an error for an empty branch")
+	}
+}
+
+func TestPruneWorktrees(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+
+	path := filepath.Join(t.TempDir(), "gone")
+	repo.Git("worktree", "add", "-b", "gone", path)
+	if err := os.RemoveAll(path); err != nil {
+		t.Fatal(err)
+	}
+
+	worktrees, err := GetWorktrees()
+	if err != nil {
+		t.Fatalf("GetWorktrees failed: %v", err)
+	}
+	if len(worktrees) != 2 || !worktrees[1].Prunable {
+		t.Fatalf("expected a prunable worktree, got %+v", worktrees)
+	}
+
+	if err := PruneWorktrees(); err != nil {
+		t.Fatalf("PruneWorktrees failed: %v", err)
+	}
+	worktrees, err = GetWorktrees()
+	if err != nil {
+		t.Fatalf("GetWorktrees failed: %v", err)
+	}
+	if len(worktrees) != 1 {
+		t.Errorf("expected 1 worktree after pruning, got %d", len(worktrees))
+	}
+}
+
+func TestSwitchWorktree(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+
+	path := filepath.Join(t.TempDir(), "feature")
+	repo.Git("worktree", "add", "-b", "feature", path)
+
+	// Prime the cached root with the main worktree
+	if !samePath(GetRepoRoot(), repo.Dir) {
+		t.Fatalf("GetRepoRoot = %s, want %s", GetRepoRoot(), repo.Dir)
+	}
+
+	if err := SwitchWorktree(path); err != nil {
+		t.Fatalf("SwitchWorktree failed: %v", err)
+	}
+	if !samePath(GetRepoRoot(), path) {
+		t.Errorf("GetRepoRoot = %s, want %s", GetRepoRoot(), path)
+	}
+
+	// Git commands now run in the new worktree
+	branch, err := Run("branch", "--show-current")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if branch != "feature\n" {
+		t.Errorf("current branch = %q, want 'feature'", branch)
+	}
+
+	if err := SwitchWorktree(filepath.Join(path, "missing")); err == nil {
+		t.Error("expected an error for a missing directory")
+	}
+}
+
+func TestSwitchWorktreeConcurrentRoot(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+	repo.InitialCommit()
+
+	path := filepath.Join(t.TempDir(), "feature")
+	repo.Git("worktree", "add", "-b", "feature", path)
+
+	// Root lookups outside Run must not race with a switch (go test -race)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 5; j++ {
+				GetRepoRoot()
+			}
+		}()
+	}
+	if err := SwitchWorktree(path); err != nil {
+		t.Fatalf("SwitchWorktree failed: %v", err)
+	}
+	wg.Wait()
+
+	if !samePath(GetRepoRoot(), path) {
+		t.Errorf("GetRepoRoot = %s, want %s", GetRepoRoot(), path)
+	}
+}
+
+func TestDefaultWorktreePath(t *testing.T) {
+	repo := NewTestRepo(t)
+	defer repo.Cleanup()
+
+	root := GetRepoRoot()
+	want := filepath.Join(filepath.Dir(root), filepath.Base(root)+"-feature-x")
+	if got := DefaultWorktreePath("feature/x"); got != want {
+		t.Errorf("DefaultWorktreePath = %s, want %s", got, want)
+	}
+	if got := DefaultWorktreePath(""); got != "" {
+		t.Errorf("expected an empty path for an empty branch, got %s", got)
+	}
+}
@@ -18,6 +18,14 @@ const (
 	viewStashes
 	viewStashDiff // drill-down from stashes to stash diff
 	viewLog
+	viewConflict // drill-down from status to a conflicted file
+	viewCommit   // drill-down from log to a single commit
+	viewRebase   // interactive rebase planner opened from the log
+	viewTags
+	viewWorktrees
+	viewSubmodules
+	viewReflog
+	viewDiscarded
 )
 
 // FileFilter specifies which hunks to show for a file
@@ -35,6 +43,15 @@ type AppModel struct {
 	branches     BranchesModel
 	stashes      StashesModel
 	log          LogModel
+	conflict     ConflictModel
+	commit       DiffModel // read-only diff of the commit selected in the log
+	rebase       RebaseModel
+	tags         TagsModel
+	tagsReturn   viewMode // view the tags view goes back to (status or log)
+	worktrees    WorktreesModel
+	submodules   SubmodulesModel
+	reflog       ReflogModel
+	discarded    DiscardedModel
 	currentFiles []FileFilter // files being viewed in diff mode
 	width        int
 	height       int
@@ -68,6 +85,7 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Propagate to all views
 		m.status.width = msg.Width
 		m.status.height = msg.Height
+		m.status.commitInput.SetWidth(msg.Width - 2)
 		m.diff.width = msg.Width
 		m.diff.height = msg.Height
 		m.branches.width = msg.Width
@@ -76,11 +94,60 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.stashes.height = msg.Height
 		m.log.width = msg.Width
 		m.log.height = msg.Height
+		m.conflict.width = msg.Width
+		m.conflict.height = msg.Height
+		m.commit.width = msg.Width
+		m.commit.height = msg.Height
+		m.rebase.width = msg.Width
+		m.rebase.height = msg.Height
+		m.tags.width = msg.Width
+		m.tags.height = msg.Height
+		m.worktrees.width = msg.Width
+		m.worktrees.height = msg.Height
+		m.submodules.width = msg.Width
+		m.submodules.height = msg.Height
+		m.reflog.width = msg.Width
+		m.reflog.height = msg.Height
+		m.discarded.width = msg.Width
+		m.discarded.height = msg.Height
+		if m.mode == viewRebase {
+			// The composer only exists once the planner is opened
+			m.rebase.composer.SetWidth(msg.Width - 2)
+		}
 
 	case tickMsg:
 		// Auto-refresh disabled
 		return m, nil
 
+	case operationStoppedMsg:
+		// Resolve the conflicts from the status view, which offers to continue or abort
+		m.mode = viewStatus
+		m.status.operationTarget = msg.target
+		m.status.err = nil
+		return m, tea.Batch(tea.ExitAltScreen, refreshStatus)
+
+	case rebaseResultMsg:
+		// A finished or aborted rebase goes back to the log to show the result
+		if m.mode == viewRebase && msg.finished() {
+			m.mode = viewLog
+			return m, refreshLog
+		}
+
+	case worktreeSwitchedMsg:
+		// Everything now runs in the other worktree, start over from its status
+		m.mode = viewStatus
+		m.status.operationTarget = ""
+		m.status.err = nil
+		return m, tea.Batch(tea.ExitAltScreen, refreshStatus)
+
+	case conflictResolvedMsg:
+		// File resolved from the conflict view - return to status
+		if m.mode == viewConflict {
+			m.mode = viewStatus
+			return m, tea.Batch(tea.ExitAltScreen, refreshStatus)
+		}
+		return m, refreshStatus
+
 	case tea.KeyMsg:
 		key := msg.String()
 
@@ -92,11 +159,17 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch m.mode {
 		case viewStatus:
 			// Skip navigation when in input modes
-			if m.status.commitMode || m.status.stashMode != stashNone || m.status.confirmMode != confirmNone {
+			if m.status.commitMode || m.status.stashMode != stashNone || m.status.confirmMode != confirmNone || m.status.pickerMode != pickerNone {
 				break
 			}
 			// Handle navigation keys from status
 			if key == Keys.FileDiff || key == Keys.Right || key == "right" || key == "enter" {
+				// Conflicted files open the conflict resolution view instead
+				if m.status.cursor < len(m.status.items) && m.status.items[m.status.cursor].Section == "conflicted" {
+					m.conflict = NewConflictModel(m.status.items[m.status.cursor].File.Path, m.width, m.height)
+					m.mode = viewConflict
+					return m, tea.Batch(tea.EnterAltScreen, m.conflict.Init())
+				}
 				// Enter file diff view for selected file(s)
 				items := m.status.getSelectedItems()
 				if len(items) > 0 {
@@ -137,13 +210,49 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.log = NewLogModelWithOptions(m.width, m.height, m.status.showVerboseHelp)
 				m.mode = viewLog
 				return m, tea.Batch(tea.EnterAltScreen, m.log.Init())
+			} else if key == Keys.Tags {
+				// Enter tags view
+				m.tags = NewTagsModelWithOptions(m.status.showVerboseHelp)
+				m.tags.width = m.width
+				m.tags.height = m.height
+				m.tagsReturn = viewStatus
+				m.mode = viewTags
+				return m, tea.Batch(tea.EnterAltScreen, m.tags.Init())
+			} else if key == Keys.Worktrees {
+				// Enter worktrees view
+				m.worktrees = NewWorktreesModelWithOptions(m.status.showVerboseHelp)
+				m.worktrees.width = m.width
+				m.worktrees.height = m.height
+				m.mode = viewWorktrees
+				return m, tea.Batch(tea.EnterAltScreen, m.worktrees.Init())
+			} else if key == Keys.Submodules {
+				// Enter submodules view
+				m.submodules = NewSubmodulesModelWithOptions(m.status.showVerboseHelp)
+				m.submodules.width = m.width
+				m.submodules.height = m.height
+				m.mode = viewSubmodules
+				return m, tea.Batch(tea.EnterAltScreen, m.submodules.Init())
+			} else if key == Keys.Reflog {
+				// Enter reflog view
+				m.reflog = NewReflogModelWithOptions(m.status.showVerboseHelp)
+				m.reflog.width = m.width
+				m.reflog.height = m.height
+				m.mode = viewReflog
+				return m, tea.Batch(tea.EnterAltScreen, m.reflog.Init())
+			} else if key == Keys.Discarded {
+				// Enter recently discarded view
+				m.discarded = NewDiscardedModelWithOptions(m.status.showVerboseHelp)
+				m.discarded.width = m.width
+				m.discarded.height = m.height
+				m.mode = viewDiscarded
+				return m, tea.Batch(tea.EnterAltScreen, m.discarded.Init())
 			}
 
 		case viewFileDiff:
 			// Handle back navigation from file diff
-			if key == Keys.Left || key == "left" || key == "esc" {
+			if (key == Keys.Left || key == "left" || key == "esc") && !m.diff.inHunkSelection() {
 				inHunkDetail := m.diff.IsViewingHunk()
-				if !inHunkDetail || (len(m.diff.hunks) == 1 && !m.diff.showHelp && !m.diff.confirmMode) {
+				if !inHunkDetail || (len(m.diff.hunks) == 1 && !m.diff.showHelp && !m.diff.confirmMode && !m.diff.visualMode) {
 					m.mode = viewStatus
 					return m, tea.Batch(tea.ExitAltScreen, refreshStatus)
 				}
@@ -151,9 +260,9 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case viewFullDiff:
 			// Handle back navigation from full diff
-			if key == Keys.Left || key == "left" || key == "esc" {
+			if (key == Keys.Left || key == "left" || key == "esc") && !m.diff.inHunkSelection() {
 				inHunkDetail := m.diff.IsViewingHunk()
-				if !inHunkDetail || (len(m.diff.hunks) == 1 && !m.diff.showHelp && !m.diff.confirmMode) {
+				if !inHunkDetail || (len(m.diff.hunks) == 1 && !m.diff.showHelp && !m.diff.confirmMode && !m.diff.visualMode) {
 					m.mode = viewStatus
 					return m, tea.Batch(tea.ExitAltScreen, refreshStatus)
 				}
@@ -162,14 +271,14 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case viewBranches:
 			// Handle back navigation from branches
 			if key == Keys.Left || key == "left" || key == "esc" {
-				if !m.branches.showHelp && !m.branches.deleteConfirmMode && !m.branches.inputMode && !m.branches.forceDeleteMode {
+				if !m.branches.inPrompt() {
 					m.mode = viewStatus
 					return m, tea.Batch(tea.ExitAltScreen, refreshStatus)
 				}
 			}
 			// Override quit to go back instead
 			if key == Keys.Quit {
-				if !m.branches.showHelp && !m.branches.deleteConfirmMode && !m.branches.inputMode && !m.branches.forceDeleteMode {
+				if !m.branches.inPrompt() {
 					m.mode = viewStatus
 					return m, tea.Batch(tea.ExitAltScreen, refreshStatus)
 				}
@@ -179,7 +288,7 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Handle drill-down to stash diff
 			if key == Keys.Right || key == "right" {
 				if len(m.stashes.stashes) > 0 && m.stashes.cursor < len(m.stashes.stashes) {
-					if !m.stashes.showHelp && !m.stashes.confirmMode {
+					if !m.stashes.inPrompt() {
 						stash := m.stashes.stashes[m.stashes.cursor]
 						m.stashes.diffModel = NewStashDiffModel(m.width, m.height)
 						m.mode = viewStashDiff
@@ -194,16 +303,26 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				return m, nil
 			}
+			// Handle comparing the stash with the working tree
+			if key == Keys.StashCompare && !m.stashes.inPrompt() {
+				if len(m.stashes.stashes) > 0 && m.stashes.cursor < len(m.stashes.stashes) {
+					stash := m.stashes.stashes[m.stashes.cursor]
+					m.stashes.diffModel = NewStashCompareModel(stash.Index, m.width, m.height)
+					m.mode = viewStashDiff
+					return m, loadStashCompare(stash.Index)
+				}
+				return m, nil
+			}
 			// Handle back navigation from stashes
 			if key == Keys.Left || key == "left" || key == "esc" {
-				if !m.stashes.showHelp && !m.stashes.confirmMode {
+				if !m.stashes.inPrompt() {
 					m.mode = viewStatus
 					return m, tea.Batch(tea.ExitAltScreen, refreshStatus)
 				}
 			}
 			// Override quit to go back instead
 			if key == Keys.Quit {
-				if !m.stashes.showHelp && !m.stashes.confirmMode {
+				if !m.stashes.inPrompt() {
 					m.mode = viewStatus
 					return m, tea.Batch(tea.ExitAltScreen, refreshStatus)
 				}
@@ -212,7 +331,7 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case viewStashDiff:
 			// Handle back navigation from stash diff
 			if key == Keys.Left || key == "left" || key == "esc" {
-				if !m.stashes.diffModel.showHelp {
+				if !m.stashes.diffModel.inPrompt() {
 					if m.stashes.diffModel.viewingHunk {
 						// Exit hunk detail first
 						m.stashes.diffModel.viewingHunk = false
@@ -225,16 +344,130 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			// Override quit to go back
 			if key == Keys.Quit {
-				if !m.stashes.diffModel.showHelp {
+				if !m.stashes.diffModel.inPrompt() {
 					m.mode = viewStashes
 					return m, nil
 				}
 			}
 
 		case viewLog:
+			// Handle drill-down to commit
+			if (key == Keys.Right || key == "right" || key == "enter") && !m.log.inPrompt() {
+				if commit, ok := m.log.SelectedCommit(); ok && !m.log.showHelp {
+					m.commit = NewCommitDiffModel(commit.Hash, m.width, m.height)
+					m.mode = viewCommit
+					return m, m.commit.Init()
+				}
+				return m, nil
+			}
+			// Plan an interactive rebase onto the selected commit
+			if key == Keys.Rebase && !m.log.inPrompt() && !m.log.showHelp {
+				if commit, ok := m.log.SelectedCommit(); ok {
+					m.rebase = NewRebaseModel(commit, m.width, m.height, m.log.showVerboseHelp)
+					m.mode = viewRebase
+					return m, m.rebase.Init()
+				}
+				return m, nil
+			}
+			// Tag the selected commit in the tags view
+			if key == Keys.Tags && !m.log.inPrompt() && !m.log.showHelp {
+				if commit, ok := m.log.SelectedCommit(); ok {
+					m.tags = NewTagsModelWithOptions(m.log.showVerboseHelp)
+					m.tags.width = m.width
+					m.tags.height = m.height
+					m.tagsReturn = viewLog
+					m.mode = viewTags
+					create := m.tags.startCreate(commit)
+					return m, tea.Batch(m.tags.Init(), create)
+				}
+				return m, nil
+			}
 			// Handle back navigation from log
 			if key == Keys.Left || key == "left" || key == "esc" || key == Keys.Quit || key == Keys.Log {
-				if !m.log.showHelp {
+				if !m.log.showHelp && !m.log.inPrompt() {
+					m.mode = viewStatus
+					return m, tea.Batch(tea.ExitAltScreen, refreshStatus)
+				}
+			}
+
+		case viewCommit:
+			// Handle back navigation from commit to log
+			if key == Keys.Left || key == "left" || key == "esc" {
+				if !m.commit.showHelp && !m.commit.viewingHunk && !m.commit.viewingFullDiff {
+					m.mode = viewLog
+					return m, nil
+				}
+			}
+			// Override quit to go back to the log
+			if key == Keys.Quit {
+				if !m.commit.showHelp {
+					m.mode = viewLog
+					return m, nil
+				}
+			}
+
+		case viewRebase:
+			// Handle back navigation from the rebase planner to the log
+			if key == Keys.Left || key == "left" || key == "esc" || key == Keys.Quit {
+				if !m.rebase.inPrompt() {
+					m.mode = viewLog
+					return m, refreshLog
+				}
+			}
+
+		case viewTags:
+			// Handle back navigation from tags to where it was opened from
+			if key == Keys.Left || key == "left" || key == "esc" || key == Keys.Quit {
+				if !m.tags.inPrompt() {
+					m.mode = m.tagsReturn
+					if m.tagsReturn == viewLog {
+						// Reload the log to show the tag decorations
+						return m, refreshLog
+					}
+					return m, tea.Batch(tea.ExitAltScreen, refreshStatus)
+				}
+			}
+
+		case viewWorktrees:
+			// Handle back navigation from worktrees
+			if key == Keys.Left || key == "left" || key == "esc" || key == Keys.Quit {
+				if !m.worktrees.inPrompt() {
+					m.mode = viewStatus
+					return m, tea.Batch(tea.ExitAltScreen, refreshStatus)
+				}
+			}
+
+		case viewSubmodules:
+			// Handle back navigation from submodules
+			if key == Keys.Left || key == "left" || key == "esc" || key == Keys.Quit {
+				if !m.submodules.inPrompt() {
+					m.mode = viewStatus
+					return m, tea.Batch(tea.ExitAltScreen, refreshStatus)
+				}
+			}
+
+		case viewReflog:
+			// Handle back navigation from reflog
+			if key == Keys.Left || key == "left" || key == "esc" || key == Keys.Quit {
+				if !m.reflog.inPrompt() {
+					m.mode = viewStatus
+					return m, tea.Batch(tea.ExitAltScreen, refreshStatus)
+				}
+			}
+
+		case viewDiscarded:
+			// Handle back navigation from recently discarded
+			if key == Keys.Left || key == "left" || key == "esc" || key == Keys.Quit {
+				if !m.discarded.inPrompt() {
+					m.mode = viewStatus
+					return m, tea.Batch(tea.ExitAltScreen, refreshStatus)
+				}
+			}
+
+		case viewConflict:
+			// Handle back navigation from conflict view
+			if key == Keys.Left || key == "left" || key == "esc" || key == Keys.Quit {
+				if !m.conflict.showHelp {
 					m.mode = viewStatus
 					return m, tea.Batch(tea.ExitAltScreen, refreshStatus)
 				}
@@ -268,6 +501,38 @@ func (m AppModel) updateCurrentView(msg tea.Msg) (tea.Model, tea.Cmd) {
 		newLog, cmd := m.log.Update(msg)
 		m.log = newLog.(LogModel)
 		return m, cmd
+	case viewConflict:
+		newConflict, cmd := m.conflict.Update(msg)
+		m.conflict = newConflict.(ConflictModel)
+		return m, cmd
+	case viewCommit:
+		newCommit, cmd := m.commit.Update(msg)
+		m.commit = newCommit.(DiffModel)
+		return m, cmd
+	case viewRebase:
+		newRebase, cmd := m.rebase.Update(msg)
+		m.rebase = newRebase.(RebaseModel)
+		return m, cmd
+	case viewTags:
+		newTags, cmd := m.tags.Update(msg)
+		m.tags = newTags.(TagsModel)
+		return m, cmd
+	case viewWorktrees:
+		newWorktrees, cmd := m.worktrees.Update(msg)
+		m.worktrees = newWorktrees.(WorktreesModel)
+		return m, cmd
+	case viewSubmodules:
+		newSubmodules, cmd := m.submodules.Update(msg)
+		m.submodules = newSubmodules.(SubmodulesModel)
+		return m, cmd
+	case viewReflog:
+		newReflog, cmd := m.reflog.Update(msg)
+		m.reflog = newReflog.(ReflogModel)
+		return m, cmd
+	case viewDiscarded:
+		newDiscarded, cmd := m.discarded.Update(msg)
+		m.discarded = newDiscarded.(DiscardedModel)
+		return m, cmd
 	default:
 		newStatus, cmd := m.status.Update(msg)
 		m.status = newStatus.(StatusModel)
@@ -287,6 +552,22 @@ func (m AppModel) View() string {
 		return m.stashes.diffModel.View()
 	case viewLog:
 		return m.log.View()
+	case viewConflict:
+		return m.conflict.View()
+	case viewCommit:
+		return m.commit.View()
+	case viewRebase:
+		return m.rebase.View()
+	case viewTags:
+		return m.tags.View()
+	case viewWorktrees:
+		return m.worktrees.View()
+	case viewSubmodules:
+		return m.submodules.View()
+	case viewReflog:
+		return m.reflog.View()
+	case viewDiscarded:
+		return m.discarded.View()
 	default:
 		return m.status.View()
 	}
@@ -296,6 +577,7 @@ func (m AppModel) View() string {
 type statusMsg struct {
 	status       *git.StatusResult
 	branchStatus git.BranchStatus
+	operation    git.Operation
 }
 
 type errMsg struct {
@@ -314,6 +596,23 @@ type branchesMsg struct {
 	branches []git.Branch
 }
 
+// branchRenamedMsg is sent after renaming the current branch when it has an
+// upstream that could be renamed too
+type branchRenamedMsg struct {
+	oldName      string
+	newName      string
+	upstream     string
+	remote       string // remote of upstream
+	remoteBranch string // name of upstream on its remote
+}
+
+// operationStoppedMsg is sent when a merge, rebase, cherry-pick or revert
+// started from another view stops with conflicts
+type operationStoppedMsg struct {
+	target string // branch being merged or rebased onto, if any
+	err    error
+}
+
 type branchDeleteFailedMsg struct {
 	branchName string
 	err        error
@@ -321,10 +620,11 @@ type branchDeleteFailedMsg struct {
 
 type stashesMsg struct {
 	stashes []git.Stash
+	notice  string
 }
 
 type stashDiffMsg struct {
-	diff *git.CombinedDiffResult
+	diff *git.StashDiff
 }
 
 func refreshStatus() tea.Msg {
@@ -333,5 +633,5 @@ func refreshStatus() tea.Msg {
 		return errMsg{err}
 	}
 	branchStatus := git.GetBranchStatus()
-	return statusMsg{status, branchStatus}
+	return statusMsg{status, branchStatus, git.GetOperation()}
 }
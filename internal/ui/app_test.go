@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
@@ -186,6 +187,24 @@ func TestAppModelBackFromFileDiff(t *testing.T) {
 	}
 }
 
+func TestAppModelEscFromSingleHunkVisualMode(t *testing.T) {
+	m := NewAppModel()
+	m.mode = viewFileDiff
+	m.diff.hunks = []git.Hunk{{FilePath: "file1.txt"}}
+	m.diff.viewingHunk = true
+	m.diff.visualMode = true
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(AppModel)
+
+	if m.mode != viewFileDiff {
+		t.Errorf("mode = %v, want viewFileDiff (ESC should only leave visual mode)", m.mode)
+	}
+	if m.diff.visualMode {
+		t.Error("ESC should exit visual mode")
+	}
+}
+
 func TestAppModelBackFromFullDiff(t *testing.T) {
 	m := NewAppModel()
 	m.mode = viewFullDiff
@@ -292,6 +311,249 @@ func TestAppModelQuitFromLogGoesBack(t *testing.T) {
 	}
 }
 
+func TestAppModelLogBackBlockedInPrompts(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(*LogModel)
+	}{
+		{name: "visualMode", setup: func(m *LogModel) { m.visualMode = true }},
+		{name: "confirmMode", setup: func(m *LogModel) { m.confirmMode = logConfirmRevert }},
+		{name: "resetMode", setup: func(m *LogModel) { m.resetMode = true }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewAppModel()
+			m.mode = viewLog
+			m.log.commits = makeCommits(2)
+			tt.setup(&m.log)
+
+			for _, key := range []tea.KeyMsg{{Type: tea.KeyRunes, Runes: []rune{'q'}}, {Type: tea.KeyEnter}} {
+				newModel, _ := m.Update(key)
+				if newModel.(AppModel).mode != viewLog {
+					t.Errorf("%s should stay in the log in %s", key, tt.name)
+				}
+			}
+		})
+	}
+}
+
+func TestAppModelOpenRebasePlanner(t *testing.T) {
+	m := NewAppModel()
+	m.mode = viewLog
+	m.log.commits = makeCommits(3)
+	m.log.cursor = 2
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	m = newModel.(AppModel)
+	if m.mode != viewRebase {
+		t.Fatalf("mode = %v, want viewRebase", m.mode)
+	}
+	if m.rebase.base.Subject != "Commit 2" || cmd == nil {
+		t.Error("the planner should load the commits after the selected one")
+	}
+
+	// Esc goes back to the log unless the planner is prompting
+	m.rebase.confirmMode = true
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(AppModel)
+	if m.mode != viewRebase || m.rebase.confirmMode {
+		t.Error("esc should cancel the prompt first")
+	}
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(AppModel)
+	if m.mode != viewLog || cmd == nil {
+		t.Error("esc should go back to the log and reload it")
+	}
+}
+
+func TestAppModelRebaseFinishedReturnsToLog(t *testing.T) {
+	m := NewAppModel()
+	m.mode = viewRebase
+
+	newModel, _ := m.Update(rebaseResultMsg{operation: git.Operation{Kind: git.OperationRebase}})
+	m = newModel.(AppModel)
+	if m.mode != viewRebase || !m.rebase.stopped() {
+		t.Fatal("a stopped rebase should stay in the planner")
+	}
+
+	newModel, cmd := m.Update(rebaseResultMsg{})
+	m = newModel.(AppModel)
+	if m.mode != viewLog || cmd == nil {
+		t.Error("a finished rebase should go back to the log")
+	}
+}
+
+func TestAppModelTagsFromStatus(t *testing.T) {
+	m := NewAppModel()
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'T'}})
+	m = newModel.(AppModel)
+	if m.mode != viewTags {
+		t.Fatalf("mode = %v, want viewTags", m.mode)
+	}
+	if cmd == nil {
+		t.Error("opening tags should load them")
+	}
+
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(AppModel)
+	if m.mode != viewStatus || cmd == nil {
+		t.Error("esc should go back to status and refresh it")
+	}
+}
+
+func TestAppModelTagsFromLog(t *testing.T) {
+	m := NewAppModel()
+	m.mode = viewLog
+	m.log.commits = makeCommits(3)
+	m.log.cursor = 1
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'T'}})
+	m = newModel.(AppModel)
+	if m.mode != viewTags {
+		t.Fatalf("mode = %v, want viewTags", m.mode)
+	}
+	if !m.tags.nameMode || m.tags.target.Subject != "Commit 1" {
+		t.Error("the name prompt should be open for the selected commit")
+	}
+
+	// Esc cancels the prompt first, then goes back to the log
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(AppModel)
+	if m.mode != viewTags || m.tags.nameMode {
+		t.Error("esc should cancel the name prompt first")
+	}
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(AppModel)
+	if m.mode != viewLog || cmd == nil {
+		t.Error("esc should go back to the log and reload it")
+	}
+}
+
+func TestAppModelWorktreesNavigation(t *testing.T) {
+	m := NewAppModel()
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'W'}})
+	m = newModel.(AppModel)
+	if m.mode != viewWorktrees {
+		t.Fatalf("mode = %v, want viewWorktrees", m.mode)
+	}
+	if cmd == nil {
+		t.Error("opening worktrees should load them")
+	}
+
+	// Esc cancels a prompt before going back
+	m.worktrees.removeMode = true
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(AppModel)
+	if m.mode != viewWorktrees || m.worktrees.removeMode {
+		t.Error("esc should cancel the prompt first")
+	}
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(AppModel)
+	if m.mode != viewStatus || cmd == nil {
+		t.Error("esc should go back to status and refresh it")
+	}
+}
+
+func TestAppModelWorktreeSwitchedReturnsToStatus(t *testing.T) {
+	m := NewAppModel()
+	m.mode = viewWorktrees
+	m.status.operationTarget = "feature"
+
+	newModel, cmd := m.Update(worktreeSwitchedMsg{path: "/repo-feature"})
+	m = newModel.(AppModel)
+	if m.mode != viewStatus || cmd == nil {
+		t.Error("switching worktrees should go back to status and refresh it")
+	}
+	if m.status.operationTarget != "" {
+		t.Error("state from the previous worktree should be cleared")
+	}
+}
+
+func TestAppModelSubmodulesNavigation(t *testing.T) {
+	m := NewAppModel()
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'O'}})
+	m = newModel.(AppModel)
+	if m.mode != viewSubmodules {
+		t.Fatalf("mode = %v, want viewSubmodules", m.mode)
+	}
+	if cmd == nil {
+		t.Error("opening submodules should load them")
+	}
+
+	// Back is ignored while an update runs
+	m.submodules.running = "Updating 'lib'..."
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(AppModel)
+	if m.mode != viewSubmodules {
+		t.Error("esc should not leave while an action runs")
+	}
+
+	m.submodules.running = ""
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(AppModel)
+	if m.mode != viewStatus || cmd == nil {
+		t.Error("esc should go back to status and refresh it")
+	}
+}
+
+func TestAppModelReflogNavigation(t *testing.T) {
+	m := NewAppModel()
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'H'}})
+	m = newModel.(AppModel)
+	if m.mode != viewReflog {
+		t.Fatalf("mode = %v, want viewReflog", m.mode)
+	}
+	if cmd == nil {
+		t.Error("opening the reflog should load it")
+	}
+
+	// Back is ignored while a prompt is open
+	m.reflog.confirmMode = reflogConfirmCheckout
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(AppModel)
+	if m.mode != viewReflog || m.reflog.confirmMode != reflogConfirmNone {
+		t.Error("esc should only cancel the prompt")
+	}
+
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(AppModel)
+	if m.mode != viewStatus || cmd == nil {
+		t.Error("esc should go back to status and refresh it")
+	}
+}
+
+func TestAppModelDiscardedNavigation(t *testing.T) {
+	m := NewAppModel()
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'D'}})
+	m = newModel.(AppModel)
+	if m.mode != viewDiscarded {
+		t.Fatalf("mode = %v, want viewDiscarded", m.mode)
+	}
+	if cmd == nil {
+		t.Error("opening the view should load the discards")
+	}
+
+	// Back only cancels an open restore prompt
+	m.discarded.restoreMode = true
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(AppModel)
+	if m.mode != viewDiscarded || m.discarded.restoreMode {
+		t.Error("esc should only cancel the prompt")
+	}
+
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(AppModel)
+	if m.mode != viewStatus || cmd == nil {
+		t.Error("esc should go back to status and refresh it")
+	}
+}
+
 func TestAppModelLogKeyToggle(t *testing.T) {
 	m := NewAppModel()
 	m.mode = viewLog
@@ -395,6 +657,44 @@ func TestAppModelBackFromStashDiffHunkDetail(t *testing.T) {
 	}
 }
 
+func TestAppModelCompareStash(t *testing.T) {
+	m := NewAppModel()
+	m.mode = viewStashes
+	m.stashes.stashes = []git.Stash{{Index: 0}, {Index: 1}}
+	m.stashes.cursor = 1
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	m = newModel.(AppModel)
+
+	if m.mode != viewStashDiff {
+		t.Errorf("mode = %v, want viewStashDiff", m.mode)
+	}
+	if !m.stashes.diffModel.compare || m.stashes.diffModel.stashIndex != 1 {
+		t.Error("should compare the selected stash with the working tree")
+	}
+	if cmd == nil {
+		t.Error("should load the comparison")
+	}
+}
+
+func TestAppModelStashDiffVisualBlocksBack(t *testing.T) {
+	m := NewAppModel()
+	m.mode = viewStashDiff
+	m.stashes.diffModel = NewStashDiffModel(100, 50)
+	m.stashes.diffModel.hunks = []git.Hunk{{FilePath: "file1.txt"}, {FilePath: "file2.txt"}}
+	m.stashes.diffModel.visualMode = true
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(AppModel)
+
+	if m.mode != viewStashDiff {
+		t.Errorf("mode = %v, esc should end the selection first", m.mode)
+	}
+	if m.stashes.diffModel.visualMode {
+		t.Error("esc should end the selection")
+	}
+}
+
 func TestAppModelQuitFromStashDiff(t *testing.T) {
 	m := NewAppModel()
 	m.mode = viewStashDiff
@@ -427,6 +727,26 @@ func TestAppModelDiffViewHunkDetailBack(t *testing.T) {
 	}
 }
 
+func TestAppModelDiffViewHunkSelectionBack(t *testing.T) {
+	m := NewAppModel()
+	m.mode = viewFileDiff
+	m.diff.hunks = []git.Hunk{
+		{FilePath: "file1.txt"},
+		{FilePath: "file2.txt"},
+	}
+	m.diff.hunkVisualMode = true
+
+	// esc ends the hunk selection instead of going back
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(AppModel)
+	if m.mode != viewFileDiff {
+		t.Errorf("mode = %v, should still be viewFileDiff", m.mode)
+	}
+	if m.diff.hunkVisualMode {
+		t.Error("esc should end the hunk selection")
+	}
+}
+
 func TestAppModelDiffViewSingleHunkBack(t *testing.T) {
 	m := NewAppModel()
 	m.mode = viewFileDiff
@@ -470,6 +790,26 @@ func TestAppModelBranchesBackBlockedInModes(t *testing.T) {
 			name: "forceDeleteMode",
 			setup: func(m *BranchesModel) { m.forceDeleteMode = true },
 		},
+		{
+			name: "renameMode",
+			setup: func(m *BranchesModel) { m.renameMode = true },
+		},
+		{
+			name: "renameRemoteMode",
+			setup: func(m *BranchesModel) { m.renameRemoteMode = true },
+		},
+		{
+			name: "upstreamMode",
+			setup: func(m *BranchesModel) { m.upstreamMode = true },
+		},
+		{
+			name: "mergeMode",
+			setup: func(m *BranchesModel) { m.mergeMode = true },
+		},
+		{
+			name: "rebaseMode",
+			setup: func(m *BranchesModel) { m.rebaseMode = true },
+		},
 	}
 
 	for _, tt := range tests {
@@ -501,6 +841,14 @@ func TestAppModelStashesBackBlockedInModes(t *testing.T) {
 			name: "confirmMode",
 			setup: func(m *StashesModel) { m.confirmMode = true },
 		},
+		{
+			name: "branchMode",
+			setup: func(m *StashesModel) { m.branchMode = true },
+		},
+		{
+			name: "renameMode",
+			setup: func(m *StashesModel) { m.renameMode = true },
+		},
 	}
 
 	for _, tt := range tests {
@@ -573,11 +921,12 @@ func TestAppModelViewLog(t *testing.T) {
 	m := NewAppModel()
 	m.mode = viewLog
 	m.log = NewLogModelWithSize(100, 50)
-	m.log.lines = []string{"commit abc123"}
+	m.log.commits = []git.CommitInfo{{Hash: "abc1234def", ShortHash: "abc1234", Subject: "Initial commit"}}
+	m.log.loaded = true
 
 	view := m.View()
 
-	if !strings.Contains(view, "commit") {
+	if !strings.Contains(view, "Initial commit") {
 		t.Error("view should show log content")
 	}
 }
@@ -698,3 +1047,130 @@ func TestFileFilter(t *testing.T) {
 		t.Error("Untracked should be false")
 	}
 }
+
+func TestAppModelNavigateToConflict(t *testing.T) {
+	m := NewAppModel()
+	m.mode = viewStatus
+	m.status.items = []StatusItem{
+		{File: git.FileStatus{Path: "conflict.txt", IndexStatus: 'U', WorkStatus: 'U'}, Section: "conflicted"},
+	}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	m = newModel.(AppModel)
+
+	if m.mode != viewConflict {
+		t.Errorf("mode = %v, want viewConflict", m.mode)
+	}
+	if m.conflict.path != "conflict.txt" {
+		t.Errorf("conflict.path = %q, want conflict.txt", m.conflict.path)
+	}
+	if cmd == nil {
+		t.Error("should return commands for entering conflict view")
+	}
+}
+
+func TestAppModelConflictResolvedReturnsToStatus(t *testing.T) {
+	m := NewAppModel()
+	m.mode = viewConflict
+
+	newModel, cmd := m.Update(conflictResolvedMsg{path: "conflict.txt"})
+	m = newModel.(AppModel)
+
+	if m.mode != viewStatus {
+		t.Errorf("mode = %v, want viewStatus", m.mode)
+	}
+	if cmd == nil {
+		t.Error("should refresh status after resolving")
+	}
+}
+
+func TestAppModelOperationStoppedReturnsToStatus(t *testing.T) {
+	m := NewAppModel()
+	m.mode = viewBranches
+
+	newModel, cmd := m.Update(operationStoppedMsg{target: "feature", err: errors.New("conflict")})
+	m = newModel.(AppModel)
+
+	if m.mode != viewStatus {
+		t.Errorf("mode = %v, want viewStatus", m.mode)
+	}
+	if m.status.operationTarget != "feature" {
+		t.Errorf("status should know the merged branch, got %q", m.status.operationTarget)
+	}
+	if cmd == nil {
+		t.Error("should refresh status to show the conflicted files")
+	}
+}
+
+func TestAppModelNavigateToCommit(t *testing.T) {
+	m := NewAppModel()
+	m.mode = viewLog
+	m.log.commits = []git.CommitInfo{
+		{Hash: "aaaaaaa111", ShortHash: "aaaaaaa", Subject: "Second"},
+		{Hash: "bbbbbbb222", ShortHash: "bbbbbbb", Subject: "First"},
+	}
+	m.log.cursor = 1
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(AppModel)
+
+	if m.mode != viewCommit {
+		t.Errorf("mode = %v, want viewCommit", m.mode)
+	}
+	if m.commit.commitHash != "bbbbbbb222" {
+		t.Errorf("commit.commitHash = %q, want bbbbbbb222", m.commit.commitHash)
+	}
+	if cmd == nil {
+		t.Error("should return command to load the commit")
+	}
+}
+
+func TestAppModelNavigateToCommitEmptyLog(t *testing.T) {
+	m := NewAppModel()
+	m.mode = viewLog
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	m = newModel.(AppModel)
+
+	if m.mode != viewLog {
+		t.Errorf("mode = %v, want viewLog when there are no commits", m.mode)
+	}
+}
+
+func TestAppModelBackFromCommit(t *testing.T) {
+	m := NewAppModel()
+	m.mode = viewCommit
+	m.commit = NewCommitDiffModel("abc", 100, 50)
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'h'}})
+	m = newModel.(AppModel)
+
+	if m.mode != viewLog {
+		t.Errorf("mode = %v, want viewLog", m.mode)
+	}
+}
+
+func TestAppModelBackFromCommitHunkDetail(t *testing.T) {
+	m := NewAppModel()
+	m.mode = viewCommit
+	m.commit = NewCommitDiffModel("abc", 100, 50)
+	m.commit.hunks = []git.Hunk{{FilePath: "a.txt"}, {FilePath: "b.txt"}}
+	m.commit.viewingHunk = true
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'h'}})
+	m = newModel.(AppModel)
+
+	if m.mode != viewCommit {
+		t.Errorf("mode = %v, want viewCommit (left leaves hunk detail first)", m.mode)
+	}
+	if m.commit.viewingHunk {
+		t.Error("should have left hunk detail")
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+	m = newModel.(AppModel)
+
+	if m.mode != viewLog {
+		t.Errorf("mode = %v, want viewLog (q goes back from commit)", m.mode)
+	}
+}
@@ -10,6 +10,9 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// mergeModes are the options of the merge picker
+var mergeModes = []git.MergeMode{git.MergeNoFF, git.MergeFFOnly, git.MergeSquash}
+
 // BranchesModel is the bubbletea model for the branches tab
 type BranchesModel struct {
 	branches            []git.Branch
@@ -21,8 +24,28 @@ type BranchesModel struct {
 	deleteConfirmMode   bool
 	forceDeleteMode     bool
 	pendingDeleteBranch string
+	deleteRemote        string // remote of the remote branch being deleted
+	deleteRemoteName    string // name of that branch on the remote
+	showRemotes         bool   // also list remote-tracking branches
+	renameMode          bool
+	renameRemoteMode    bool   // confirming the rename of the remote branch
+	pendingRename       string // branch being renamed
+	renamedTo           string // new name, once renamed locally
+	renamedUpstream     string // upstream of the renamed branch, e.g. "origin/old"
+	renamedRemote       string // remote of renamedUpstream
+	renamedRemoteBranch string // name of renamedUpstream on its remote
+	upstreamMode        bool
+	upstreamPicker      picker
+	upstreamChoices     []upstreamChoice // options of upstreamPicker
+	mergeMode           bool             // previewing a merge of pendingTarget into HEAD
+	rebaseMode          bool             // previewing a rebase of HEAD onto pendingTarget
+	pendingTarget       string           // branch to merge or rebase onto
+	preview             []git.CommitInfo // commits brought in (HEAD..pendingTarget)
+	replayed            int              // commits of HEAD replayed by the rebase
+	mergePicker         picker
 	branchInput         textinput.Model
 	deleteInput         textinput.Model
+	renameInput         textinput.Model
 	lastKey             string
 	err                 error
 	width               int
@@ -46,9 +69,15 @@ func NewBranchesModelWithOptions(showVerboseHelp bool) BranchesModel {
 	di.CharLimit = 100
 	di.Width = 40
 
+	ri := textinput.New()
+	ri.Placeholder = "New branch name"
+	ri.CharLimit = 100
+	ri.Width = 40
+
 	return BranchesModel{
 		branchInput:     ti,
 		deleteInput:     di,
+		renameInput:     ri,
 		showVerboseHelp: showVerboseHelp,
 	}
 }
@@ -59,13 +88,32 @@ func (m BranchesModel) Init() tea.Cmd {
 }
 
 func refreshBranches() tea.Msg {
+	return loadBranches(false)
+}
+
+// loadBranches lists the local branches, followed by the remote-tracking
+// branches when withRemotes is set
+func loadBranches(withRemotes bool) tea.Msg {
 	branches, err := git.GetBranches()
 	if err != nil {
 		return errMsg{err}
 	}
+	if withRemotes {
+		remotes, err := git.GetRemoteBranches()
+		if err != nil {
+			return errMsg{err}
+		}
+		branches = append(branches, remotes...)
+	}
 	return branchesMsg{branches}
 }
 
+// inPrompt returns true while an input or confirmation prompt is open
+func (m BranchesModel) inPrompt() bool {
+	return m.showHelp || m.deleteConfirmMode || m.inputMode || m.forceDeleteMode ||
+		m.renameMode || m.renameRemoteMode || m.upstreamMode || m.mergeMode || m.rebaseMode
+}
+
 // Update handles messages
 func (m BranchesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -89,6 +137,9 @@ func (m BranchesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.deleteInput.Reset()
 				m.deleteInput.Blur()
 				if m.cursor < len(m.branches) && typedName == m.branches[m.cursor].Name {
+					if m.branches[m.cursor].IsRemote {
+						return m, m.doDeleteRemoteBranch()
+					}
 					return m, m.doDeleteBranch()
 				}
 				return m, nil
@@ -120,6 +171,101 @@ func (m BranchesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		// Handle rename input mode
+		if m.renameMode {
+			switch key {
+			case "enter":
+				oldName := m.pendingRename
+				newName := strings.TrimSpace(m.renameInput.Value())
+				m.renameMode = false
+				m.renameInput.Reset()
+				m.renameInput.Blur()
+				if newName == "" || newName == oldName {
+					m.pendingRename = ""
+					return m, nil
+				}
+				return m, m.doRenameBranch(oldName, newName)
+			case "esc":
+				m.renameMode = false
+				m.pendingRename = ""
+				m.renameInput.Reset()
+				m.renameInput.Blur()
+				return m, nil
+			default:
+				var cmd tea.Cmd
+				m.renameInput, cmd = m.renameInput.Update(msg)
+				return m, cmd
+			}
+		}
+
+		// Handle remote branch rename confirmation
+		if m.renameRemoteMode {
+			switch key {
+			case "y", "Y":
+				m.renameRemoteMode = false
+				return m, m.doRenameRemoteBranch()
+			case "n", "N", "esc":
+				m.renameRemoteMode = false
+				m.pendingRename = ""
+				m.renamedTo = ""
+				m.renamedUpstream = ""
+				m.renamedRemote = ""
+				m.renamedRemoteBranch = ""
+				return m, nil
+			}
+			return m, nil
+		}
+
+		// Handle upstream picker
+		if m.upstreamMode {
+			if m.upstreamPicker.move(key) {
+				return m, nil
+			}
+			switch key {
+			case "enter":
+				m.upstreamMode = false
+				if m.cursor < len(m.branches) && m.upstreamPicker.cursor < len(m.upstreamChoices) {
+					return m, m.doSetUpstream(m.branches[m.cursor].Name, m.upstreamChoices[m.upstreamPicker.cursor])
+				}
+				return m, nil
+			case "esc":
+				m.upstreamMode = false
+				return m, nil
+			}
+			return m, nil
+		}
+
+		// Handle merge preview (pick the merge mode)
+		if m.mergeMode {
+			if m.mergePicker.move(key) {
+				return m, nil
+			}
+			switch key {
+			case "enter":
+				m.mergeMode = false
+				return m, m.doMerge(m.pendingTarget, mergeModes[m.mergePicker.cursor])
+			case "esc":
+				m.mergeMode = false
+				m.pendingTarget = ""
+				return m, nil
+			}
+			return m, nil
+		}
+
+		// Handle rebase preview
+		if m.rebaseMode {
+			switch key {
+			case "y", "Y", "enter":
+				m.rebaseMode = false
+				return m, m.doRebase(m.pendingTarget)
+			case "n", "N", "esc":
+				m.rebaseMode = false
+				m.pendingTarget = ""
+				return m, nil
+			}
+			return m, nil
+		}
+
 		// Handle input mode (new branch)
 		if m.inputMode {
 			switch key {
@@ -187,11 +333,70 @@ func (m BranchesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Checkout selected branch
 			if len(m.branches) > 0 && m.cursor < len(m.branches) {
 				branch := m.branches[m.cursor]
+				if branch.IsRemote {
+					return m, m.doCheckoutRemoteBranch(branch.Name)
+				}
 				if !branch.IsCurrent {
 					return m, m.doCheckoutBranch(branch.Name)
 				}
 			}
 			return m, nil
+		case Keys.RemoteBranches:
+			m.showRemotes = !m.showRemotes
+			m.err = nil
+			return m, m.refresh()
+		case Keys.RenameBranch:
+			if m.cursor < len(m.branches) && !m.branches[m.cursor].IsRemote {
+				m.pendingRename = m.branches[m.cursor].Name
+				m.renameMode = true
+				m.renameInput.SetValue(m.pendingRename)
+				m.renameInput.CursorEnd()
+				m.renameInput.Focus()
+				return m, textinput.Blink
+			}
+			return m, nil
+		case Keys.SetUpstream:
+			if m.cursor < len(m.branches) && !m.branches[m.cursor].IsRemote {
+				m.err = nil
+				return m, loadUpstreamChoices(m.branches[m.cursor])
+			}
+			return m, nil
+		case Keys.Merge, Keys.Rebase:
+			if m.cursor >= len(m.branches) || m.branches[m.cursor].IsCurrent {
+				return m, nil
+			}
+			target := m.branches[m.cursor].Name
+			preview, err := git.GetCommits(git.LogOptions{Revision: "HEAD.." + target})
+			if err != nil {
+				m.err = err
+				return m, nil
+			}
+			m.err = nil
+			m.pendingTarget = target
+			m.preview = preview
+			if key == Keys.Merge {
+				options := make([]string, len(mergeModes))
+				for i, mode := range mergeModes {
+					options[i] = mode.String()
+				}
+				m.mergePicker = newPicker(fmt.Sprintf("Merge '%s' into HEAD with:", target), options, 0)
+				m.mergeMode = true
+				return m, nil
+			}
+			replayed, err := git.GetCommits(git.LogOptions{Revision: target + "..HEAD"})
+			if err != nil {
+				m.err = err
+				return m, nil
+			}
+			m.replayed = len(replayed)
+			m.rebaseMode = true
+			return m, nil
+		case Keys.UnsetUpstream:
+			if m.cursor < len(m.branches) && m.branches[m.cursor].Upstream != "" {
+				m.err = nil
+				return m, m.doUnsetUpstream(m.branches[m.cursor].Name)
+			}
+			return m, nil
 		case Keys.NewBranch:
 			// Create new branch
 			m.inputMode = true
@@ -202,6 +407,9 @@ func (m BranchesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if len(m.branches) > 0 && m.cursor < len(m.branches) {
 				branch := m.branches[m.cursor]
 				if !branch.IsCurrent {
+					if branch.IsRemote {
+						m.deleteRemote, m.deleteRemoteName = git.SplitRemoteBranch(branch.Name)
+					}
 					m.deleteConfirmMode = true
 					m.deleteInput.Focus()
 					return m, textinput.Blink
@@ -230,6 +438,30 @@ func (m BranchesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.ensureCursorVisible()
 		return m, nil
 
+	case branchRenamedMsg:
+		// Offer to rename the remote branch as well
+		m.pendingRename = msg.oldName
+		m.renamedTo = msg.newName
+		m.renamedUpstream = msg.upstream
+		m.renamedRemote = msg.remote
+		m.renamedRemoteBranch = msg.remoteBranch
+		m.renameRemoteMode = true
+		return m, m.refresh()
+
+	case upstreamChoicesMsg:
+		options := make([]string, len(msg.choices))
+		cursor := 0
+		for i, c := range msg.choices {
+			options[i] = c.String()
+			if c.ref == msg.current {
+				cursor = i
+			}
+		}
+		m.upstreamChoices = msg.choices
+		m.upstreamPicker = newPicker(fmt.Sprintf("Set upstream of '%s' to:", msg.branch), options, cursor)
+		m.upstreamMode = true
+		return m, nil
+
 	case branchDeleteFailedMsg:
 		m.err = msg.err
 		m.pendingDeleteBranch = msg.branchName
@@ -244,23 +476,44 @@ func (m BranchesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// refresh reloads the branch list, including remote branches if they are shown
+func (m BranchesModel) refresh() tea.Cmd {
+	showRemotes := m.showRemotes
+	return func() tea.Msg {
+		return loadBranches(showRemotes)
+	}
+}
+
 func (m BranchesModel) doCheckoutBranch(name string) tea.Cmd {
+	showRemotes := m.showRemotes
 	return func() tea.Msg {
 		err := git.CheckoutBranch(name)
 		if err != nil {
 			return errMsg{err}
 		}
-		return refreshBranches()
+		return loadBranches(showRemotes)
+	}
+}
+
+// doCheckoutRemoteBranch checks out a local branch tracking the remote branch
+func (m BranchesModel) doCheckoutRemoteBranch(name string) tea.Cmd {
+	showRemotes := m.showRemotes
+	return func() tea.Msg {
+		if err := git.CheckoutRemoteBranch(name); err != nil {
+			return errMsg{err}
+		}
+		return loadBranches(showRemotes)
 	}
 }
 
 func (m BranchesModel) doCreateBranch(name string) tea.Cmd {
+	showRemotes := m.showRemotes
 	return func() tea.Msg {
 		err := git.CreateBranch(name)
 		if err != nil {
 			return errMsg{err}
 		}
-		return refreshBranches()
+		return loadBranches(showRemotes)
 	}
 }
 
@@ -269,6 +522,7 @@ func (m BranchesModel) doDeleteBranch() tea.Cmd {
 		return nil
 	}
 	branch := m.branches[m.cursor]
+	showRemotes := m.showRemotes
 	return func() tea.Msg {
 		err := git.DeleteBranch(branch.Name)
 		if err != nil {
@@ -278,7 +532,228 @@ func (m BranchesModel) doDeleteBranch() tea.Cmd {
 			}
 			return errMsg{err}
 		}
-		return refreshBranches()
+		return loadBranches(showRemotes)
+	}
+}
+
+// doMerge merges the branch into HEAD. Stopping on conflicts sends the user
+// to the status view to resolve them.
+func (m BranchesModel) doMerge(branch string, mode git.MergeMode) tea.Cmd {
+	showRemotes := m.showRemotes
+	return func() tea.Msg {
+		if err := git.Merge(branch, mode); err != nil {
+			if git.HasConflicts() {
+				return operationStoppedMsg{target: branch, err: err}
+			}
+			return errMsg{err}
+		}
+		return loadBranches(showRemotes)
+	}
+}
+
+// doRebase rebases HEAD onto the branch. Stopping on conflicts sends the
+// user to the status view to resolve them.
+func (m BranchesModel) doRebase(branch string) tea.Cmd {
+	showRemotes := m.showRemotes
+	return func() tea.Msg {
+		if err := git.Rebase(branch); err != nil {
+			if git.HasConflicts() {
+				return operationStoppedMsg{target: branch, err: err}
+			}
+			return errMsg{err}
+		}
+		return loadBranches(showRemotes)
+	}
+}
+
+// renderIntegratePreview lists the commits a merge or rebase brings in
+func (m BranchesModel) renderIntegratePreview() string {
+	var sb strings.Builder
+	switch len(m.preview) {
+	case 0:
+		sb.WriteString(StyleMuted.Render(fmt.Sprintf("HEAD already contains '%s', no new commits.", m.pendingTarget)))
+		sb.WriteString("\n")
+	case 1:
+		sb.WriteString(fmt.Sprintf("1 commit from '%s' (HEAD..%s):\n", m.pendingTarget, m.pendingTarget))
+	default:
+		sb.WriteString(fmt.Sprintf("%d commits from '%s' (HEAD..%s):\n", len(m.preview), m.pendingTarget, m.pendingTarget))
+	}
+
+	// Keep the preview short so the branch list stays visible
+	const maxPreview = 10
+	for i, c := range m.preview {
+		if i == maxPreview {
+			sb.WriteString(StyleMuted.Render(fmt.Sprintf("  ... and %d more", len(m.preview)-maxPreview)))
+			sb.WriteString("\n")
+			break
+		}
+		sb.WriteString("  ")
+		sb.WriteString(StyleCommitHash.Render(c.ShortHash))
+		sb.WriteString(" ")
+		sb.WriteString(c.Subject)
+		sb.WriteString("\n")
+	}
+
+	if m.mergeMode {
+		sb.WriteString(m.mergePicker.View())
+		sb.WriteString(StyleMuted.Render("  (enter to merge, esc to cancel)"))
+	} else {
+		if m.replayed == 1 {
+			sb.WriteString("1 commit of HEAD will be replayed on top. ")
+		} else {
+			sb.WriteString(fmt.Sprintf("%d commits of HEAD will be replayed on top. ", m.replayed))
+		}
+		sb.WriteString(fmt.Sprintf("Rebase onto '%s'? (y/n) ", m.pendingTarget))
+	}
+	return sb.String()
+}
+
+// doRenameBranch renames a local branch. Renaming the current branch while it
+// has an upstream asks whether to rename the remote branch too.
+func (m BranchesModel) doRenameBranch(oldName, newName string) tea.Cmd {
+	var current git.Branch
+	for _, b := range m.branches {
+		if b.IsCurrent {
+			current = b
+		}
+	}
+	showRemotes := m.showRemotes
+	return func() tea.Msg {
+		if err := git.RenameBranch(oldName, newName); err != nil {
+			return errMsg{err}
+		}
+		if current.Name == oldName && current.Upstream != "" {
+			remote, remoteBranch := git.SplitRemoteBranch(current.Upstream)
+			return branchRenamedMsg{
+				oldName:      oldName,
+				newName:      newName,
+				upstream:     current.Upstream,
+				remote:       remote,
+				remoteBranch: remoteBranch,
+			}
+		}
+		return loadBranches(showRemotes)
+	}
+}
+
+// doRenameRemoteBranch renames the upstream of a renamed branch on its remote
+func (m BranchesModel) doRenameRemoteBranch() tea.Cmd {
+	remote, oldName := m.renamedRemote, m.renamedRemoteBranch
+	newName := m.renamedTo
+	showRemotes := m.showRemotes
+	return func() tea.Msg {
+		if err := git.RenameRemoteBranch(remote, oldName, newName); err != nil {
+			return errMsg{err}
+		}
+		return loadBranches(showRemotes)
+	}
+}
+
+// upstreamChoice is an option of the upstream picker
+type upstreamChoice struct {
+	remote string
+	ref    string // remote-tracking branch, e.g. "origin/feature"
+	isNew  bool   // the branch doesn't exist on the remote yet
+}
+
+// String returns the picker label of the choice
+func (c upstreamChoice) String() string {
+	if c.isNew {
+		return c.ref + " (new)"
+	}
+	return c.ref
+}
+
+// upstreamChoicesMsg carries the upstream options of a local branch
+type upstreamChoicesMsg struct {
+	branch  string
+	current string // current upstream, if any
+	choices []upstreamChoice
+}
+
+// loadUpstreamChoices lists the remote branches the branch can track:
+// existing branches of the same name first, then the remotes where that
+// branch would be created by pushing
+func loadUpstreamChoices(branch git.Branch) tea.Cmd {
+	return func() tea.Msg {
+		remotes, err := git.GetRemotes()
+		if err != nil {
+			return errMsg{err}
+		}
+		if len(remotes) == 0 {
+			return errMsg{fmt.Errorf("no remotes configured")}
+		}
+		remoteBranches, err := git.GetRemoteBranches()
+		if err != nil {
+			return errMsg{err}
+		}
+		existing := make(map[string]bool, len(remoteBranches))
+		for _, b := range remoteBranches {
+			existing[b.Name] = true
+		}
+
+		var choices, missing []upstreamChoice
+		for _, remote := range remotes {
+			ref := remote + "/" + branch.Name
+			if strings.HasPrefix(branch.Upstream, remote+"/") && existing[branch.Upstream] {
+				// Keep a current upstream with a different name on offer
+				ref = branch.Upstream
+			}
+			if existing[ref] {
+				choices = append(choices, upstreamChoice{remote: remote, ref: ref})
+			} else {
+				missing = append(missing, upstreamChoice{remote: remote, ref: ref, isNew: true})
+			}
+		}
+		return upstreamChoicesMsg{
+			branch:  branch.Name,
+			current: branch.Upstream,
+			choices: append(choices, missing...),
+		}
+	}
+}
+
+// doSetUpstream sets the upstream of a local branch. A branch that doesn't
+// exist on the remote yet is pushed there first.
+func (m BranchesModel) doSetUpstream(branch string, upstream upstreamChoice) tea.Cmd {
+	showRemotes := m.showRemotes
+	return func() tea.Msg {
+		var err error
+		if upstream.isNew {
+			err = git.PushSetUpstream(upstream.remote, branch)
+		} else {
+			err = git.SetUpstream(branch, upstream.ref)
+		}
+		if err != nil {
+			return errMsg{err}
+		}
+		return loadBranches(showRemotes)
+	}
+}
+
+// doUnsetUpstream removes the upstream of a local branch
+func (m BranchesModel) doUnsetUpstream(branch string) tea.Cmd {
+	showRemotes := m.showRemotes
+	return func() tea.Msg {
+		if err := git.UnsetUpstream(branch); err != nil {
+			return errMsg{err}
+		}
+		return loadBranches(showRemotes)
+	}
+}
+
+// doDeleteRemoteBranch deletes the selected branch on its remote
+func (m BranchesModel) doDeleteRemoteBranch() tea.Cmd {
+	if m.cursor >= len(m.branches) {
+		return nil
+	}
+	name := m.branches[m.cursor].Name
+	showRemotes := m.showRemotes
+	return func() tea.Msg {
+		if err := git.DeleteRemoteBranch(name); err != nil {
+			return errMsg{err}
+		}
+		return loadBranches(showRemotes)
 	}
 }
 
@@ -287,12 +762,13 @@ func (m BranchesModel) doForceDeleteBranch() tea.Cmd {
 		return nil
 	}
 	branchName := m.pendingDeleteBranch
+	showRemotes := m.showRemotes
 	return func() tea.Msg {
 		err := git.ForceDeleteBranch(branchName)
 		if err != nil {
 			return errMsg{err}
 		}
-		return refreshBranches()
+		return loadBranches(showRemotes)
 	}
 }
 
@@ -303,6 +779,13 @@ func (m BranchesModel) visibleLines() int {
 	if m.showVerboseHelp {
 		reserved += 3
 	}
+	// The "Remote branches" header, with a blank line above it after local branches
+	if n := len(m.branches); n > 0 && m.branches[n-1].IsRemote {
+		reserved++
+		if !m.branches[0].IsRemote {
+			reserved++
+		}
+	}
 	if m.height <= reserved {
 		return 10 // fallback minimum
 	}
@@ -376,6 +859,13 @@ func (m BranchesModel) View() string {
 
 	for i := visibleStart; i < visibleEnd; i++ {
 		branch := m.branches[i]
+		if branch.IsRemote && (i == 0 || !m.branches[i-1].IsRemote) {
+			if i > 0 {
+				sb.WriteString("\n")
+			}
+			sb.WriteString(StyleSectionHeader.Render("Remote branches"))
+			sb.WriteString("\n")
+		}
 		prefix := "  "
 		if i == m.cursor {
 			prefix = "> "
@@ -393,6 +883,8 @@ func (m BranchesModel) View() string {
 		var line string
 		if branch.IsCurrent {
 			line = prefix + StyleStaged.Render(name)
+		} else if branch.IsRemote {
+			line = prefix + StyleUnstaged.Render(name)
 		} else {
 			line = prefix + name
 		}
@@ -437,6 +929,11 @@ func (m BranchesModel) View() string {
 	if m.deleteConfirmMode && m.cursor < len(m.branches) {
 		sb.WriteString("\n")
 		branch := m.branches[m.cursor]
+		if branch.IsRemote {
+			remote, name := m.deleteRemote, m.deleteRemoteName
+			sb.WriteString(StyleConfirm.Render(fmt.Sprintf("This deletes '%s' on '%s' (git push %s --delete %s).", name, remote, remote, name)))
+			sb.WriteString("\n")
+		}
 		sb.WriteString(fmt.Sprintf("Type '%s' to delete: ", branch.Name))
 		sb.WriteString(m.deleteInput.View())
 		sb.WriteString(StyleMuted.Render("  (esc to cancel)"))
@@ -460,8 +957,33 @@ func (m BranchesModel) View() string {
 		sb.WriteString(StyleMuted.Render("  (enter to create, esc to cancel)"))
 	}
 
+	// Rename prompts
+	if m.renameMode {
+		sb.WriteString("\n")
+		sb.WriteString(fmt.Sprintf("Rename '%s' to: ", m.pendingRename))
+		sb.WriteString(m.renameInput.View())
+		sb.WriteString(StyleMuted.Render("  (enter to rename, esc to cancel)"))
+	}
+	if m.renameRemoteMode {
+		sb.WriteString("\n")
+		sb.WriteString(fmt.Sprintf("Also rename '%s' to '%s/%s' on the remote? (y/n) ", m.renamedUpstream, m.renamedRemote, m.renamedTo))
+	}
+
+	// Merge / rebase preview
+	if m.mergeMode || m.rebaseMode {
+		sb.WriteString("\n")
+		sb.WriteString(m.renderIntegratePreview())
+	}
+
+	// Upstream picker
+	if m.upstreamMode {
+		sb.WriteString("\n")
+		sb.WriteString(m.upstreamPicker.View())
+		sb.WriteString(StyleMuted.Render("  (enter to confirm, esc to cancel)"))
+	}
+
 	// Help bar (only show when showVerboseHelp is on and not in a special mode)
-	if m.showVerboseHelp && !m.inputMode && !m.deleteConfirmMode && !m.forceDeleteMode {
+	if m.showVerboseHelp && !m.inPrompt() {
 		sb.WriteString("\n\n")
 		sb.WriteString(m.renderHelpBar())
 	}
@@ -484,6 +1006,11 @@ func (m BranchesModel) renderHelpBar() string {
 		{formatKeyList(Keys.Right, "Enter"), "checkout"},
 		{Keys.NewBranch, "new"},
 		{Keys.Delete, "delete"},
+		{Keys.Merge, "merge"},
+		{Keys.Rebase, "rebase"},
+		{Keys.RenameBranch, "rename"},
+		{formatKeyList(Keys.SetUpstream, Keys.UnsetUpstream), "upstream"},
+		{formatKeyLabel(Keys.RemoteBranches), "remotes"},
 		{Keys.Help, "help"},
 		{formatKeyList(Keys.Left, "ESC"), "back"},
 	}
@@ -516,9 +1043,15 @@ func (m BranchesModel) renderHelp() string {
 		{moveKeys, "Move down/up"},
 		{topKey, "Go to top"},
 		{Keys.Bottom, "Go to bottom"},
-		{checkoutKeys, "Checkout branch"},
+		{checkoutKeys, "Checkout branch (remote: create a tracking branch)"},
 		{Keys.NewBranch, "Create new branch"},
-		{Keys.Delete, "Delete branch"},
+		{Keys.Delete, "Delete branch (remote: git push --delete)"},
+		{Keys.Merge, "Merge branch into HEAD"},
+		{Keys.Rebase, "Rebase HEAD onto branch"},
+		{Keys.RenameBranch, "Rename branch"},
+		{Keys.SetUpstream, "Set upstream branch (new ones are created by pushing)"},
+		{Keys.UnsetUpstream, "Unset upstream branch"},
+		{formatKeyLabel(Keys.RemoteBranches), "Show/hide remote branches"},
 		{Keys.Help, "Toggle help"},
 		{backKeys, "Go back"},
 	}
@@ -423,6 +423,320 @@ func TestBranchesModelView(t *testing.T) {
 	}
 }
 
+func TestBranchesModelViewRemoteBranches(t *testing.T) {
+	m := NewBranchesModel()
+	m.showRemotes = true
+	m.branches = []git.Branch{
+		{Name: "main", IsCurrent: true},
+		{Name: "origin/main", IsRemote: true},
+		{Name: "origin/feature", IsRemote: true},
+	}
+
+	view := m.View()
+	if strings.Count(view, "Remote branches") != 1 {
+		t.Errorf("view should have a single remote branches section, got:\n%s", view)
+	}
+	if strings.Index(view, "Remote branches") > strings.Index(view, "origin/main") {
+		t.Error("remote branches section header should come before the remote branches")
+	}
+}
+
+func TestBranchesModelVisibleLinesRemoteHeader(t *testing.T) {
+	m := NewBranchesModel()
+	m.height = 30
+	m.branches = []git.Branch{{Name: "main", IsCurrent: true}}
+	local := m.visibleLines()
+
+	m.branches = append(m.branches, git.Branch{Name: "origin/main", IsRemote: true})
+	if got := m.visibleLines(); got != local-2 {
+		t.Errorf("visibleLines() = %d, want %d to fit the remote branches header", got, local-2)
+	}
+}
+
+func TestBranchesModelToggleRemotes(t *testing.T) {
+	m := NewBranchesModel()
+	m.branches = []git.Branch{{Name: "main", IsCurrent: true}}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = newModel.(BranchesModel)
+	if !m.showRemotes {
+		t.Error("tab should show remote branches")
+	}
+	if cmd == nil {
+		t.Error("toggling should reload the branches")
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = newModel.(BranchesModel)
+	if m.showRemotes {
+		t.Error("tab again should hide remote branches")
+	}
+}
+
+func TestBranchesModelCheckoutRemote(t *testing.T) {
+	m := NewBranchesModel()
+	m.branches = []git.Branch{
+		{Name: "main", IsCurrent: true},
+		{Name: "origin/feature", IsRemote: true},
+	}
+	m.cursor = 1
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Error("enter on a remote branch should check out a tracking branch")
+	}
+}
+
+func TestBranchesModelDeleteRemoteConfirm(t *testing.T) {
+	m := NewBranchesModel()
+	m.branches = []git.Branch{
+		{Name: "main", IsCurrent: true},
+		{Name: "origin/feature", IsRemote: true},
+	}
+	m.cursor = 1
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	m = newModel.(BranchesModel)
+	if !m.deleteConfirmMode {
+		t.Fatal("delete on a remote branch should ask for confirmation")
+	}
+	view := m.View()
+	if !strings.Contains(view, "--delete feature") || !strings.Contains(view, "Type 'origin/feature' to delete") {
+		t.Errorf("view should explain the remote delete, got:\n%s", view)
+	}
+
+	// A wrong name cancels without deleting
+	m.deleteInput.SetValue("origin/other")
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(BranchesModel)
+	if m.deleteConfirmMode || cmd != nil {
+		t.Error("wrong name should cancel the remote delete")
+	}
+}
+
+func TestBranchesModelRenameMode(t *testing.T) {
+	m := NewBranchesModel()
+	m.branches = []git.Branch{
+		{Name: "main", IsCurrent: true},
+		{Name: "feature"},
+	}
+	m.cursor = 1
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'R'}})
+	m = newModel.(BranchesModel)
+	if !m.renameMode {
+		t.Fatal("R should open the rename input")
+	}
+	if got := m.renameInput.Value(); got != "feature" {
+		t.Errorf("rename input = %q, want the current name", got)
+	}
+	if !m.inPrompt() {
+		t.Error("rename input should count as a prompt")
+	}
+	if view := m.View(); !strings.Contains(view, "Rename 'feature' to:") {
+		t.Errorf("view should show the rename prompt, got:\n%s", view)
+	}
+
+	// Enter without changing the name does nothing
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(BranchesModel)
+	if m.renameMode || cmd != nil {
+		t.Error("enter with the same name should close the prompt without renaming")
+	}
+}
+
+func TestBranchesModelRenameIgnoresRemote(t *testing.T) {
+	m := NewBranchesModel()
+	m.branches = []git.Branch{{Name: "origin/main", IsRemote: true}}
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'R'}})
+	m = newModel.(BranchesModel)
+	if m.renameMode {
+		t.Error("remote branches can't be renamed from here")
+	}
+}
+
+func TestBranchesModelRenameRemotePrompt(t *testing.T) {
+	m := NewBranchesModel()
+	m.branches = []git.Branch{{Name: "new-name", IsCurrent: true, Upstream: "origin/old-name"}}
+
+	newModel, _ := m.Update(branchRenamedMsg{
+		oldName:      "old-name",
+		newName:      "new-name",
+		upstream:     "origin/old-name",
+		remote:       "origin",
+		remoteBranch: "old-name",
+	})
+	m = newModel.(BranchesModel)
+	if !m.renameRemoteMode {
+		t.Fatal("renaming the current branch should offer to rename the remote branch")
+	}
+	if view := m.View(); !strings.Contains(view, "Also rename 'origin/old-name' to 'origin/new-name'") {
+		t.Errorf("view should show the remote rename prompt, got:\n%s", view)
+	}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	m = newModel.(BranchesModel)
+	if m.renameRemoteMode || cmd != nil {
+		t.Error("n should keep the remote branch as is")
+	}
+}
+
+func TestBranchesModelUpstreamPicker(t *testing.T) {
+	m := NewBranchesModel()
+	m.branches = []git.Branch{{Name: "main", IsCurrent: true}}
+	newModel, _ := m.Update(upstreamChoicesMsg{
+		branch: "main",
+		choices: []upstreamChoice{
+			{remote: "origin", ref: "origin/main"},
+			{remote: "fork", ref: "fork/main", isNew: true},
+		},
+	})
+	m = newModel.(BranchesModel)
+	if !m.upstreamMode {
+		t.Fatal("loading the choices should open the upstream picker")
+	}
+
+	if view := m.View(); !strings.Contains(view, "fork/main") {
+		t.Errorf("view should list the upstream choices, got:\n%s", view)
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	m = newModel.(BranchesModel)
+	if m.upstreamPicker.selected() != "fork/main (new)" {
+		t.Errorf("selected = %q, want fork/main marked as new", m.upstreamPicker.selected())
+	}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(BranchesModel)
+	if m.upstreamMode || cmd == nil {
+		t.Error("enter should set the upstream")
+	}
+}
+
+func TestBranchesModelUpstreamPickerStartsOnCurrent(t *testing.T) {
+	m := NewBranchesModel()
+	m.branches = []git.Branch{{Name: "main", IsCurrent: true, Upstream: "fork/main"}}
+
+	newModel, _ := m.Update(upstreamChoicesMsg{
+		branch:  "main",
+		current: "fork/main",
+		choices: []upstreamChoice{
+			{remote: "origin", ref: "origin/main"},
+			{remote: "fork", ref: "fork/main"},
+		},
+	})
+	m = newModel.(BranchesModel)
+	if got := m.upstreamPicker.selected(); got != "fork/main" {
+		t.Errorf("selected = %q, want the current upstream", got)
+	}
+}
+
+func TestBranchesModelUnsetUpstream(t *testing.T) {
+	m := NewBranchesModel()
+	m.branches = []git.Branch{{Name: "main", IsCurrent: true}}
+
+	// Nothing to unset without an upstream
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'U'}})
+	if cmd != nil {
+		t.Error("unset upstream should do nothing without an upstream")
+	}
+
+	m.branches[0].Upstream = "origin/main"
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'U'}})
+	if cmd == nil {
+		t.Error("unset upstream should run with an upstream")
+	}
+}
+
+func TestBranchesModelMergePreview(t *testing.T) {
+	m := NewBranchesModel()
+	m.branches = []git.Branch{
+		{Name: "main", IsCurrent: true},
+		{Name: "feature"},
+	}
+	m.cursor = 1
+	m.pendingTarget = "feature"
+	m.preview = []git.CommitInfo{
+		{ShortHash: "abc1234", Subject: "Add login"},
+		{ShortHash: "def5678", Subject: "Add logout"},
+	}
+	m.mergePicker = newPicker("Merge 'feature' into HEAD with:", []string{"no-ff", "ff-only", "squash"}, 0)
+	m.mergeMode = true
+
+	view := m.View()
+	for _, want := range []string{"2 commits from 'feature' (HEAD..feature)", "abc1234", "Add logout", "squash"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q, got:\n%s", want, view)
+		}
+	}
+
+	// Pick squash and merge
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'G'}})
+	m = newModel.(BranchesModel)
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	m = newModel.(BranchesModel)
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	m = newModel.(BranchesModel)
+	if got := mergeModes[m.mergePicker.cursor]; got != git.MergeSquash {
+		t.Errorf("merge mode = %v, want squash", got)
+	}
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(BranchesModel)
+	if m.mergeMode || cmd == nil {
+		t.Error("enter should run the merge")
+	}
+}
+
+func TestBranchesModelMergePreviewCancel(t *testing.T) {
+	m := NewBranchesModel()
+	m.branches = []git.Branch{{Name: "main", IsCurrent: true}, {Name: "feature"}}
+	m.pendingTarget = "feature"
+	m.mergePicker = newPicker("Merge 'feature' into HEAD with:", []string{"no-ff", "ff-only", "squash"}, 0)
+	m.mergeMode = true
+
+	if view := m.View(); !strings.Contains(view, "no new commits") {
+		t.Errorf("view should say there is nothing to merge, got:\n%s", view)
+	}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(BranchesModel)
+	if m.mergeMode || cmd != nil {
+		t.Error("esc should cancel the merge")
+	}
+}
+
+func TestBranchesModelRebasePreview(t *testing.T) {
+	m := NewBranchesModel()
+	m.branches = []git.Branch{{Name: "main", IsCurrent: true}, {Name: "feature"}}
+	m.pendingTarget = "feature"
+	m.preview = []git.CommitInfo{{ShortHash: "abc1234", Subject: "Add login"}}
+	m.replayed = 3
+	m.rebaseMode = true
+
+	view := m.View()
+	if !strings.Contains(view, "1 commit from 'feature'") || !strings.Contains(view, "3 commits of HEAD will be replayed") {
+		t.Errorf("view should preview the rebase, got:\n%s", view)
+	}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m = newModel.(BranchesModel)
+	if m.rebaseMode || cmd == nil {
+		t.Error("y should run the rebase")
+	}
+}
+
+func TestBranchesModelMergeIgnoresCurrent(t *testing.T) {
+	m := NewBranchesModel()
+	m.branches = []git.Branch{{Name: "main", IsCurrent: true}}
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'M'}})
+	m = newModel.(BranchesModel)
+	if m.mergeMode {
+		t.Error("the current branch can't be merged into itself")
+	}
+}
+
 func TestBranchesModelViewEmpty(t *testing.T) {
 	m := NewBranchesModel()
 	m.branches = nil
@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// Conventional commit message line lengths
+	subjectSoftLimit = 50
+	lineHardLimit    = 72
+
+	composerWidth  = lineHardLimit + 8 // room for the prompt and a few characters past the limit
+	composerHeight = 6
+)
+
+// commitComposer is a multi-line commit message editor: the subject goes on
+// the first line, followed by a blank line and the body
+type commitComposer struct {
+	textarea textarea.Model
+}
+
+func newCommitComposer() commitComposer {
+	ta := textarea.New()
+	ta.Placeholder = "Subject on the first line, then a blank line and the body"
+	ta.ShowLineNumbers = false
+	ta.CharLimit = 0
+	ta.SetWidth(composerWidth)
+	ta.SetHeight(composerHeight)
+	return commitComposer{textarea: ta}
+}
+
+// Focus focuses the composer and returns the cursor blink command
+func (c *commitComposer) Focus() tea.Cmd {
+	return c.textarea.Focus()
+}
+
+// Blur removes focus from the composer
+func (c *commitComposer) Blur() {
+	c.textarea.Blur()
+}
+
+// Reset clears the message
+func (c *commitComposer) Reset() {
+	c.textarea.Reset()
+}
+
+// SetValue replaces the message and moves the cursor to the start of the subject
+func (c *commitComposer) SetValue(message string) {
+	c.textarea.SetValue(message)
+	for c.textarea.Line() > 0 {
+		c.textarea.CursorUp()
+	}
+	c.textarea.CursorStart()
+}
+
+// Value returns the message as typed, including any # comment lines
+func (c commitComposer) Value() string {
+	return c.textarea.Value()
+}
+
+// SetWidth fits the composer to the terminal width
+func (c *commitComposer) SetWidth(width int) {
+	if width > 0 {
+		c.textarea.SetWidth(min(width, composerWidth))
+	}
+}
+
+// Update forwards a message to the textarea
+func (c commitComposer) Update(msg tea.Msg) (commitComposer, tea.Cmd) {
+	var cmd tea.Cmd
+	c.textarea, cmd = c.textarea.Update(msg)
+	return c, cmd
+}
+
+// View renders the textarea followed by the column indicator
+func (c commitComposer) View() string {
+	return c.textarea.View() + "\n" + c.columnIndicator()
+}
+
+// columnIndicator shows which part of the message the cursor is on and the
+// length of the current line, turning yellow past 50 characters on the
+// subject and red past 72 on any line
+func (c commitComposer) columnIndicator() string {
+	lines := strings.Split(c.textarea.Value(), "\n")
+	row := min(c.textarea.Line(), len(lines)-1)
+	length := utf8.RuneCountInString(lines[row])
+
+	switch row {
+	case 0:
+		text := fmt.Sprintf("subject %d/%d", length, subjectSoftLimit)
+		switch {
+		case length > lineHardLimit:
+			return StyleColumnOver.Render(text)
+		case length > subjectSoftLimit:
+			return StyleColumnWarn.Render(text)
+		}
+		return StyleMuted.Render(text)
+	case 1:
+		if length > 0 {
+			return StyleColumnOver.Render("line 2 should be blank to separate the subject from the body")
+		}
+		return StyleMuted.Render("blank line")
+	}
+
+	text := fmt.Sprintf("body %d/%d", length, lineHardLimit)
+	if length > lineHardLimit {
+		return StyleColumnOver.Render(text)
+	}
+	return StyleMuted.Render(text)
+}
@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestCommitComposerSetValueMovesCursorToSubject(t *testing.T) {
+	c := newCommitComposer()
+	c.SetValue("Subject\n\n# Explain why\n")
+
+	if c.textarea.Line() != 0 {
+		t.Errorf("cursor line = %d, want 0 (the subject)", c.textarea.Line())
+	}
+	if c.Value() != "Subject\n\n# Explain why\n" {
+		t.Errorf("Value() = %q, want the template unchanged", c.Value())
+	}
+}
+
+func TestCommitComposerColumnIndicator(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		line    int
+		want    string
+	}{
+		{"short subject", "Fix bug", 0, "subject 7/50"},
+		{"long subject", strings.Repeat("x", 60), 0, "subject 60/50"},
+		{"blank separator", "Fix bug\n\nBody", 1, "blank line"},
+		{"missing separator", "Fix bug\nBody", 1, "line 2 should be blank"},
+		{"body", "Fix bug\n\n" + strings.Repeat("x", 80), 2, "body 80/72"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newCommitComposer()
+			c.SetValue(tt.message)
+			for c.textarea.Line() < tt.line {
+				c.textarea.CursorDown()
+			}
+			if got := c.columnIndicator(); !strings.Contains(got, tt.want) {
+				t.Errorf("columnIndicator() = %q, want it to contain %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommitComposerColumnIndicatorColors(t *testing.T) {
+	subject := func(n int) string {
+		c := newCommitComposer()
+		c.SetValue(strings.Repeat("x", n))
+		return c.columnIndicator()
+	}
+	text := func(n int) string {
+		return fmt.Sprintf("subject %d/50", n)
+	}
+
+	if got := subject(40); got != StyleMuted.Render(text(40)) {
+		t.Errorf("subject under 50 should be muted, got %q", got)
+	}
+	if got := subject(60); got != StyleColumnWarn.Render(text(60)) {
+		t.Errorf("subject past 50 should be yellow, got %q", got)
+	}
+	if got := subject(80); got != StyleColumnOver.Render(text(80)) {
+		t.Errorf("subject past 72 should be red, got %q", got)
+	}
+}
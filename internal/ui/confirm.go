@@ -0,0 +1,41 @@
+package ui
+
+// typedConfirmResult is the outcome of a key in a typedConfirm prompt
+type typedConfirmResult int
+
+const (
+	typedConfirmPending typedConfirmResult = iota
+	typedConfirmAccepted
+	typedConfirmCancelled
+)
+
+// typedConfirm is a prompt for destructive actions, such as a hard reset,
+// that is only confirmed by typing "yes"
+type typedConfirm struct {
+	input string
+}
+
+// update handles a key: letters are typed, enter confirms if "yes" was typed
+// and esc cancels. The input is cleared once the prompt is done.
+func (c *typedConfirm) update(key string) typedConfirmResult {
+	switch key {
+	case "backspace":
+		if len(c.input) > 0 {
+			c.input = c.input[:len(c.input)-1]
+		}
+	case "enter":
+		if c.input == "yes" {
+			c.input = ""
+			return typedConfirmAccepted
+		}
+	case "esc":
+		c.input = ""
+		return typedConfirmCancelled
+	default:
+		// Only accept lowercase letters for typing "yes"
+		if len(key) == 1 && key[0] >= 'a' && key[0] <= 'z' {
+			c.input += key
+		}
+	}
+	return typedConfirmPending
+}
@@ -0,0 +1,31 @@
+package ui
+
+import "testing"
+
+func TestTypedConfirm(t *testing.T) {
+	var c typedConfirm
+
+	for _, key := range []string{"y", "e", "X", "s", "s", "backspace"} {
+		if got := c.update(key); got != typedConfirmPending {
+			t.Fatalf("update(%q) = %v, want pending", key, got)
+		}
+	}
+	if c.input != "yes" {
+		t.Errorf("input = %q, want yes (uppercase ignored, backspace removes a letter)", c.input)
+	}
+	if got := c.update("enter"); got != typedConfirmAccepted {
+		t.Errorf("enter after yes = %v, want accepted", got)
+	}
+	if c.input != "" {
+		t.Errorf("input should be cleared once confirmed, got %q", c.input)
+	}
+
+	c.update("n")
+	c.update("o")
+	if got := c.update("enter"); got != typedConfirmPending {
+		t.Errorf("enter after no = %v, want pending", got)
+	}
+	if got := c.update("esc"); got != typedConfirmCancelled || c.input != "" {
+		t.Errorf("esc = %v with input %q, want cancelled and cleared", got, c.input)
+	}
+}
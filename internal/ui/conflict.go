@@ -0,0 +1,346 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"go-on-git/internal/git"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ConflictModel is the bubbletea model for resolving conflicts in a single file
+type ConflictModel struct {
+	path         string
+	conflict     *git.ConflictFile
+	cursor       int
+	scrollOffset int
+	showHelp     bool
+	lastKey      string
+	err          error
+	width        int
+	height       int
+}
+
+// NewConflictModel creates a conflict view for a repo-relative path
+func NewConflictModel(path string, width, height int) ConflictModel {
+	return ConflictModel{
+		path:   path,
+		width:  width,
+		height: height,
+	}
+}
+
+type conflictMsg struct {
+	conflict *git.ConflictFile
+}
+
+// conflictResolvedMsg is sent once a file has been marked resolved
+type conflictResolvedMsg struct {
+	path string
+}
+
+func loadConflict(path string) tea.Cmd {
+	return func() tea.Msg {
+		conflict, err := git.GetConflictFile(path)
+		if err != nil {
+			return errMsg{err}
+		}
+		return conflictMsg{conflict}
+	}
+}
+
+// Init initializes the model
+func (m ConflictModel) Init() tea.Cmd {
+	return loadConflict(m.path)
+}
+
+// Update handles messages
+func (m ConflictModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		key := msg.String()
+
+		if m.showHelp {
+			if key == Keys.Help || key == "esc" || key == Keys.Quit {
+				m.showHelp = false
+			}
+			return m, nil
+		}
+
+		// Check for gg sequence
+		if m.lastKey == Keys.Top && key == Keys.Top {
+			m.lastKey = ""
+			m.cursor = 0
+			m.scrollOffset = 0
+			return m, nil
+		}
+
+		if key == Keys.Top {
+			m.lastKey = Keys.Top
+			return m, nil
+		}
+		m.lastKey = ""
+
+		switch key {
+		case Keys.Help:
+			m.showHelp = true
+			return m, nil
+		case Keys.Down, "down":
+			if m.blockCount() > 0 {
+				m.cursor = min(m.cursor+1, m.blockCount()-1)
+				m.scrollOffset = 0
+			}
+			return m, nil
+		case Keys.Up, "up":
+			if m.blockCount() > 0 {
+				m.cursor = max(m.cursor-1, 0)
+				m.scrollOffset = 0
+			}
+			return m, nil
+		case Keys.Bottom:
+			if m.blockCount() > 0 {
+				m.cursor = m.blockCount() - 1
+				m.scrollOffset = 0
+			}
+			return m, nil
+		case "ctrl+d":
+			if m.cursor < m.blockCount() {
+				maxScroll := len(renderConflictBlock(m.conflict.Blocks[m.cursor])) - m.visibleLines()
+				m.scrollOffset = max(min(m.scrollOffset+m.visibleLines()/2, maxScroll), 0)
+			}
+			return m, nil
+		case "ctrl+u":
+			m.scrollOffset = max(m.scrollOffset-m.visibleLines()/2, 0)
+			return m, nil
+		case Keys.Ours:
+			return m, m.resolve(git.ResolveOurs)
+		case Keys.Theirs:
+			return m, m.resolve(git.ResolveTheirs)
+		case Keys.Both:
+			return m, m.resolve(git.ResolveBoth)
+		case Keys.Edit:
+			return m, m.openEditor()
+		case Keys.Stage, " ":
+			return m, m.markResolved()
+		}
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case conflictMsg:
+		m.conflict = msg.conflict
+		m.err = nil
+		if m.cursor >= m.blockCount() {
+			m.cursor = max(0, m.blockCount()-1)
+		}
+		m.scrollOffset = 0
+		return m, nil
+
+	case errMsg:
+		m.err = msg.err
+		return m, nil
+	}
+
+	return m, nil
+}
+
+func (m ConflictModel) blockCount() int {
+	if m.conflict == nil {
+		return 0
+	}
+	return len(m.conflict.Blocks)
+}
+
+func (m ConflictModel) resolve(resolution git.ConflictResolution) tea.Cmd {
+	if m.cursor >= m.blockCount() {
+		return nil
+	}
+	path := m.path
+	block := m.cursor
+	return func() tea.Msg {
+		if err := git.ResolveConflict(path, block, resolution); err != nil {
+			return errMsg{err}
+		}
+		return loadConflict(path)()
+	}
+}
+
+// openEditor opens $EDITOR at the marker under the cursor (or the top of the file)
+func (m ConflictModel) openEditor() tea.Cmd {
+	if m.conflict != nil && !m.conflict.Exists {
+		return nil
+	}
+	line := 0
+	if m.cursor < m.blockCount() {
+		line = m.conflict.Blocks[m.cursor].StartLine
+	}
+	path := m.path
+	c := editorCommand(filepath.Join(git.GetRepoRoot(), path), line)
+	return tea.ExecProcess(c, func(err error) tea.Msg {
+		if err != nil {
+			return errMsg{err}
+		}
+		return loadConflict(path)()
+	})
+}
+
+func (m ConflictModel) markResolved() tea.Cmd {
+	path := m.path
+	return func() tea.Msg {
+		if err := git.MarkResolved(path); err != nil {
+			return errMsg{err}
+		}
+		return conflictResolvedMsg{path}
+	}
+}
+
+func (m ConflictModel) visibleLines() int {
+	// Reserve lines for the block list, header and prompt
+	reserved := m.blockCount() + 6
+	if m.height <= reserved+5 {
+		return 20
+	}
+	return m.height - reserved
+}
+
+func (m ConflictModel) anchorBottom(content string) string {
+	lines := strings.Count(content, "\n")
+	if m.height <= lines {
+		return content
+	}
+	padding := m.height - lines - 1
+	return strings.Repeat("\n", padding) + content
+}
+
+// View renders the model
+func (m ConflictModel) View() string {
+	if m.showHelp {
+		return m.renderHelp()
+	}
+
+	var sb strings.Builder
+
+	if m.err != nil {
+		sb.WriteString(StyleUnstaged.Render(fmt.Sprintf("Error: %v", m.err)))
+		sb.WriteString("\n")
+	}
+
+	if m.conflict == nil {
+		sb.WriteString(StyleMuted.Render("Loading..."))
+		sb.WriteString("\n")
+		return sb.String()
+	}
+
+	if !m.conflict.HasMarkers() {
+		if m.conflict.Exists {
+			sb.WriteString(StyleEmpty.Render(fmt.Sprintf("No conflict markers left in '%s'", m.conflict.DisplayPath)))
+		} else {
+			sb.WriteString(StyleEmpty.Render(fmt.Sprintf("'%s' was deleted on one side", m.conflict.DisplayPath)))
+		}
+		sb.WriteString("\n\n")
+		sb.WriteString(StyleMuted.Render(fmt.Sprintf("Press %s to mark resolved, %s to go back", Keys.Stage, formatKeyList(Keys.Left, "ESC"))))
+		sb.WriteString("\n")
+		return m.anchorBottom(sb.String())
+	}
+
+	// Current block preview (scrollable)
+	block := m.conflict.Blocks[m.cursor]
+	lines := renderConflictBlock(block)
+	visible := m.visibleLines()
+	start := min(m.scrollOffset, max(len(lines)-visible, 0))
+	end := min(start+visible, len(lines))
+	for i := start; i < end; i++ {
+		sb.WriteString(lines[i])
+		sb.WriteString("\n")
+	}
+	if len(lines) > visible {
+		sb.WriteString(StyleMuted.Render(fmt.Sprintf("Lines %d-%d of %d (ctrl+d/u to scroll)", start+1, end, len(lines))))
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+
+	// Block list
+	for i, b := range m.conflict.Blocks {
+		cursor := "  "
+		if i == m.cursor {
+			cursor = "> "
+		}
+		sb.WriteString(cursor)
+		sb.WriteString(StyleConflicted.Render("[C]"))
+		sb.WriteString(fmt.Sprintf(" lines %d-%d  %s %d / %s %d", b.StartLine, b.EndLine,
+			StyleStaged.Render("ours"), len(b.Ours), StyleUnstaged.Render("theirs"), len(b.Theirs)))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(fmt.Sprintf("─── %s %s (%d/%d) ───", StyleConflicted.Render("[Conflict]"), m.conflict.DisplayPath, m.cursor+1, len(m.conflict.Blocks)))
+	sb.WriteString("\n")
+	sb.WriteString(StyleMuted.Render(fmt.Sprintf("%s ours  %s theirs  %s both  %s edit  %s mark resolved",
+		Keys.Ours, Keys.Theirs, Keys.Both, Keys.Edit, Keys.Stage)))
+	sb.WriteString("\n")
+
+	return m.anchorBottom(sb.String())
+}
+
+// renderConflictBlock renders a block with its markers, coloring each side
+func renderConflictBlock(block git.ConflictBlock) []string {
+	var lines []string
+
+	lines = append(lines, StyleDiffHeader.Render(fmt.Sprintf("<<<<<<< %s (ours)", block.OursLabel)))
+	for _, line := range block.Ours {
+		lines = append(lines, StyleDiffAdded.Render(line))
+	}
+	if len(block.Base) > 0 {
+		lines = append(lines, StyleDiffHeader.Render("||||||| base"))
+		for _, line := range block.Base {
+			lines = append(lines, StyleDiffContext.Render(line))
+		}
+	}
+	lines = append(lines, StyleDiffHeader.Render("======="))
+	for _, line := range block.Theirs {
+		lines = append(lines, StyleDiffRemoved.Render(line))
+	}
+	lines = append(lines, StyleDiffHeader.Render(fmt.Sprintf(">>>>>>> %s (theirs)", block.TheirsLabel)))
+
+	return lines
+}
+
+func (m ConflictModel) renderHelp() string {
+	var sb strings.Builder
+
+	sb.WriteString(StyleHelpTitle.Render("Conflict Shortcuts"))
+	sb.WriteString("\n\n")
+
+	moveKeys := formatKeyList(Keys.Down, Keys.Up, "↓", "↑")
+	topKey := formatDoubleKey(Keys.Top)
+	resolvedKeys := formatKeyList(Keys.Stage, " ")
+	backKeys := formatKeyList(Keys.Left, "←", "ESC")
+
+	help := []struct {
+		key  string
+		desc string
+	}{
+		{moveKeys, "Next/previous conflict"},
+		{topKey, "Go to first conflict"},
+		{Keys.Bottom, "Go to last conflict"},
+		{"ctrl+d/u", "Scroll conflict"},
+		{Keys.Ours, "Keep ours"},
+		{Keys.Theirs, "Keep theirs"},
+		{Keys.Both, "Keep both (ours first)"},
+		{Keys.Edit, "Open $EDITOR at conflict"},
+		{resolvedKeys, "Mark file resolved"},
+		{Keys.Help, "Toggle help"},
+		{backKeys, "Go back"},
+	}
+
+	for _, h := range help {
+		sb.WriteString(fmt.Sprintf("  %s  %s\n",
+			StyleHelpKey.Render(fmt.Sprintf("%-8s", h.key)),
+			StyleHelpDesc.Render(h.desc)))
+	}
+
+	return sb.String()
+}
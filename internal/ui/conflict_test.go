@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"go-on-git/internal/git"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func testConflictFile() *git.ConflictFile {
+	return &git.ConflictFile{
+		Path:        "file.txt",
+		DisplayPath: "file.txt",
+		Exists:      true,
+		Blocks: []git.ConflictBlock{
+			{StartLine: 1, EndLine: 5, OursLabel: "HEAD", TheirsLabel: "feature", Ours: []string{"ours 1"}, Theirs: []string{"theirs 1"}},
+			{StartLine: 8, EndLine: 12, OursLabel: "HEAD", TheirsLabel: "feature", Ours: []string{"ours 2"}, Theirs: []string{"theirs 2"}},
+		},
+	}
+}
+
+func TestNewConflictModel(t *testing.T) {
+	m := NewConflictModel("file.txt", 80, 24)
+
+	if m.path != "file.txt" {
+		t.Errorf("path = %q, want file.txt", m.path)
+	}
+	if m.width != 80 || m.height != 24 {
+		t.Errorf("size = %dx%d, want 80x24", m.width, m.height)
+	}
+	if m.Init() == nil {
+		t.Error("Init() should return a command")
+	}
+}
+
+func TestConflictModelNavigation(t *testing.T) {
+	m := NewConflictModel("file.txt", 80, 24)
+	newModel, _ := m.Update(conflictMsg{testConflictFile()})
+	m = newModel.(ConflictModel)
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m = newModel.(ConflictModel)
+	if m.cursor != 1 {
+		t.Errorf("after 'j', cursor = %d, want 1", m.cursor)
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m = newModel.(ConflictModel)
+	if m.cursor != 1 {
+		t.Errorf("cursor should stay at 1, got %d", m.cursor)
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
+	m = newModel.(ConflictModel)
+	if m.cursor != 0 {
+		t.Errorf("after 'k', cursor = %d, want 0", m.cursor)
+	}
+}
+
+func TestConflictModelCursorClampedOnReload(t *testing.T) {
+	m := NewConflictModel("file.txt", 80, 24)
+	m.cursor = 1
+
+	conflict := testConflictFile()
+	conflict.Blocks = conflict.Blocks[:1]
+	newModel, _ := m.Update(conflictMsg{conflict})
+	m = newModel.(ConflictModel)
+
+	if m.cursor != 0 {
+		t.Errorf("cursor = %d, want 0 after a block was resolved", m.cursor)
+	}
+}
+
+func TestConflictModelResolveKeysReturnCommands(t *testing.T) {
+	m := NewConflictModel("file.txt", 80, 24)
+	newModel, _ := m.Update(conflictMsg{testConflictFile()})
+	m = newModel.(ConflictModel)
+
+	for _, key := range []string{Keys.Ours, Keys.Theirs, Keys.Both, Keys.Stage} {
+		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		if cmd == nil {
+			t.Errorf("key %q should return a command", key)
+		}
+	}
+}
+
+func TestConflictModelView(t *testing.T) {
+	m := NewConflictModel("file.txt", 80, 24)
+	newModel, _ := m.Update(conflictMsg{testConflictFile()})
+	m = newModel.(ConflictModel)
+
+	view := m.View()
+	for _, want := range []string{"ours 1", "theirs 1", "<<<<<<< HEAD", ">>>>>>> feature", "(1/2)"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q", want)
+		}
+	}
+}
+
+func TestConflictModelViewNoMarkers(t *testing.T) {
+	m := NewConflictModel("file.txt", 80, 24)
+	newModel, _ := m.Update(conflictMsg{&git.ConflictFile{Path: "file.txt", DisplayPath: "file.txt", Exists: true}})
+	m = newModel.(ConflictModel)
+
+	if !strings.Contains(m.View(), "No conflict markers left") {
+		t.Error("view should say no markers are left")
+	}
+}
+
+func TestConflictModelViewHelp(t *testing.T) {
+	m := NewConflictModel("file.txt", 80, 24)
+	m.showHelp = true
+
+	if !strings.Contains(m.View(), "Conflict Shortcuts") {
+		t.Error("help view should contain title")
+	}
+}
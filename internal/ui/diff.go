@@ -2,6 +2,8 @@ package ui
 
 import (
 	"fmt"
+	"os"
+	"os/exec"
 	"strings"
 
 	"go-on-git/internal/git"
@@ -14,15 +16,24 @@ type DiffModel struct {
 	diff             *git.CombinedDiffResult
 	hunks            []git.Hunk
 	cursor           int
-	listScrollOffset int          // scroll position in hunk list
-	filterFiles      []FileFilter // only show hunks for these files (empty = all)
-	viewingHunk      bool         // true when drilled into a single hunk
-	viewingFullDiff  bool         // true when viewing complete diff output
-	scrollOffset     int          // scroll position within hunk content or full diff
+	listScrollOffset int             // scroll position in hunk list
+	filterFiles      []FileFilter    // only show hunks for these files (empty = all)
+	viewingHunk      bool            // true when drilled into a single hunk
+	viewingFullDiff  bool            // true when viewing complete diff output
+	scrollOffset     int             // scroll position within hunk content or full diff
+	lineCursor       int             // selected line within the hunk detail view
+	visualMode       bool            // true when selecting a range of lines in the hunk detail view
+	visualStart      int             // line where the visual selection started
+	hunkVisualMode   bool            // true when selecting a range of hunks in the hunk list
+	hunkVisualStart  int             // hunk where the visual selection started
+	stashConfirm     bool            // confirming a stash of the selected hunks
+	splitKeys        map[string]bool // stable keys of sub-hunks split off by the user
 	showHelp         bool
 	confirmMode      bool
 	confirmInput     string
 	lastKey          string
+	commitHash       string            // when set, shows this commit (read-only) instead of the working tree
+	commit           *git.CommitDetail // loaded commit metadata and diffstat
 	err              error
 	width            int
 	height           int
@@ -65,6 +76,15 @@ func NewDiffModelWithFilters(filters []FileFilter, width, height int) DiffModel
 	}
 }
 
+// NewCommitDiffModel creates a read-only diff model showing a single commit
+func NewCommitDiffModel(hash string, width, height int) DiffModel {
+	return DiffModel{
+		commitHash: hash,
+		width:      width,
+		height:     height,
+	}
+}
+
 // IsViewingHunk returns true if the user is in the hunk detail view
 func (m DiffModel) IsViewingHunk() bool {
 	return m.viewingHunk
@@ -72,9 +92,31 @@ func (m DiffModel) IsViewingHunk() bool {
 
 // Init initializes the model
 func (m DiffModel) Init() tea.Cmd {
+	if m.isCommitView() {
+		return loadCommitDetail(m.commitHash)
+	}
 	return m.refreshCombinedDiff
 }
 
+type commitDetailMsg struct {
+	detail *git.CommitDetail
+}
+
+func loadCommitDetail(hash string) tea.Cmd {
+	return func() tea.Msg {
+		detail, err := git.GetCommitDetail(hash)
+		if err != nil {
+			return errMsg{err}
+		}
+		return commitDetailMsg{detail}
+	}
+}
+
+// isCommitView returns true when showing a commit rather than the working tree
+func (m DiffModel) isCommitView() bool {
+	return m.commitHash != ""
+}
+
 func (m DiffModel) refreshCombinedDiff() tea.Msg {
 	diff, err := git.GetCombinedDiff()
 	if err != nil {
@@ -124,6 +166,25 @@ func (m DiffModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		if m.stashConfirm {
+			switch key {
+			case "y", "Y":
+				m.stashConfirm = false
+				return m, m.stashHunks()
+			case "n", "N", "esc":
+				m.stashConfirm = false
+			}
+			return m, nil
+		}
+
+		// Commits are read-only: ignore staging and editing keys
+		if m.isCommitView() {
+			switch key {
+			case " ", Keys.Stage, Keys.Unstage, Keys.Discard, Keys.Edit, Keys.Visual, Keys.Split, Keys.EditHunk, Keys.StashHunks:
+				return m, nil
+			}
+		}
+
 		// Handle full diff view navigation
 		if m.viewingFullDiff {
 			switch key {
@@ -167,24 +228,30 @@ func (m DiffModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Handle hunk detail view navigation
 		if m.viewingHunk {
 			switch key {
-			case Keys.Left, "left", "esc":
-				m.viewingHunk = false
-				m.scrollOffset = 0
+			case "esc":
+				if m.visualMode {
+					m.visualMode = false
+					return m, nil
+				}
+				m.leaveHunkDetail()
+				return m, nil
+			case Keys.Left, "left":
+				m.leaveHunkDetail()
 				return m, nil
 			case Keys.Down, "down":
 				if m.cursor < len(m.hunks) {
-					maxScroll := len(m.hunks[m.cursor].Lines) - m.visibleLines()
-					if maxScroll > 0 {
-						m.scrollOffset = min(m.scrollOffset+1, maxScroll)
-					}
+					m.lineCursor = max(min(m.lineCursor+1, len(m.hunks[m.cursor].Lines)-1), 0)
+					m.ensureLineCursorVisible()
 				}
 				return m, nil
 			case Keys.Up, "up":
-				m.scrollOffset = max(m.scrollOffset-1, 0)
+				m.lineCursor = max(m.lineCursor-1, 0)
+				m.ensureLineCursorVisible()
 				return m, nil
 			case Keys.Top:
 				if m.lastKey == Keys.Top {
 					m.lastKey = ""
+					m.lineCursor = 0
 					m.scrollOffset = 0
 					return m, nil
 				}
@@ -192,20 +259,35 @@ func (m DiffModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			case Keys.Bottom:
 				if m.cursor < len(m.hunks) {
-					maxScroll := len(m.hunks[m.cursor].Lines) - m.visibleLines()
-					if maxScroll > 0 {
-						m.scrollOffset = maxScroll
-					}
+					m.lineCursor = max(len(m.hunks[m.cursor].Lines)-1, 0)
+					m.ensureLineCursorVisible()
 				}
 				return m, nil
+			case Keys.Visual:
+				if m.visualMode {
+					m.visualMode = false
+				} else {
+					m.visualMode = true
+					m.visualStart = m.lineCursor
+				}
+				return m, nil
+			case Keys.Split:
+				if m.splitHunk() {
+					m.enterHunkDetail()
+				}
+				return m, nil
+			case Keys.EditHunk:
+				return m, m.editHunk()
 			case " ":
-				return m, m.toggleStage()
+				return m, m.toggleStageLines()
 			case Keys.Stage:
+				// Stage and unstage keep acting on the whole hunk so that
+				// files opened straight into detail can still be staged at once
 				return m, m.stageHunk()
 			case Keys.Unstage:
 				return m, m.unstageHunk()
 			case Keys.Discard:
-				if len(m.hunks) > 0 && m.cursor < len(m.hunks) && !m.hunks[m.cursor].Staged {
+				if len(m.hunks) > 0 && m.cursor < len(m.hunks) && !m.hunks[m.cursor].Staged && len(m.selectedLines()) > 0 {
 					m.confirmMode = true
 				}
 				return m, nil
@@ -245,6 +327,23 @@ func (m DiffModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case Keys.Help:
 			m.showHelp = true
 			return m, nil
+		case "esc":
+			m.hunkVisualMode = false
+			return m, nil
+		case Keys.Visual:
+			if m.hunkVisualMode {
+				m.hunkVisualMode = false
+			} else if len(m.hunks) > 0 {
+				m.hunkVisualMode = true
+				m.hunkVisualStart = m.cursor
+			}
+			return m, nil
+		case Keys.StashHunks:
+			if len(m.hunks) > 0 && m.cursor < len(m.hunks) {
+				m.err = nil
+				m.stashConfirm = true
+			}
+			return m, nil
 		case Keys.FullDiff:
 			if len(m.hunks) > 0 {
 				m.viewingFullDiff = true
@@ -253,8 +352,8 @@ func (m DiffModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		case Keys.Right, "right", "enter":
 			if len(m.hunks) > 0 && m.cursor < len(m.hunks) {
-				m.viewingHunk = true
-				m.scrollOffset = 0
+				m.hunkVisualMode = false
+				m.enterHunkDetail()
 			}
 			return m, nil
 		case Keys.Down, "down":
@@ -275,6 +374,11 @@ func (m DiffModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.ensureHunkCursorVisible()
 			}
 			return m, nil
+		case Keys.Split:
+			m.splitHunk()
+			return m, nil
+		case Keys.EditHunk:
+			return m, m.editHunk()
 		case " ":
 			return m, m.toggleStage()
 		case Keys.Stage:
@@ -302,22 +406,54 @@ func (m DiffModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case combinedDiffMsg:
 		m.diff = msg.diff
-		newHunks := m.getFilteredHunks()
+		m.err = nil
+		var prev *git.Hunk
+		if m.viewingHunk && m.cursor < len(m.hunks) {
+			prev = &m.hunks[m.cursor]
+		}
+		newHunks := m.applySplits(m.getFilteredHunks())
 		if len(m.hunks) > 0 && len(newHunks) > 0 {
 			newHunks = m.keepHunkOrder(newHunks)
 		}
 		m.hunks = newHunks
+		if prev != nil {
+			// Stay on what remains of the hunk after staging some of its lines
+			if i := nearestHunk(m.hunks, *prev); i >= 0 {
+				m.cursor = i
+			}
+		}
 		if m.cursor >= len(m.hunks) {
 			m.cursor = max(0, len(m.hunks)-1)
 		}
 		m.ensureHunkCursorVisible()
+		m.visualMode = false
+		m.hunkVisualMode = false
+		if m.viewingHunk && m.cursor < len(m.hunks) {
+			m.lineCursor = min(m.lineCursor, max(len(m.hunks[m.cursor].Lines)-1, 0))
+			m.ensureLineCursorVisible()
+		}
 		// Auto-enter detail view when there's only one hunk
 		if len(m.hunks) == 1 && !m.viewingHunk {
-			m.viewingHunk = true
-			m.scrollOffset = 0
+			m.enterHunkDetail()
 		}
 		return m, nil
 
+	case hunkEditReadyMsg:
+		return m, stageEditedHunk(msg)
+
+	case commitDetailMsg:
+		m.commit = msg.detail
+		m.diff = &git.CombinedDiffResult{
+			StagedDiff:   &git.DiffResult{},
+			UnstagedDiff: msg.detail.Diff,
+		}
+		m.hunks = m.getFilteredHunks()
+		if m.cursor >= len(m.hunks) {
+			m.cursor = max(0, len(m.hunks)-1)
+		}
+		m.ensureHunkCursorVisible()
+		return m, nil
+
 	case errMsg:
 		m.err = msg.err
 		return m, nil
@@ -326,6 +462,163 @@ func (m DiffModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// splitHunk replaces the hunk under the cursor with its sub-hunks.
+// Returns false if the hunk can't be split.
+func (m *DiffModel) splitHunk() bool {
+	if m.cursor >= len(m.hunks) {
+		return false
+	}
+	subs := m.hunks[m.cursor].Split()
+	if len(subs) < 2 {
+		return false
+	}
+
+	if m.splitKeys == nil {
+		m.splitKeys = make(map[string]bool)
+	}
+	for _, sub := range subs {
+		m.splitKeys[hunkStableKey(sub)] = true
+	}
+
+	hunks := make([]git.Hunk, 0, len(m.hunks)+len(subs)-1)
+	hunks = append(hunks, m.hunks[:m.cursor]...)
+	hunks = append(hunks, subs...)
+	hunks = append(hunks, m.hunks[m.cursor+1:]...)
+	m.hunks = hunks
+	m.ensureHunkCursorVisible()
+	return true
+}
+
+// applySplits splits refreshed hunks that contain a sub-hunk the user
+// split off before, so splits survive staging part of a hunk
+func (m DiffModel) applySplits(hunks []git.Hunk) []git.Hunk {
+	if len(m.splitKeys) == 0 {
+		return hunks
+	}
+	var result []git.Hunk
+	for _, hunk := range hunks {
+		subs := hunk.Split()
+		split := false
+		for _, sub := range subs {
+			if len(subs) > 1 && m.splitKeys[hunkStableKey(sub)] {
+				split = true
+				break
+			}
+		}
+		if split {
+			result = append(result, subs...)
+		} else {
+			result = append(result, hunk)
+		}
+	}
+	return result
+}
+
+// enterHunkDetail drills into the hunk under the cursor, placing the
+// line cursor on its first change
+func (m *DiffModel) enterHunkDetail() {
+	m.viewingHunk = true
+	m.scrollOffset = 0
+	m.lineCursor = 0
+	m.visualMode = false
+	if m.cursor < len(m.hunks) {
+		for i, line := range m.hunks[m.cursor].Lines {
+			if line.Type == git.LineAdded || line.Type == git.LineRemoved {
+				m.lineCursor = i
+				break
+			}
+		}
+	}
+	m.ensureLineCursorVisible()
+}
+
+func (m *DiffModel) leaveHunkDetail() {
+	m.viewingHunk = false
+	m.visualMode = false
+	m.scrollOffset = 0
+	m.lineCursor = 0
+}
+
+// ensureLineCursorVisible adjusts scrollOffset to keep the line cursor in view
+func (m *DiffModel) ensureLineCursorVisible() {
+	visible := m.visibleLines()
+	if m.lineCursor < m.scrollOffset {
+		m.scrollOffset = m.lineCursor
+	}
+	if m.lineCursor >= m.scrollOffset+visible {
+		m.scrollOffset = m.lineCursor - visible + 1
+	}
+	m.scrollOffset = max(m.scrollOffset, 0)
+}
+
+// selectionRange returns the first and last hunk lines covered by the
+// visual selection, or the line under the cursor
+func (m DiffModel) selectionRange() (int, int) {
+	if !m.visualMode {
+		return m.lineCursor, m.lineCursor
+	}
+	return min(m.visualStart, m.lineCursor), max(m.visualStart, m.lineCursor)
+}
+
+// hunkSelectionRange returns the range of hunks in the visual selection of
+// the hunk list, or the hunk under the cursor
+func (m DiffModel) hunkSelectionRange() (int, int) {
+	if !m.hunkVisualMode {
+		return m.cursor, m.cursor
+	}
+	return min(m.hunkVisualStart, m.cursor), max(m.hunkVisualStart, m.cursor)
+}
+
+// inHunkSelection returns true while hunks are selected or about to be
+// stashed in the hunk list, where esc cancels instead of going back
+func (m DiffModel) inHunkSelection() bool {
+	return m.hunkVisualMode || m.stashConfirm
+}
+
+// selectedLines returns the added and removed lines in the current selection
+func (m DiffModel) selectedLines() map[int]bool {
+	if m.cursor >= len(m.hunks) {
+		return nil
+	}
+	lines := m.hunks[m.cursor].Lines
+	start, end := m.selectionRange()
+	selected := make(map[int]bool)
+	for i := start; i <= end && i < len(lines); i++ {
+		if lines[i].Type == git.LineAdded || lines[i].Type == git.LineRemoved {
+			selected[i] = true
+		}
+	}
+	return selected
+}
+
+// nearestHunk finds the hunk covering the same region of the same file as
+// prev, returning -1 if there is none. Positions are compared on the side
+// of the diff that staging doesn't change (the worktree for unstaged
+// hunks, HEAD for staged ones).
+func nearestHunk(hunks []git.Hunk, prev git.Hunk) int {
+	best, bestDist := -1, 0
+	for i, h := range hunks {
+		if h.FilePath != prev.FilePath || h.Staged != prev.Staged {
+			continue
+		}
+		if hunkStableKey(h) == hunkStableKey(prev) {
+			return i
+		}
+		start, prevStart := h.StartNew, prev.StartNew
+		if h.Staged {
+			start, prevStart = h.StartOld, prev.StartOld
+		}
+		dist := start - prevStart
+		if dist < 0 {
+			dist = -dist
+		}
+		if best < 0 || dist < bestDist {
+			best, bestDist = i, dist
+		}
+	}
+	return best
+}
+
 func (m DiffModel) keepHunkOrder(newHunks []git.Hunk) []git.Hunk {
 	indexByKey := make(map[string][]int, len(newHunks))
 	for i, hunk := range newHunks {
@@ -578,6 +871,116 @@ func (m DiffModel) unstageHunk() tea.Cmd {
 	}
 }
 
+// toggleStageLines stages or unstages the selected lines of the hunk
+// shown in the detail view
+func (m DiffModel) toggleStageLines() tea.Cmd {
+	if len(m.hunks) == 0 || m.cursor >= len(m.hunks) {
+		return nil
+	}
+
+	hunk := m.hunks[m.cursor]
+	fileDiff := m.diff.GetFileDiff(&hunk)
+	selected := m.selectedLines()
+	if fileDiff == nil || len(selected) == 0 {
+		return nil
+	}
+
+	return func() tea.Msg {
+		// Staged hunks are applied in reverse, so the patch keeps unselected lines as they are in the index
+		patch := hunk.GeneratePartialPatch(fileDiff, selected, hunk.Staged)
+		var err error
+		if hunk.Staged {
+			err = git.UnstageHunk(patch)
+		} else {
+			err = git.StageHunk(patch)
+		}
+		if err != nil {
+			return errMsg{err}
+		}
+
+		diff, err := git.GetCombinedDiff()
+		if err != nil {
+			return errMsg{err}
+		}
+		return combinedDiffMsg{diff}
+	}
+}
+
+// hunkEditGuide is appended to a hunk opened for editing (like git add -p)
+const hunkEditGuide = `# ---
+# To remove '-' lines, make them ' ' lines (context).
+# To remove '+' lines, delete them.
+# Lines starting with # will be removed.
+# If the patch applies cleanly, the edited hunk will be staged.
+# To abort, delete all lines.
+`
+
+// editHunk opens the patch for an unstaged hunk in $EDITOR and stages
+// the edited result
+func (m DiffModel) editHunk() tea.Cmd {
+	if len(m.hunks) == 0 || m.cursor >= len(m.hunks) {
+		return nil
+	}
+
+	hunk := m.hunks[m.cursor]
+	if hunk.Staged {
+		return nil
+	}
+	fileDiff := m.diff.GetFileDiff(&hunk)
+	if fileDiff == nil {
+		return nil
+	}
+
+	patch := hunk.GeneratePatch(fileDiff) + hunkEditGuide
+	return func() tea.Msg {
+		f, err := os.CreateTemp("", "go-on-git-hunk-*.diff")
+		if err != nil {
+			return errMsg{err}
+		}
+		path := f.Name()
+		_, err = f.WriteString(patch)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(path)
+			return errMsg{err}
+		}
+		return hunkEditReadyMsg{path: path, cmd: editorCommand(path, 0)}
+	}
+}
+
+// hunkEditReadyMsg carries the temp file holding a hunk patch, and the
+// editor command to open it with
+type hunkEditReadyMsg struct {
+	path string
+	cmd  *exec.Cmd
+}
+
+// stageEditedHunk runs the editor on a prepared hunk patch and stages
+// the edited result
+func stageEditedHunk(msg hunkEditReadyMsg) tea.Cmd {
+	return tea.ExecProcess(msg.cmd, func(err error) tea.Msg {
+		defer os.Remove(msg.path)
+		if err != nil {
+			return errMsg{err}
+		}
+		edited, err := os.ReadFile(msg.path)
+		if err != nil {
+			return errMsg{err}
+		}
+		if err := git.StageEditedHunk(string(edited)); err != nil {
+			return errMsg{err}
+		}
+
+		diff, err := git.GetCombinedDiff()
+		if err != nil {
+			return errMsg{err}
+		}
+		return combinedDiffMsg{diff}
+	})
+}
+
 func (m DiffModel) doDiscard() tea.Cmd {
 	if len(m.hunks) == 0 || m.cursor >= len(m.hunks) {
 		return nil
@@ -594,8 +997,20 @@ func (m DiffModel) doDiscard() tea.Cmd {
 		return nil
 	}
 
+	// In the detail view only the selected lines are discarded
+	var selected map[int]bool
+	if m.viewingHunk {
+		selected = m.selectedLines()
+		if len(selected) == 0 {
+			return nil
+		}
+	}
+
 	return func() tea.Msg {
 		patch := hunk.GeneratePatch(fileDiff)
+		if selected != nil {
+			patch = hunk.GeneratePartialPatch(fileDiff, selected, true)
+		}
 		err := git.DiscardHunk(patch)
 		if err != nil {
 			return errMsg{err}
@@ -609,6 +1024,46 @@ func (m DiffModel) doDiscard() tea.Cmd {
 	}
 }
 
+// stashHunks stashes the selected hunks, leaving the index and the other
+// hunks alone
+func (m DiffModel) stashHunks() tea.Cmd {
+	start, end := m.hunkSelectionRange()
+	var patches []string
+	for i := start; i <= end && i < len(m.hunks); i++ {
+		hunk := m.hunks[i]
+		if hunk.Staged {
+			return func() tea.Msg {
+				return errMsg{fmt.Errorf("only unstaged hunks can be stashed, unstage the selected hunks first")}
+			}
+		}
+		// Untracked files have no diff against the index to stash
+		if hunk.Untracked {
+			continue
+		}
+		fileDiff := m.diff.GetFileDiff(&hunk)
+		if fileDiff == nil {
+			continue
+		}
+		patches = append(patches, hunk.GeneratePatch(fileDiff))
+	}
+	if len(patches) == 0 {
+		return func() tea.Msg {
+			return errMsg{fmt.Errorf("untracked files can't be stashed by hunk, stash them from the status view")}
+		}
+	}
+
+	return func() tea.Msg {
+		if err := git.StashHunks(patches, ""); err != nil {
+			return errMsg{err}
+		}
+		diff, err := git.GetCombinedDiff()
+		if err != nil {
+			return errMsg{err}
+		}
+		return combinedDiffMsg{diff}
+	}
+}
+
 // View renders the model
 func (m DiffModel) View() string {
 	var sb strings.Builder
@@ -629,6 +1084,10 @@ func (m DiffModel) View() string {
 	}
 
 	if len(m.hunks) == 0 {
+		for _, line := range m.commitHeader(false) {
+			sb.WriteString(line)
+			sb.WriteString("\n")
+		}
 		sb.WriteString(StyleEmpty.Render("No changes"))
 		sb.WriteString("\n")
 		return sb.String()
@@ -661,6 +1120,21 @@ func (m DiffModel) View() string {
 		fixedLines++ // "↓ N more below"
 	}
 
+	// Commit message and diffstat summary go above the preview
+	header := m.commitHeader(false)
+	if len(header) > 0 {
+		maxHeader := max(m.height/3, 4)
+		if len(header) > maxHeader {
+			header = append(header[:maxHeader-1], StyleMuted.Render(fmt.Sprintf("... (%s for full message and diffstat)", Keys.FullDiff)))
+		}
+		for _, line := range header {
+			sb.WriteString(line)
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+		fixedLines += len(header) + 1
+	}
+
 	// Calculate available lines for preview (use remaining space)
 	availableForDetail := 50
 	if m.height > fixedLines+5 {
@@ -671,7 +1145,7 @@ func (m DiffModel) View() string {
 	if m.cursor < len(m.hunks) && availableForDetail > 0 {
 		hunk := m.hunks[m.cursor]
 
-		sb.WriteString(fmt.Sprintf("─── %s %s ───", renderStageLabel(hunk.Staged), hunk.Header))
+		sb.WriteString(fmt.Sprintf("─── %s %s ───", m.renderHunkLabel(hunk), hunk.Header))
 		sb.WriteString("\n")
 
 		totalLines := len(hunk.Lines)
@@ -680,13 +1154,14 @@ func (m DiffModel) View() string {
 		for i := 0; i < showLines; i++ {
 			line := hunk.Lines[i]
 			var styled string
+			content := displayLine(hunk, line)
 			switch line.Type {
 			case git.LineAdded:
-				styled = StyleDiffAdded.Render(line.Content)
+				styled = StyleDiffAdded.Render(content)
 			case git.LineRemoved:
-				styled = StyleDiffRemoved.Render(line.Content)
+				styled = StyleDiffRemoved.Render(content)
 			default:
-				styled = StyleDiffContext.Render(line.Content)
+				styled = StyleDiffContext.Render(content)
 			}
 			sb.WriteString(styled)
 			sb.WriteString("\n")
@@ -707,6 +1182,7 @@ func (m DiffModel) View() string {
 	}
 
 	// Show hunk list at the bottom (only visible items)
+	selStart, selEnd := m.hunkSelectionRange()
 	for i := visibleStart; i < visibleEnd; i++ {
 		h := m.hunks[i]
 		cursor := "  "
@@ -720,6 +1196,10 @@ func (m DiffModel) View() string {
 			stageLabel = "[S]"
 			stageStyle = StyleHunkHeaderStaged
 		}
+		if m.isCommitView() {
+			stageLabel = "[C]"
+			stageStyle = StyleCommitHash
+		}
 
 		adds, dels := 0, 0
 		for _, line := range h.Lines {
@@ -731,9 +1211,14 @@ func (m DiffModel) View() string {
 			}
 		}
 
+		label := fmt.Sprintf(" @@ %s +%d -%d", h.DisplayFilePath, adds, dels)
+		if m.hunkVisualMode && i >= selStart && i <= selEnd {
+			label = StyleVisual.Render(label)
+		}
+
 		sb.WriteString(cursor)
 		sb.WriteString(stageStyle.Render(stageLabel))
-		sb.WriteString(fmt.Sprintf(" @@ %s +%d -%d", h.DisplayFilePath, adds, dels))
+		sb.WriteString(label)
 		sb.WriteString("\n")
 	}
 
@@ -743,12 +1228,25 @@ func (m DiffModel) View() string {
 		sb.WriteString("\n")
 	}
 
+	if m.hunkVisualMode {
+		sb.WriteString(StyleVisual.Render("-- VISUAL --"))
+		sb.WriteString("\n")
+	}
+
 	// Confirm prompt
 	if m.confirmMode {
 		sb.WriteString("\n")
 		hunk := m.hunks[m.cursor]
 		sb.WriteString(StyleConfirm.Render(fmt.Sprintf("Discard hunk from '%s'? Type 'yes' to confirm: %s", hunk.DisplayFilePath, m.confirmInput)))
 	}
+	if m.stashConfirm {
+		sb.WriteString("\n")
+		if selStart == selEnd {
+			sb.WriteString(StyleConfirm.Render(fmt.Sprintf("Stash hunk from '%s'? (y/n) ", m.hunks[selStart].DisplayFilePath)))
+		} else {
+			sb.WriteString(StyleConfirm.Render(fmt.Sprintf("Stash %d hunks? (y/n) ", selEnd-selStart+1)))
+		}
+	}
 
 	return m.anchorBottom(sb.String())
 }
@@ -762,23 +1260,37 @@ func (m DiffModel) renderHunkDetail() string {
 	totalLines := len(hunk.Lines)
 	visible := m.visibleLines()
 	endLine := min(m.scrollOffset+visible, totalLines)
+	selStart, selEnd := m.selectionRange()
 	for i := m.scrollOffset; i < endLine; i++ {
 		line := hunk.Lines[i]
-		var styled string
+		style := StyleDiffContext
 		switch line.Type {
 		case git.LineAdded:
-			styled = StyleDiffAdded.Render(line.Content)
+			style = StyleDiffAdded
 		case git.LineRemoved:
-			styled = StyleDiffRemoved.Render(line.Content)
-		default:
-			styled = StyleDiffContext.Render(line.Content)
+			style = StyleDiffRemoved
+		}
+		switch {
+		case m.visualMode && i >= selStart && i <= selEnd:
+			style = style.Inherit(StyleVisual)
+		case i == m.lineCursor && !m.isCommitView():
+			style = style.Inherit(StyleSelected)
 		}
-		sb.WriteString(styled)
+		content := displayLine(hunk, line)
+		if content == "" && i == m.lineCursor {
+			content = " " // keep the cursor visible on empty lines
+		}
+		sb.WriteString(style.Render(content))
 		sb.WriteString("\n")
 	}
 
 	sb.WriteString("\n")
 
+	if m.visualMode {
+		sb.WriteString(StyleVisual.Render("-- VISUAL --"))
+		sb.WriteString("\n")
+	}
+
 	// Show scroll position if scrollable
 	if totalLines > visible {
 		sb.WriteString(StyleMuted.Render(fmt.Sprintf("Lines %d-%d of %d", m.scrollOffset+1, min(m.scrollOffset+visible, totalLines), totalLines)))
@@ -786,12 +1298,12 @@ func (m DiffModel) renderHunkDetail() string {
 	}
 
 	// Header with file info and navigation hint (at bottom)
-	sb.WriteString(fmt.Sprintf("─── %s %s %s ───", renderStageLabel(hunk.Staged), hunk.DisplayFilePath, hunk.Header))
+	sb.WriteString(fmt.Sprintf("─── %s %s %s ───", m.renderHunkLabel(hunk), hunk.DisplayFilePath, hunk.Header))
 	sb.WriteString("\n")
 
 	// Confirm prompt (only shown when confirming)
 	if m.confirmMode {
-		sb.WriteString(StyleConfirm.Render(fmt.Sprintf("Discard hunk from '%s'? Type 'yes' to confirm: %s", hunk.DisplayFilePath, m.confirmInput)))
+		sb.WriteString(StyleConfirm.Render(fmt.Sprintf("Discard selected lines from '%s'? Type 'yes' to confirm: %s", hunk.DisplayFilePath, m.confirmInput)))
 	}
 
 	return sb.String()
@@ -799,7 +1311,7 @@ func (m DiffModel) renderHunkDetail() string {
 
 // fullDiffTotalLines returns the total number of lines in the full diff view
 func (m DiffModel) fullDiffTotalLines() int {
-	total := 0
+	total := len(m.commitHeader(true))
 	for _, h := range m.hunks {
 		// File header line + hunk header line + all hunk lines + blank line
 		total += 2 + len(h.Lines) + 1
@@ -811,19 +1323,23 @@ func (m DiffModel) fullDiffTotalLines() int {
 func (m DiffModel) renderFullDiff() string {
 	var sb strings.Builder
 
-	// Build full diff content
-	var lines []string
+	// Build full diff content (like git show for commits)
+	lines := m.commitHeader(true)
 	lastFilePath := ""
 	for _, h := range m.hunks {
 		// Add file header when file changes
 		if h.FilePath != lastFilePath {
-			stageLabel := "[Unstaged]"
-			stageStyle := StyleHunkHeaderUnstaged
-			if h.Staged {
-				stageLabel = "[Staged]"
-				stageStyle = StyleHunkHeaderStaged
+			if m.isCommitView() {
+				lines = append(lines, StyleDiffHeader.Render(h.DisplayFilePath))
+			} else {
+				stageLabel := "[Unstaged]"
+				stageStyle := StyleHunkHeaderUnstaged
+				if h.Staged {
+					stageLabel = "[Staged]"
+					stageStyle = StyleHunkHeaderStaged
+				}
+				lines = append(lines, stageStyle.Render(stageLabel)+" "+h.DisplayFilePath)
 			}
-			lines = append(lines, stageStyle.Render(stageLabel)+" "+h.DisplayFilePath)
 			lastFilePath = h.FilePath
 		}
 
@@ -833,13 +1349,14 @@ func (m DiffModel) renderFullDiff() string {
 		// Add hunk lines
 		for _, line := range h.Lines {
 			var styled string
+			content := displayLine(h, line)
 			switch line.Type {
 			case git.LineAdded:
-				styled = StyleDiffAdded.Render(line.Content)
+				styled = StyleDiffAdded.Render(content)
 			case git.LineRemoved:
-				styled = StyleDiffRemoved.Render(line.Content)
+				styled = StyleDiffRemoved.Render(content)
 			default:
-				styled = StyleDiffContext.Render(line.Content)
+				styled = StyleDiffContext.Render(content)
 			}
 			lines = append(lines, styled)
 		}
@@ -884,23 +1401,34 @@ func (m DiffModel) renderHelp() string {
 	moveKeys := formatKeyList(Keys.Down, Keys.Up, "↓", "↑")
 	topKey := formatDoubleKey(Keys.Top)
 
-	help := []struct {
+	type helpItem struct {
 		key  string
 		desc string
-	}{
+	}
+	help := []helpItem{
 		{drillKeys, "View hunk detail (scrollable)"},
 		{Keys.FullDiff, "Toggle full diff view"},
 		{backKeys, "Go back"},
 		{moveKeys, "Navigate / scroll"},
 		{topKey, "Go to top"},
 		{Keys.Bottom, "Go to bottom"},
-		{"SPACE", "Toggle stage/unstage hunk"},
-		{Keys.Stage, "Stage hunk"},
-		{Keys.Unstage, "Unstage hunk"},
-		{Keys.Discard, "Discard hunk (unstaged only)"},
-		{Keys.Help, "Toggle help"},
-		{Keys.Quit, "Quit"},
 	}
+	if !m.isCommitView() {
+		help = append(help,
+			helpItem{"SPACE", "Toggle stage/unstage hunk (selected lines in hunk detail)"},
+			helpItem{Keys.Stage, "Stage whole hunk"},
+			helpItem{Keys.Unstage, "Unstage whole hunk"},
+			helpItem{Keys.Discard, "Discard hunk (selected lines in hunk detail, unstaged only)"},
+			helpItem{Keys.Visual, "Select hunks (lines in hunk detail)"},
+			helpItem{Keys.StashHunks, "Stash selected hunks (unstaged only)"},
+			helpItem{Keys.Split, "Split hunk into smaller hunks"},
+			helpItem{Keys.EditHunk, "Edit hunk in $EDITOR, then stage it"},
+		)
+	}
+	help = append(help,
+		helpItem{Keys.Help, "Toggle help"},
+		helpItem{Keys.Quit, "Quit"},
+	)
 
 	for _, h := range help {
 		sb.WriteString(fmt.Sprintf("  %s  %s\n",
@@ -911,6 +1439,76 @@ func (m DiffModel) renderHelp() string {
 	return sb.String()
 }
 
+// renderHunkLabel labels a hunk with its stage state, or the commit it belongs to
+func (m DiffModel) renderHunkLabel(hunk git.Hunk) string {
+	if m.isCommitView() && m.commit != nil {
+		return StyleCommitHash.Render("[" + m.commit.Commit.ShortHash + "]")
+	}
+	return renderStageLabel(hunk.Staged)
+}
+
+// commitHeader returns the commit metadata and message (like git show).
+// When full is false, the diffstat is reduced to its summary line.
+func (m DiffModel) commitHeader(full bool) []string {
+	if m.commit == nil {
+		return nil
+	}
+	c := m.commit.Commit
+
+	var lines []string
+	hashLine := StyleCommitHash.Render("commit " + c.Hash)
+	if refs := renderRefs(c.Refs); refs != "" {
+		hashLine += " " + refs
+	}
+	lines = append(lines, hashLine)
+	if c.IsMerge() {
+		short := make([]string, len(c.Parents))
+		for i, p := range c.Parents {
+			short[i] = p[:min(7, len(p))]
+		}
+		lines = append(lines, StyleMuted.Render("Merge:  "+strings.Join(short, " ")))
+	}
+	lines = append(lines, StyleMuted.Render(fmt.Sprintf("Author: %s <%s>", c.AuthorName, c.AuthorEmail)))
+	lines = append(lines, StyleMuted.Render("Date:   "+c.AuthorDate.Format("Mon Jan 2 15:04:05 2006 -0700")))
+	lines = append(lines, "")
+	for _, line := range strings.Split(c.Message(), "\n") {
+		lines = append(lines, "    "+line)
+	}
+
+	if m.commit.Stat != "" {
+		lines = append(lines, "")
+		stat := strings.Split(m.commit.Stat, "\n")
+		if !full {
+			stat = stat[len(stat)-1:]
+		}
+		for _, line := range stat {
+			lines = append(lines, StyleMuted.Render(line))
+		}
+	}
+	if full {
+		lines = append(lines, "")
+	}
+
+	return lines
+}
+
+// displayLine returns the text shown for a diff line. The "Subproject
+// commit" lines of a submodule hunk are shortened to the commit they point to.
+func displayLine(hunk git.Hunk, line git.DiffLine) string {
+	if !hunk.Submodule {
+		return line.Content
+	}
+	hash, dirty, ok := git.ParseSubprojectLine(line.Content)
+	if !ok {
+		return line.Content
+	}
+	content := line.Content[:1] + "submodule at " + git.ShortHash(hash)
+	if dirty {
+		content += " (with uncommitted changes)"
+	}
+	return content
+}
+
 func renderStageLabel(staged bool) string {
 	if staged {
 		return StyleHunkHeaderStaged.Render("[Staged]")
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"os"
 	"strings"
 	"testing"
 
@@ -179,30 +180,42 @@ func TestDiffModelHunkDetailNavigation(t *testing.T) {
 	}
 	m.viewingHunk = true
 
-	// Test scroll down
+	// Test line cursor down
 	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
 	m = newModel.(DiffModel)
-	if m.scrollOffset != 1 {
-		t.Errorf("scrollOffset = %d, want 1", m.scrollOffset)
+	if m.lineCursor != 1 {
+		t.Errorf("lineCursor = %d, want 1", m.lineCursor)
 	}
 
-	// Test scroll up
+	// Test line cursor up
 	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
 	m = newModel.(DiffModel)
-	if m.scrollOffset != 0 {
-		t.Errorf("scrollOffset = %d, want 0", m.scrollOffset)
+	if m.lineCursor != 0 {
+		t.Errorf("lineCursor = %d, want 0", m.lineCursor)
 	}
 
-	// Test can't scroll past top
+	// Test can't move past top
 	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
 	m = newModel.(DiffModel)
-	if m.scrollOffset != 0 {
-		t.Errorf("scrollOffset should stay at 0, got %d", m.scrollOffset)
+	if m.lineCursor != 0 {
+		t.Errorf("lineCursor should stay at 0, got %d", m.lineCursor)
+	}
+
+	// Scrolling follows the cursor past the visible lines
+	for i := 0; i < m.visibleLines(); i++ {
+		newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+		m = newModel.(DiffModel)
+	}
+	if m.scrollOffset != 1 {
+		t.Errorf("scrollOffset = %d, want 1", m.scrollOffset)
 	}
 
 	// Test jump to bottom (G)
 	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'G'}})
 	m = newModel.(DiffModel)
+	if m.lineCursor != 19 {
+		t.Errorf("after 'G', lineCursor = %d, want 19", m.lineCursor)
+	}
 	if m.scrollOffset == 0 {
 		t.Error("scrollOffset should be > 0 after 'G'")
 	}
@@ -212,8 +225,149 @@ func TestDiffModelHunkDetailNavigation(t *testing.T) {
 	m = newModel.(DiffModel)
 	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
 	m = newModel.(DiffModel)
-	if m.scrollOffset != 0 {
-		t.Errorf("after 'gg', scrollOffset = %d, want 0", m.scrollOffset)
+	if m.scrollOffset != 0 || m.lineCursor != 0 {
+		t.Errorf("after 'gg', scrollOffset = %d, lineCursor = %d, want 0", m.scrollOffset, m.lineCursor)
+	}
+}
+
+func TestDiffModelEnterHunkDetailCursorOnFirstChange(t *testing.T) {
+	m := NewDiffModel(nil)
+	m.hunks = []git.Hunk{
+		{
+			FilePath: "file1.txt",
+			Lines: []git.DiffLine{
+				{Content: " context", Type: git.LineContext},
+				{Content: " context", Type: git.LineContext},
+				{Content: "+added", Type: git.LineAdded},
+			},
+		},
+	}
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	m = newModel.(DiffModel)
+	if m.lineCursor != 2 {
+		t.Errorf("lineCursor = %d, want 2", m.lineCursor)
+	}
+}
+
+func TestDiffModelHunkDetailVisualSelection(t *testing.T) {
+	m := NewDiffModel(nil)
+	m.diff = &git.CombinedDiffResult{}
+	m.hunks = []git.Hunk{
+		{
+			FilePath: "file1.txt",
+			Lines: []git.DiffLine{
+				{Content: " context", Type: git.LineContext},
+				{Content: "-removed", Type: git.LineRemoved},
+				{Content: "+added", Type: git.LineAdded},
+				{Content: "+more", Type: git.LineAdded},
+			},
+		},
+	}
+	m.viewingHunk = true
+
+	// Cursor on a context line selects nothing
+	if got := len(m.selectedLines()); got != 0 {
+		t.Errorf("selectedLines() on context = %d lines, want 0", got)
+	}
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	m = newModel.(DiffModel)
+	if !m.visualMode {
+		t.Fatal("should be in visual mode after 'v'")
+	}
+	for i := 0; i < 2; i++ {
+		newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+		m = newModel.(DiffModel)
+	}
+
+	selected := m.selectedLines()
+	if len(selected) != 2 || !selected[1] || !selected[2] {
+		t.Errorf("selectedLines() = %v, want lines 1 and 2", selected)
+	}
+	if !strings.Contains(m.View(), "-- VISUAL --") {
+		t.Error("view should show visual mode indicator")
+	}
+
+	// ESC leaves visual mode but stays in the hunk
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(DiffModel)
+	if m.visualMode {
+		t.Error("ESC should exit visual mode")
+	}
+	if !m.viewingHunk {
+		t.Error("ESC in visual mode should stay in the hunk detail view")
+	}
+	if got := len(m.selectedLines()); got != 1 {
+		t.Errorf("selectedLines() = %d lines, want just the cursor line", got)
+	}
+}
+
+func TestDiffModelHunkDetailStageNothingSelected(t *testing.T) {
+	m := NewDiffModel(nil)
+	m.diff = &git.CombinedDiffResult{UnstagedDiff: &git.DiffResult{Files: []git.FileDiff{{Path: "file1.txt"}}}}
+	m.hunks = []git.Hunk{
+		{
+			FilePath: "file1.txt",
+			Lines:    []git.DiffLine{{Content: " context", Type: git.LineContext}},
+		},
+	}
+	m.viewingHunk = true
+
+	for _, key := range []string{" ", Keys.Discard} {
+		newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		m = newModel.(DiffModel)
+		if cmd != nil {
+			t.Errorf("key %q should do nothing on a context line", key)
+		}
+		if m.confirmMode {
+			t.Errorf("key %q should not ask to discard a context line", key)
+		}
+	}
+}
+
+func TestDiffModelHunkDetailStagesWholeHunk(t *testing.T) {
+	m := NewDiffModel(nil)
+	m.diff = &git.CombinedDiffResult{UnstagedDiff: &git.DiffResult{Files: []git.FileDiff{{Path: "file1.txt"}}}}
+	m.hunks = []git.Hunk{
+		{
+			FilePath: "file1.txt",
+			Lines: []git.DiffLine{
+				{Content: " context", Type: git.LineContext},
+				{Content: "+added", Type: git.LineAdded},
+			},
+		},
+	}
+	m.viewingHunk = true
+
+	// The cursor sits on a context line, so only a whole-hunk stage has work to do
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(Keys.Stage)})
+	if cmd == nil {
+		t.Error("stage key in hunk detail should stage the whole hunk")
+	}
+
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(Keys.Unstage)})
+	if cmd != nil {
+		t.Error("unstage key should do nothing on an unstaged hunk")
+	}
+}
+
+func TestNearestHunk(t *testing.T) {
+	hunks := []git.Hunk{
+		{FilePath: "a.txt", StartNew: 1, Lines: []git.DiffLine{{Content: "+a", Type: git.LineAdded}}},
+		{FilePath: "b.txt", StartNew: 10, Lines: []git.DiffLine{{Content: "+b", Type: git.LineAdded}}},
+		{FilePath: "b.txt", StartNew: 40, Lines: []git.DiffLine{{Content: "+c", Type: git.LineAdded}}},
+		{FilePath: "b.txt", StartNew: 10, Staged: true, Lines: []git.DiffLine{{Content: "+d", Type: git.LineAdded}}},
+	}
+
+	prev := git.Hunk{FilePath: "b.txt", StartNew: 12, Lines: []git.DiffLine{{Content: "+x", Type: git.LineAdded}}}
+	if got := nearestHunk(hunks, prev); got != 1 {
+		t.Errorf("nearestHunk() = %d, want 1", got)
+	}
+
+	prev = git.Hunk{FilePath: "c.txt"}
+	if got := nearestHunk(hunks, prev); got != -1 {
+		t.Errorf("nearestHunk() = %d, want -1", got)
 	}
 }
 
@@ -270,6 +424,85 @@ func TestDiffModelConfirmModeNotForStaged(t *testing.T) {
 	}
 }
 
+func TestDiffModelHunkListVisualSelection(t *testing.T) {
+	m := NewDiffModel(nil)
+	m.diff = &git.CombinedDiffResult{}
+	m.hunks = []git.Hunk{
+		{FilePath: "file1.txt", DisplayFilePath: "file1.txt"},
+		{FilePath: "file2.txt", DisplayFilePath: "file2.txt"},
+		{FilePath: "file3.txt", DisplayFilePath: "file3.txt"},
+	}
+	m.cursor = 2
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	m = newModel.(DiffModel)
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
+	m = newModel.(DiffModel)
+	if !m.hunkVisualMode || !m.inHunkSelection() {
+		t.Fatal("v should start selecting hunks")
+	}
+	if start, end := m.hunkSelectionRange(); start != 1 || end != 2 {
+		t.Errorf("hunkSelectionRange() = %d, %d, want 1, 2", start, end)
+	}
+	if !strings.Contains(m.View(), "-- VISUAL --") {
+		t.Error("view should show the visual mode")
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'S'}})
+	m = newModel.(DiffModel)
+	if !m.stashConfirm {
+		t.Fatal("S should ask to confirm the stash")
+	}
+	if view := m.View(); !strings.Contains(view, "Stash 2 hunks? (y/n)") {
+		t.Errorf("view should show the stash prompt, got:\n%s", view)
+	}
+
+	// n cancels the stash but keeps the selection, esc ends it
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	m = newModel.(DiffModel)
+	if m.stashConfirm || cmd != nil || !m.hunkVisualMode {
+		t.Error("n should cancel the stash")
+	}
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(DiffModel)
+	if m.hunkVisualMode {
+		t.Error("esc should end the selection")
+	}
+}
+
+func TestDiffModelStashHunksNotForStaged(t *testing.T) {
+	m := NewDiffModel(nil)
+	m.diff = &git.CombinedDiffResult{StagedDiff: &git.DiffResult{Files: []git.FileDiff{{Path: "file1.txt"}}}}
+	m.hunks = []git.Hunk{{FilePath: "file1.txt", Staged: true}}
+	m.stashConfirm = true
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if cmd == nil {
+		t.Fatal("y should run the stash")
+	}
+	newModel, _ = newModel.(DiffModel).Update(cmd())
+	if err := newModel.(DiffModel).err; err == nil || !strings.Contains(err.Error(), "only unstaged hunks") {
+		t.Errorf("stashing a staged hunk should fail, got %v", err)
+	}
+}
+
+func TestDiffModelStashHunksNotForUntracked(t *testing.T) {
+	m := NewDiffModel(nil)
+	// The untracked hunk shares its path and index with the unstaged file
+	m.diff = &git.CombinedDiffResult{UnstagedDiff: &git.DiffResult{Files: []git.FileDiff{{Path: "file1.txt"}}}}
+	m.hunks = []git.Hunk{{FilePath: "file1.txt", Untracked: true}}
+	m.stashConfirm = true
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if cmd == nil {
+		t.Fatal("y should run the stash")
+	}
+	newModel, _ = newModel.(DiffModel).Update(cmd())
+	if err := newModel.(DiffModel).err; err == nil || !strings.Contains(err.Error(), "untracked files") {
+		t.Errorf("stashing an untracked hunk should fail, got %v", err)
+	}
+}
+
 func TestDiffModelQuit(t *testing.T) {
 	m := NewDiffModel(nil)
 
@@ -413,6 +646,35 @@ func TestDiffModelView(t *testing.T) {
 	}
 }
 
+func TestDiffModelViewSubmoduleHunk(t *testing.T) {
+	m := NewDiffModel(nil)
+	m.diff = &git.CombinedDiffResult{}
+	m.hunks = []git.Hunk{
+		{
+			FilePath:        "lib",
+			DisplayFilePath: "lib",
+			Header:          "@@ -1 +1 @@",
+			Submodule:       true,
+			Lines: []git.DiffLine{
+				{Content: "-Subproject commit 1111111111111111111111111111111111111111", Type: git.LineRemoved},
+				{Content: "+Subproject commit 2222222222222222222222222222222222222222-dirty", Type: git.LineAdded},
+			},
+		},
+	}
+
+	view := m.View()
+
+	if strings.Contains(view, "Subproject commit") {
+		t.Error("view should not show the raw submodule lines")
+	}
+	if !strings.Contains(view, "-submodule at 1111111") {
+		t.Error("view should show the old submodule commit")
+	}
+	if !strings.Contains(view, "+submodule at 2222222 (with uncommitted changes)") {
+		t.Error("view should show the new submodule commit and that it is dirty")
+	}
+}
+
 func TestDiffModelViewLoading(t *testing.T) {
 	m := NewDiffModel(nil)
 	m.diff = nil
@@ -646,3 +908,238 @@ func TestDiffModelAnchorBottom(t *testing.T) {
 		t.Error("anchored content should have leading newlines")
 	}
 }
+
+func testCommitDetail() *git.CommitDetail {
+	return &git.CommitDetail{
+		Commit: git.CommitInfo{
+			Hash:        "abc1234def5678",
+			ShortHash:   "abc1234",
+			AuthorName:  "Test User",
+			AuthorEmail: "test@example.com",
+			Subject:     "Fix the thing",
+			Body:        "Longer explanation",
+		},
+		Stat: " file.txt | 2 +-\n 1 file changed, 1 insertion(+), 1 deletion(-)",
+		Diff: &git.DiffResult{
+			Files: []git.FileDiff{{
+				Path:        "file.txt",
+				DisplayPath: "file.txt",
+				Hunks: []git.Hunk{{
+					Header:          "@@ -1 +1 @@",
+					FilePath:        "file.txt",
+					DisplayFilePath: "file.txt",
+					Lines: []git.DiffLine{
+						{Type: git.LineRemoved, Content: "-old"},
+						{Type: git.LineAdded, Content: "+new"},
+					},
+				}},
+			}},
+		},
+	}
+}
+
+func TestNewCommitDiffModel(t *testing.T) {
+	m := NewCommitDiffModel("abc1234", 100, 50)
+
+	if !m.isCommitView() {
+		t.Error("isCommitView should be true")
+	}
+	if m.Init() == nil {
+		t.Error("Init() should return a command")
+	}
+}
+
+func TestDiffModelCommitDetailMsg(t *testing.T) {
+	m := NewCommitDiffModel("abc1234", 100, 50)
+
+	newModel, _ := m.Update(commitDetailMsg{testCommitDetail()})
+	m = newModel.(DiffModel)
+
+	if len(m.hunks) != 1 {
+		t.Fatalf("len(hunks) = %d, want 1", len(m.hunks))
+	}
+	if m.viewingHunk {
+		t.Error("commit view should stay on the hunk list so the message is visible")
+	}
+}
+
+func TestDiffModelCommitIsReadOnly(t *testing.T) {
+	m := NewCommitDiffModel("abc1234", 100, 50)
+	newModel, _ := m.Update(commitDetailMsg{testCommitDetail()})
+	m = newModel.(DiffModel)
+
+	for _, key := range []string{" ", Keys.Stage, Keys.Unstage, Keys.Discard, Keys.Edit, Keys.StashHunks} {
+		newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		m = newModel.(DiffModel)
+		if cmd != nil {
+			t.Errorf("key %q should not return a command in commit view", key)
+		}
+		if m.confirmMode || m.stashConfirm {
+			t.Errorf("key %q should not enter confirm mode in commit view", key)
+		}
+	}
+}
+
+func TestDiffModelCommitView(t *testing.T) {
+	m := NewCommitDiffModel("abc1234", 100, 50)
+	newModel, _ := m.Update(commitDetailMsg{testCommitDetail()})
+	m = newModel.(DiffModel)
+
+	view := m.View()
+	for _, want := range []string{"commit abc1234def5678", "Author: Test User <test@example.com>", "Fix the thing", "Longer explanation", "1 file changed", "[abc1234]", "+new"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q", want)
+		}
+	}
+	if strings.Contains(view, "[Unstaged]") {
+		t.Error("commit view should not label hunks as unstaged")
+	}
+
+	m.viewingFullDiff = true
+	view = m.View()
+	if !strings.Contains(view, "file.txt | 2 +-") {
+		t.Error("full diff should include the complete diffstat")
+	}
+}
+
+func TestDiffModelCommitViewHelp(t *testing.T) {
+	m := NewCommitDiffModel("abc1234", 100, 50)
+	m.showHelp = true
+
+	view := m.View()
+	if strings.Contains(view, "Stage hunk") {
+		t.Error("commit view help should not list staging actions")
+	}
+}
+
+func splittableHunk() git.Hunk {
+	return git.Hunk{
+		FilePath: "file1.txt",
+		Header:   "@@ -1,5 +1,5 @@",
+		StartOld: 1, CountOld: 5, StartNew: 1, CountNew: 5,
+		Lines: []git.DiffLine{
+			{Content: "-one", Type: git.LineRemoved},
+			{Content: "+ONE", Type: git.LineAdded},
+			{Content: " two", Type: git.LineContext},
+			{Content: " three", Type: git.LineContext},
+			{Content: "-four", Type: git.LineRemoved},
+			{Content: "+FOUR", Type: git.LineAdded},
+			{Content: " five", Type: git.LineContext},
+		},
+	}
+}
+
+func TestDiffModelSplitHunk(t *testing.T) {
+	m := NewDiffModel(nil)
+	m.hunks = []git.Hunk{{FilePath: "other.txt"}, splittableHunk()}
+	m.cursor = 1
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	m = newModel.(DiffModel)
+
+	if len(m.hunks) != 3 {
+		t.Fatalf("len(hunks) = %d, want 3 after split", len(m.hunks))
+	}
+	if m.hunks[1].Header != "@@ -1,3 +1,3 @@" || m.hunks[2].Header != "@@ -2,4 +2,4 @@" {
+		t.Errorf("sub-hunk headers = %q, %q", m.hunks[1].Header, m.hunks[2].Header)
+	}
+	if m.cursor != 1 {
+		t.Errorf("cursor = %d, want 1 (first sub-hunk)", m.cursor)
+	}
+
+	// A single change can't be split further
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	m = newModel.(DiffModel)
+	if len(m.hunks) != 3 {
+		t.Errorf("len(hunks) = %d, want 3", len(m.hunks))
+	}
+}
+
+func TestDiffModelSplitHunkInDetail(t *testing.T) {
+	m := NewDiffModel(nil)
+	m.hunks = []git.Hunk{splittableHunk()}
+	m.viewingHunk = true
+	m.lineCursor = 5
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	m = newModel.(DiffModel)
+
+	if len(m.hunks) != 2 {
+		t.Fatalf("len(hunks) = %d, want 2 after split", len(m.hunks))
+	}
+	if !m.viewingHunk || m.lineCursor != 0 {
+		t.Errorf("should stay in hunk detail on the first change, lineCursor = %d", m.lineCursor)
+	}
+}
+
+func TestDiffModelSplitSurvivesRefresh(t *testing.T) {
+	m := NewDiffModel(nil)
+	m.hunks = []git.Hunk{splittableHunk()}
+	m.splitHunk()
+
+	// Refreshing returns the unsplit hunk from git
+	diff := &git.CombinedDiffResult{
+		UnstagedDiff: &git.DiffResult{Files: []git.FileDiff{{Path: "file1.txt", Hunks: []git.Hunk{splittableHunk()}}}},
+	}
+	newModel, _ := m.Update(combinedDiffMsg{diff})
+	m = newModel.(DiffModel)
+
+	if len(m.hunks) != 2 {
+		t.Errorf("len(hunks) = %d, want split hunk to stay split", len(m.hunks))
+	}
+}
+
+func TestDiffModelEditHunkIgnoresStaged(t *testing.T) {
+	m := NewDiffModel(nil)
+	m.diff = &git.CombinedDiffResult{StagedDiff: &git.DiffResult{Files: []git.FileDiff{{Path: "file1.txt"}}}}
+	m.hunks = []git.Hunk{{FilePath: "file1.txt", Staged: true}}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'E'}})
+	if cmd != nil {
+		t.Error("editing a staged hunk should do nothing")
+	}
+}
+
+func TestDiffModelEditHunk(t *testing.T) {
+	m := NewDiffModel(nil)
+	m.diff = &git.CombinedDiffResult{UnstagedDiff: &git.DiffResult{Files: []git.FileDiff{{
+		Path:   "file1.txt",
+		Header: []string{"diff --git a/file1.txt b/file1.txt", "--- a/file1.txt", "+++ b/file1.txt"},
+	}}}}
+	m.hunks = []git.Hunk{{
+		FilePath: "file1.txt",
+		Header:   "@@ -1 +1 @@",
+		Lines: []git.DiffLine{
+			{Content: "-old", Type: git.LineRemoved},
+			{Content: "+new", Type: git.LineAdded},
+		},
+	}}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'E'}})
+	if cmd == nil {
+		t.Fatal("editing an unstaged hunk should return a command")
+	}
+	ready, ok := cmd().(hunkEditReadyMsg)
+	if !ok {
+		t.Fatalf("command returned %T, want hunkEditReadyMsg", cmd())
+	}
+	defer os.Remove(ready.path)
+
+	content, err := os.ReadFile(ready.path)
+	if err != nil {
+		t.Fatalf("reading hunk file: %v", err)
+	}
+	for _, want := range []string{"+++ b/file1.txt", "@@ -1 +1 @@", "-old", "+new", hunkEditGuide} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("hunk file missing %q:\n%s", want, content)
+		}
+	}
+	if args := ready.cmd.Args; args[len(args)-1] != ready.path {
+		t.Errorf("editor args = %v, want the hunk file last", args)
+	}
+
+	_, cmd = m.Update(ready)
+	if cmd == nil {
+		t.Error("a prepared hunk file should open the editor")
+	}
+}
@@ -0,0 +1,351 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go-on-git/internal/git"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// discardedLimit caps the number of discards listed
+const discardedLimit = 200
+
+// DiscardedModel is the bubbletea model for the recently discarded view
+type DiscardedModel struct {
+	discarded       []git.Discarded
+	loaded          bool
+	cursor          int
+	scrollOffset    int
+	restoreMode     bool   // confirming a restore
+	notice          string // result of the last action
+	showHelp        bool
+	showVerboseHelp bool
+	lastKey         string
+	err             error
+	width           int
+	height          int
+}
+
+// NewDiscardedModel creates a new recently discarded model
+func NewDiscardedModel() DiscardedModel {
+	return NewDiscardedModelWithOptions(false)
+}
+
+// NewDiscardedModelWithOptions creates a new recently discarded model with options
+func NewDiscardedModelWithOptions(showVerboseHelp bool) DiscardedModel {
+	return DiscardedModel{
+		showVerboseHelp: showVerboseHelp,
+	}
+}
+
+type discardedMsg struct {
+	discarded []git.Discarded
+	notice    string
+}
+
+// Init initializes the model
+func (m DiscardedModel) Init() tea.Cmd {
+	return refreshDiscarded
+}
+
+func refreshDiscarded() tea.Msg {
+	discarded, err := git.GetDiscarded(discardedLimit)
+	if err != nil {
+		return errMsg{err}
+	}
+	return discardedMsg{discarded: discarded}
+}
+
+// inPrompt returns true while the help or a confirmation is open
+func (m DiscardedModel) inPrompt() bool {
+	return m.showHelp || m.restoreMode
+}
+
+// SelectedDiscarded returns the discard under the cursor
+func (m DiscardedModel) SelectedDiscarded() (git.Discarded, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.discarded) {
+		return git.Discarded{}, false
+	}
+	return m.discarded[m.cursor], true
+}
+
+// Update handles messages
+func (m DiscardedModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		key := msg.String()
+
+		// Handle help mode
+		if m.showHelp {
+			if key == Keys.Help || key == "esc" || key == Keys.Quit {
+				m.showHelp = false
+			}
+			return m, nil
+		}
+
+		// Handle restore confirmation
+		if m.restoreMode {
+			switch key {
+			case "y", "Y":
+				m.restoreMode = false
+				return m, m.doRestore()
+			case "n", "N", "esc":
+				m.restoreMode = false
+			}
+			return m, nil
+		}
+
+		// Check for gg sequence
+		if m.lastKey == Keys.Top && key == Keys.Top {
+			m.lastKey = ""
+			m.cursor = 0
+			m.ensureCursorVisible()
+			return m, nil
+		}
+
+		if key == Keys.Top {
+			m.lastKey = Keys.Top
+			return m, nil
+		}
+		m.lastKey = ""
+
+		switch key {
+		case Keys.Help:
+			m.showHelp = true
+			return m, nil
+		case Keys.VerboseHelp:
+			m.showVerboseHelp = !m.showVerboseHelp
+			return m, nil
+		case Keys.Down, "down":
+			if len(m.discarded) > 0 {
+				m.cursor = min(m.cursor+1, len(m.discarded)-1)
+				m.ensureCursorVisible()
+			}
+			return m, nil
+		case Keys.Up, "up":
+			if len(m.discarded) > 0 {
+				m.cursor = max(m.cursor-1, 0)
+				m.ensureCursorVisible()
+			}
+			return m, nil
+		case Keys.Bottom:
+			if len(m.discarded) > 0 {
+				m.cursor = len(m.discarded) - 1
+				m.ensureCursorVisible()
+			}
+			return m, nil
+		case Keys.Restore, "enter":
+			if _, ok := m.SelectedDiscarded(); ok {
+				m.err = nil
+				m.notice = ""
+				m.restoreMode = true
+			}
+			return m, nil
+		}
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case discardedMsg:
+		m.discarded = msg.discarded
+		m.loaded = true
+		m.err = nil
+		m.notice = msg.notice
+		if m.cursor >= len(m.discarded) {
+			m.cursor = max(0, len(m.discarded)-1)
+		}
+		m.ensureCursorVisible()
+		return m, nil
+
+	case errMsg:
+		m.err = msg.err
+		m.notice = ""
+		return m, nil
+	}
+
+	return m, nil
+}
+
+func (m DiscardedModel) doRestore() tea.Cmd {
+	d, ok := m.SelectedDiscarded()
+	if !ok {
+		return nil
+	}
+	return func() tea.Msg {
+		if err := git.RestoreDiscarded(d); err != nil {
+			return errMsg{err}
+		}
+		msg := refreshDiscarded()
+		if discarded, ok := msg.(discardedMsg); ok {
+			discarded.notice = fmt.Sprintf("Restored %s", d.Description())
+			return discarded
+		}
+		return msg
+	}
+}
+
+// visibleLines returns the number of lines that can be displayed
+func (m DiscardedModel) visibleLines() int {
+	// Reserve lines for: header (~3), help bar (~3 if shown), prompts, and buffer
+	reserved := 8
+	if m.showVerboseHelp {
+		reserved += 3
+	}
+	if m.height <= reserved {
+		return 10 // fallback minimum
+	}
+	return m.height - reserved
+}
+
+// ensureCursorVisible adjusts scrollOffset to keep cursor in view
+func (m *DiscardedModel) ensureCursorVisible() {
+	visible := m.visibleLines()
+	if m.cursor < m.scrollOffset {
+		m.scrollOffset = m.cursor
+	}
+	if m.cursor >= m.scrollOffset+visible {
+		m.scrollOffset = m.cursor - visible + 1
+	}
+	m.scrollOffset = max(0, min(m.scrollOffset, len(m.discarded)-visible))
+}
+
+// View renders the model
+func (m DiscardedModel) View() string {
+	if m.showHelp {
+		return m.renderHelp()
+	}
+
+	var sb strings.Builder
+
+	if m.err != nil {
+		sb.WriteString(StyleUnstaged.Render(fmt.Sprintf("Error: %v", m.err)))
+		sb.WriteString("\n\n")
+	}
+
+	sb.WriteString(m.renderHeader())
+	sb.WriteString("\n\n")
+
+	switch {
+	case !m.loaded:
+		sb.WriteString(StyleMuted.Render("Loading..."))
+		sb.WriteString("\n")
+	case len(m.discarded) == 0:
+		sb.WriteString(StyleEmpty.Render("Nothing discarded yet"))
+		sb.WriteString("\n")
+	}
+
+	visibleEnd := min(m.scrollOffset+m.visibleLines(), len(m.discarded))
+	if m.scrollOffset > 0 {
+		sb.WriteString(StyleMuted.Render(fmt.Sprintf("  ↑ %d more above", m.scrollOffset)))
+		sb.WriteString("\n")
+	}
+
+	now := time.Now()
+	for i := m.scrollOffset; i < visibleEnd; i++ {
+		d := m.discarded[i]
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+		}
+
+		sb.WriteString(prefix)
+		sb.WriteString(StyleCommitHash.Render(git.ShortHash(d.Hash)))
+		sb.WriteString(" ")
+		switch d.Kind {
+		case git.DiscardedUntracked:
+			sb.WriteString(StyleUntracked.Render(d.Description()))
+		default:
+			sb.WriteString(StyleUnstaged.Render(d.Description()))
+		}
+		sb.WriteString(StyleMuted.Render(" - " + formatRelativeTime(d.Date, now)))
+		sb.WriteString("\n")
+	}
+
+	if visibleEnd < len(m.discarded) {
+		sb.WriteString(StyleMuted.Render(fmt.Sprintf("  ↓ %d more below", len(m.discarded)-visibleEnd)))
+		sb.WriteString("\n")
+	}
+
+	if m.notice != "" {
+		sb.WriteString("\n")
+		sb.WriteString(StyleMuted.Render(m.notice))
+		sb.WriteString("\n")
+	}
+
+	if d, ok := m.SelectedDiscarded(); ok && m.restoreMode {
+		sb.WriteString("\n")
+		sb.WriteString(StyleConfirm.Render(fmt.Sprintf("Restore %s into the working tree? (y/n) ", d.Description())))
+	}
+
+	// Help bar (only show when showVerboseHelp is on and not in a prompt)
+	if m.showVerboseHelp && !m.inPrompt() {
+		sb.WriteString("\n\n")
+		sb.WriteString(m.renderHelpBar())
+	}
+
+	return sb.String()
+}
+
+func (m DiscardedModel) renderHeader() string {
+	return StyleMuted.Render("> recently discarded") + "  " + StyleMuted.Render("(esc to go back)") + "\n" + StyleMuted.Render("───────────────────────────────────────────────────────────────")
+}
+
+func (m DiscardedModel) renderHelpBar() string {
+	var sb strings.Builder
+
+	sb.WriteString(StyleMuted.Render("───────────────────────────────────────────────────────────────"))
+	sb.WriteString("\n")
+
+	items := []struct{ key, desc string }{
+		{formatKeyList(Keys.Down, Keys.Up), "navigate"},
+		{formatKeyList(Keys.Restore, "Enter"), "restore"},
+		{Keys.Help, "help"},
+		{formatKeyList(Keys.Left, "ESC"), "back"},
+	}
+
+	for _, item := range items {
+		sb.WriteString(StyleHelpKey.Render(item.key))
+		sb.WriteString(" ")
+		sb.WriteString(StyleHelpDesc.Render(item.desc))
+		sb.WriteString("  ")
+	}
+
+	return sb.String()
+}
+
+func (m DiscardedModel) renderHelp() string {
+	var sb strings.Builder
+
+	sb.WriteString(StyleHelpTitle.Render("Recently Discarded Shortcuts"))
+	sb.WriteString("\n\n")
+
+	moveKeys := formatKeyList(Keys.Down, Keys.Up, "↓", "↑")
+	topKey := formatDoubleKey(Keys.Top)
+	restoreKeys := formatKeyList(Keys.Restore, "Enter")
+	backKeys := formatKeyList(Keys.Left, "←", "ESC")
+
+	help := []struct {
+		key  string
+		desc string
+	}{
+		{moveKeys, "Move down/up"},
+		{topKey, "Go to top"},
+		{Keys.Bottom, "Go to bottom"},
+		{restoreKeys, "Restore into the working tree"},
+		{Keys.Help, "Toggle help"},
+		{backKeys, "Go back"},
+	}
+
+	for _, h := range help {
+		sb.WriteString(fmt.Sprintf("  %s  %s\n",
+			StyleHelpKey.Render(fmt.Sprintf("%-8s", h.key)),
+			StyleHelpDesc.Render(h.desc)))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go-on-git/internal/git"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func makeDiscarded() []git.Discarded {
+	now := time.Now()
+	return []git.Discarded{
+		{Hash: "1111111111111111111111111111111111111111", Kind: git.DiscardedHunk, Path: "main.go", Date: now.Add(-time.Minute)},
+		{Hash: "2222222222222222222222222222222222222222", Kind: git.DiscardedUntracked, Path: "notes/", Date: now.Add(-time.Hour)},
+		{Hash: "3333333333333333333333333333333333333333", Kind: git.DiscardedChanges, Path: "README.md", Date: now.Add(-48 * time.Hour)},
+	}
+}
+
+func TestNewDiscardedModel(t *testing.T) {
+	m := NewDiscardedModel()
+
+	if m.cursor != 0 {
+		t.Errorf("cursor = %d, want 0", m.cursor)
+	}
+	if m.loaded {
+		t.Error("loaded should be false initially")
+	}
+	if m.Init() == nil {
+		t.Error("Init() should return a command")
+	}
+}
+
+func TestDiscardedModelView(t *testing.T) {
+	m := NewDiscardedModel()
+	m.discarded = makeDiscarded()
+	m.loaded = true
+
+	view := m.View()
+
+	if !strings.Contains(view, "> recently discarded") {
+		t.Error("view should contain the header")
+	}
+	for _, want := range []string{"1111111", "hunk in main.go", "1 minute ago", "untracked notes/", "changes to README.md", "2 days ago"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q", want)
+		}
+	}
+}
+
+func TestDiscardedModelViewEmpty(t *testing.T) {
+	m := NewDiscardedModel()
+
+	newModel, _ := m.Update(discardedMsg{})
+	m = newModel.(DiscardedModel)
+
+	if !strings.Contains(m.View(), "Nothing discarded yet") {
+		t.Error("view should say nothing was discarded")
+	}
+}
+
+func TestDiscardedModelRestore(t *testing.T) {
+	m := NewDiscardedModel()
+	m.discarded = makeDiscarded()
+	m.loaded = true
+	m.cursor = 1
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	m = newModel.(DiscardedModel)
+	if !m.restoreMode || !m.inPrompt() {
+		t.Fatal("r should ask to confirm the restore")
+	}
+	if view := m.View(); !strings.Contains(view, "Restore untracked notes/ into the working tree? (y/n)") {
+		t.Errorf("view should show the restore prompt, got:\n%s", view)
+	}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	m = newModel.(DiscardedModel)
+	if m.restoreMode || cmd != nil {
+		t.Error("n should cancel the restore")
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(DiscardedModel)
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m = newModel.(DiscardedModel)
+	if m.restoreMode || cmd == nil {
+		t.Error("y should restore")
+	}
+}
+
+func TestDiscardedModelRestoreError(t *testing.T) {
+	m := NewDiscardedModel()
+	m.discarded = makeDiscarded()
+	m.loaded = true
+	m.notice = "Restored hunk in main.go"
+
+	newModel, _ := m.Update(errMsg{errors.New("'README.md' has changes, discard or stash them before restoring")})
+	m = newModel.(DiscardedModel)
+
+	if m.notice != "" {
+		t.Error("an error should clear the notice")
+	}
+	if !strings.Contains(m.View(), "Error: 'README.md' has changes") {
+		t.Error("view should show the error")
+	}
+}
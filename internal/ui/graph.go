@@ -0,0 +1,225 @@
+package ui
+
+import (
+	"strings"
+
+	"go-on-git/internal/git"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// graphRow is a single line of the commit graph. Each lane takes two
+// characters: the lane symbol followed by a gap used for diagonal edges.
+type graphRow struct {
+	chars []rune
+	lanes []int // lane index for coloring each character (-1 = uncolored)
+}
+
+// graphEntry holds the graph lines for a single commit: the row with the
+// commit node and any connector rows drawn before the next commit
+type graphEntry struct {
+	commit     graphRow
+	connectors []graphRow
+}
+
+// lineCount returns the number of display lines used by the entry
+func (e graphEntry) lineCount() int {
+	return 1 + len(e.connectors)
+}
+
+func newGraphRow(width int) graphRow {
+	row := graphRow{
+		chars: make([]rune, width),
+		lanes: make([]int, width),
+	}
+	for i := range row.chars {
+		row.chars[i] = ' '
+		row.lanes[i] = -1
+	}
+	return row
+}
+
+func (r *graphRow) set(pos int, ch rune, lane int) {
+	if pos < 0 || pos >= len(r.chars) {
+		return
+	}
+	r.chars[pos] = ch
+	r.lanes[pos] = lane
+}
+
+// width returns the row width without trailing blanks
+func (r graphRow) width() int {
+	w := len(r.chars)
+	for w > 0 && r.chars[w-1] == ' ' {
+		w--
+	}
+	return w
+}
+
+// buildGraph lays out commits into lanes like git log --graph.
+// Commits must be in topological order (children before parents).
+func buildGraph(commits []git.CommitInfo) []graphEntry {
+	entries := make([]graphEntry, len(commits))
+	var lanes []string // hash of the commit expected next in each lane ("" = free)
+
+	findLane := func(hash string) int {
+		for i, h := range lanes {
+			if h == hash {
+				return i
+			}
+		}
+		return -1
+	}
+	freeLane := func(exclude int) int {
+		for i, h := range lanes {
+			if h == "" && i != exclude {
+				return i
+			}
+		}
+		lanes = append(lanes, "")
+		return len(lanes) - 1
+	}
+
+	for idx, c := range commits {
+		// Drop trailing free lanes so the graph doesn't keep growing
+		for len(lanes) > 0 && lanes[len(lanes)-1] == "" {
+			lanes = lanes[:len(lanes)-1]
+		}
+
+		col := findLane(c.Hash)
+		if col < 0 {
+			// Branch tip not reached from an earlier commit: start a new lane
+			col = freeLane(-1)
+			lanes[col] = c.Hash
+		}
+
+		// Commit row
+		commitRow := newGraphRow(len(lanes) * 2)
+		for i, h := range lanes {
+			if i == col {
+				commitRow.set(i*2, '*', i)
+			} else if h != "" {
+				commitRow.set(i*2, '|', i)
+			}
+		}
+
+		before := append([]string(nil), lanes...)
+
+		// Assign parents to lanes, recording edges that need connectors
+		type edge struct{ from, to, color int }
+		var edges []edge
+		newLane := make(map[int]bool) // lanes that start at one of this commit's parents
+		lanes[col] = ""
+		for pi, parent := range c.Parents {
+			if existing := findLane(parent); existing >= 0 {
+				switch {
+				case pi == 0 && existing > col:
+					// Keep the parent in the leftmost lane: fold the other lane into this one
+					lanes[col] = parent
+					lanes[existing] = ""
+					edges = append(edges, edge{existing, col, existing})
+				case pi == 0:
+					// Join the parent's lane to the left
+					edges = append(edges, edge{col, existing, col})
+				default:
+					// Merge parent already has a lane
+					edges = append(edges, edge{col, existing, existing})
+				}
+				continue
+			}
+			if pi == 0 {
+				lanes[col] = parent
+				continue
+			}
+			lane := freeLane(col)
+			lanes[lane] = parent
+			newLane[lane] = true
+			edges = append(edges, edge{col, lane, lane})
+		}
+
+		entries[idx].commit = commitRow
+
+		// Leftward edges (lanes joining) are drawn before rightward edges
+		// (lanes branching out), so a lane freed by a join can be reused
+		var left, right []edge
+		for _, e := range edges {
+			if e.to < e.from {
+				left = append(left, e)
+			} else {
+				right = append(right, e)
+			}
+		}
+
+		if len(left) > 0 {
+			row := newGraphRow(len(lanes) * 2)
+			for i, h := range lanes {
+				if h != "" && i < len(before) && before[i] != "" && !newLane[i] {
+					row.set(i*2, '|', i)
+				}
+			}
+			for _, e := range left {
+				for pos := e.to*2 + 2; pos < e.from*2; pos++ {
+					if row.chars[pos] == ' ' {
+						row.set(pos, '-', e.color)
+					}
+				}
+				row.set(e.to*2+1, '/', e.color)
+			}
+			entries[idx].connectors = append(entries[idx].connectors, row)
+		}
+
+		if len(right) > 0 {
+			row := newGraphRow(len(lanes) * 2)
+			for i, h := range lanes {
+				if h != "" && !(newLane[i] && i > col) {
+					row.set(i*2, '|', i)
+				}
+			}
+			for _, e := range right {
+				for pos := e.from*2 + 1; pos < e.to*2-1; pos++ {
+					if row.chars[pos] == ' ' {
+						row.set(pos, '-', e.color)
+					}
+				}
+				row.set(e.to*2-1, '\\', e.color)
+			}
+			entries[idx].connectors = append(entries[idx].connectors, row)
+		}
+	}
+
+	return entries
+}
+
+// graphWidth returns the widest row in the graph
+func graphWidth(entries []graphEntry) int {
+	w := 0
+	for _, e := range entries {
+		w = max(w, e.commit.width())
+		for _, c := range e.connectors {
+			w = max(w, c.width())
+		}
+	}
+	return w
+}
+
+// render draws the row padded to width, coloring each lane
+func (r graphRow) render(width int) string {
+	var sb strings.Builder
+	for i := 0; i < width; i++ {
+		if i >= len(r.chars) {
+			sb.WriteRune(' ')
+			continue
+		}
+		if r.lanes[i] < 0 || r.chars[i] == ' ' {
+			sb.WriteRune(r.chars[i])
+			continue
+		}
+		sb.WriteString(laneStyle(r.lanes[i]).Render(string(r.chars[i])))
+	}
+	return sb.String()
+}
+
+// laneStyle returns the color used for a graph lane
+func laneStyle(lane int) lipgloss.Style {
+	return GraphLaneStyles[lane%len(GraphLaneStyles)]
+}
@@ -0,0 +1,172 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"go-on-git/internal/git"
+)
+
+// graphText renders the graph as plain text for comparison
+func graphText(entries []graphEntry) string {
+	var lines []string
+	for _, e := range entries {
+		lines = append(lines, strings.TrimRight(string(e.commit.chars), " "))
+		for _, c := range e.connectors {
+			lines = append(lines, strings.TrimRight(string(c.chars), " "))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func graphCommit(hash string, parents ...string) git.CommitInfo {
+	return git.CommitInfo{Hash: hash, ShortHash: hash, Parents: parents}
+}
+
+func TestBuildGraphLinear(t *testing.T) {
+	entries := buildGraph([]git.CommitInfo{
+		graphCommit("c", "b"),
+		graphCommit("b", "a"),
+		graphCommit("a"),
+	})
+
+	want := "*\n*\n*"
+	if got := graphText(entries); got != want {
+		t.Errorf("graph =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestBuildGraphMerge(t *testing.T) {
+	// m merges f (feature) into b; f and b both descend from a
+	entries := buildGraph([]git.CommitInfo{
+		graphCommit("m", "b", "f"),
+		graphCommit("f", "a"),
+		graphCommit("b", "a"),
+		graphCommit("a"),
+	})
+
+	want := strings.Join([]string{
+		"*",
+		`|\`,
+		"| *",
+		"* |",
+		"|/",
+		"*",
+	}, "\n")
+	if got := graphText(entries); got != want {
+		t.Errorf("graph =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestBuildGraphParallelBranches(t *testing.T) {
+	// Two branch tips that share a parent
+	entries := buildGraph([]git.CommitInfo{
+		graphCommit("x", "a"),
+		graphCommit("y", "a"),
+		graphCommit("a"),
+	})
+
+	want := strings.Join([]string{
+		"*",
+		"| *",
+		"|/",
+		"*",
+	}, "\n")
+	if got := graphText(entries); got != want {
+		t.Errorf("graph =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestBuildGraphOctopusMerge(t *testing.T) {
+	entries := buildGraph([]git.CommitInfo{
+		graphCommit("m", "a", "b", "c"),
+		graphCommit("c", "r"),
+		graphCommit("b", "r"),
+		graphCommit("a", "r"),
+		graphCommit("r"),
+	})
+
+	lines := strings.Split(graphText(entries), "\n")
+	if lines[0] != "*" {
+		t.Errorf("first row = %q, want %q", lines[0], "*")
+	}
+	if lines[1] != `|\-\` {
+		t.Errorf("merge connector = %q, want %q", lines[1], `|\-\`)
+	}
+	if last := lines[len(lines)-1]; last != "*" {
+		t.Errorf("root row = %q, want %q", last, "*")
+	}
+}
+
+func TestBuildGraphEntryLineCount(t *testing.T) {
+	entries := buildGraph([]git.CommitInfo{
+		graphCommit("m", "b", "f"),
+		graphCommit("f", "b"),
+		graphCommit("b"),
+	})
+
+	if entries[0].lineCount() != 2 {
+		t.Errorf("merge entry lineCount = %d, want 2", entries[0].lineCount())
+	}
+	if entries[2].lineCount() != 1 {
+		t.Errorf("root entry lineCount = %d, want 1", entries[2].lineCount())
+	}
+}
+
+func TestGraphWidth(t *testing.T) {
+	entries := buildGraph([]git.CommitInfo{
+		graphCommit("m", "b", "f"),
+		graphCommit("f", "b"),
+		graphCommit("b"),
+	})
+
+	if w := graphWidth(entries); w != 3 {
+		t.Errorf("graphWidth = %d, want 3", w)
+	}
+}
+
+func TestGraphRowRenderPads(t *testing.T) {
+	row := newGraphRow(2)
+	row.set(0, '*', 0)
+
+	got := row.render(5)
+	if !strings.Contains(got, "*") {
+		t.Errorf("render should contain the node, got %q", got)
+	}
+	if !strings.HasSuffix(got, "    ") {
+		t.Errorf("render should pad to width, got %q", got)
+	}
+}
+
+func TestBuildGraphJoinThenBranch(t *testing.T) {
+	// m1's first parent d is already on the right lane (via e), and its
+	// merge parent c needs a lane: the join is drawn before the branch
+	entries := buildGraph([]git.CommitInfo{
+		graphCommit("m2", "g", "e"),
+		graphCommit("e", "d"),
+		graphCommit("g", "m1"),
+		graphCommit("m1", "d", "c"),
+		graphCommit("c", "b"),
+		graphCommit("b", "a"),
+		graphCommit("d", "a"),
+		graphCommit("a"),
+	})
+
+	want := strings.Join([]string{
+		"*",
+		`|\`,
+		"| *",
+		"* |",
+		"* |",
+		"|/",
+		`|\`,
+		"| *",
+		"| *",
+		"* |",
+		"|/",
+		"*",
+	}, "\n")
+	if got := graphText(entries); got != want {
+		t.Errorf("graph =\n%s\nwant\n%s", got, want)
+	}
+}
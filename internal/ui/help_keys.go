@@ -35,6 +35,8 @@ func formatKeyLabel(key string) string {
 		return "Enter"
 	case " ":
 		return "SPACE"
+	case "tab":
+		return "TAB"
 	default:
 		return key
 	}
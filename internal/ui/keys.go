@@ -16,24 +16,33 @@ type Keymap struct {
 	Quit   string
 
 	// Actions
-	Stage      string
-	StageAll   string
-	Unstage    string
-	UnstageAll string
-	Discard    string
-	Commit     string
-	CommitEdit string
-	Push       string
-	Stash      string
-	StashAll   string
+	Stage       string
+	StageAll    string
+	Unstage     string
+	UnstageAll  string
+	Discard     string
+	Commit      string
+	CommitEdit  string
+	Amend       string
+	AmendNoEdit string
+	Push        string
+	Fetch       string
+	Pull        string
+	Stash       string
+	StashAll    string
 
 	// Views
-	FileDiff string
-	AllDiffs string
-	FullDiff string
-	Branches string
-	Stashes  string
-	Log      string
+	FileDiff   string
+	AllDiffs   string
+	FullDiff   string
+	Branches   string
+	Stashes    string
+	Log        string
+	Tags       string
+	Worktrees  string
+	Submodules string
+	Reflog     string
+	Discarded  string
 
 	// Other
 	Refresh string
@@ -45,6 +54,65 @@ type Keymap struct {
 	VerboseHelp string
 	NewBranch   string
 	Delete      string
+
+	// Branches
+	RemoteBranches string
+	RenameBranch   string
+	SetUpstream    string
+	UnsetUpstream  string
+	Merge          string
+	Rebase         string
+
+	// History
+	CherryPick string
+	Revert     string
+	Reset      string
+	Undo       string
+	Restore    string
+
+	// Rebase planner
+	MoveUp     string
+	MoveDown   string
+	Pick       string
+	Reword     string
+	EditCommit string
+	Squash     string
+	Fixup      string
+	Drop       string
+
+	// Operations
+	Continue string
+	Skip     string
+	Abort    string
+
+	// Stashes
+	StashApply     string
+	StashApplyFile string
+	StashPop       string
+	StashDrop      string
+	StashRename    string
+	StashCompare   string
+
+	// Tags
+	NewTag string
+
+	// Worktrees
+	Prune string
+
+	// Submodules
+	SubmoduleInit   string
+	SubmoduleUpdate string
+	SubmoduleSync   string
+
+	// Conflicts
+	Ours   string
+	Theirs string
+	Both   string
+
+	// Hunks
+	Split      string
+	EditHunk   string
+	StashHunks string
 }
 
 type keymapBinding struct {
@@ -69,7 +137,11 @@ var keymapBindings = []keymapBinding{
 	{action: "discard", key: func(k *Keymap) *string { return &k.Discard }},
 	{action: "commit", key: func(k *Keymap) *string { return &k.Commit }},
 	{action: "commit-edit", key: func(k *Keymap) *string { return &k.CommitEdit }},
+	{action: "amend", key: func(k *Keymap) *string { return &k.Amend }},
+	{action: "amend-no-edit", key: func(k *Keymap) *string { return &k.AmendNoEdit }},
 	{action: "push", key: func(k *Keymap) *string { return &k.Push }},
+	{action: "fetch", key: func(k *Keymap) *string { return &k.Fetch }},
+	{action: "pull", key: func(k *Keymap) *string { return &k.Pull }},
 	{action: "stash", key: func(k *Keymap) *string { return &k.Stash }},
 	{action: "stash-all", key: func(k *Keymap) *string { return &k.StashAll }},
 	{action: "file-diff", key: func(k *Keymap) *string { return &k.FileDiff }},
@@ -78,13 +150,57 @@ var keymapBindings = []keymapBinding{
 	{action: "branches", key: func(k *Keymap) *string { return &k.Branches }},
 	{action: "stashes", key: func(k *Keymap) *string { return &k.Stashes }},
 	{action: "log", key: func(k *Keymap) *string { return &k.Log }},
+	{action: "tags", key: func(k *Keymap) *string { return &k.Tags }},
+	{action: "worktrees", key: func(k *Keymap) *string { return &k.Worktrees }},
+	{action: "submodules", key: func(k *Keymap) *string { return &k.Submodules }},
+	{action: "reflog", key: func(k *Keymap) *string { return &k.Reflog }},
+	{action: "discarded", key: func(k *Keymap) *string { return &k.Discarded }},
 	{action: "visual", key: func(k *Keymap) *string { return &k.Visual }},
 	{action: "edit", key: func(k *Keymap) *string { return &k.Edit }},
 	{action: "help", key: func(k *Keymap) *string { return &k.Help }},
 	{action: "verbose-help", key: func(k *Keymap) *string { return &k.VerboseHelp }},
 	{action: "new-branch", key: func(k *Keymap) *string { return &k.NewBranch }},
 	{action: "delete", key: func(k *Keymap) *string { return &k.Delete }},
+	{action: "remote-branches", key: func(k *Keymap) *string { return &k.RemoteBranches }},
+	{action: "rename-branch", key: func(k *Keymap) *string { return &k.RenameBranch }},
+	{action: "set-upstream", key: func(k *Keymap) *string { return &k.SetUpstream }},
+	{action: "unset-upstream", key: func(k *Keymap) *string { return &k.UnsetUpstream }},
+	{action: "merge", key: func(k *Keymap) *string { return &k.Merge }},
+	{action: "rebase", key: func(k *Keymap) *string { return &k.Rebase }},
+	{action: "cherry-pick", key: func(k *Keymap) *string { return &k.CherryPick }},
+	{action: "revert", key: func(k *Keymap) *string { return &k.Revert }},
+	{action: "reset", key: func(k *Keymap) *string { return &k.Reset }},
+	{action: "undo", key: func(k *Keymap) *string { return &k.Undo }},
+	{action: "restore", key: func(k *Keymap) *string { return &k.Restore }},
+	{action: "move-up", key: func(k *Keymap) *string { return &k.MoveUp }},
+	{action: "move-down", key: func(k *Keymap) *string { return &k.MoveDown }},
+	{action: "pick", key: func(k *Keymap) *string { return &k.Pick }},
+	{action: "reword", key: func(k *Keymap) *string { return &k.Reword }},
+	{action: "edit-commit", key: func(k *Keymap) *string { return &k.EditCommit }},
+	{action: "squash", key: func(k *Keymap) *string { return &k.Squash }},
+	{action: "fixup", key: func(k *Keymap) *string { return &k.Fixup }},
+	{action: "drop", key: func(k *Keymap) *string { return &k.Drop }},
+	{action: "continue", key: func(k *Keymap) *string { return &k.Continue }},
+	{action: "skip", key: func(k *Keymap) *string { return &k.Skip }},
+	{action: "abort", key: func(k *Keymap) *string { return &k.Abort }},
+	{action: "stash-apply", key: func(k *Keymap) *string { return &k.StashApply }},
+	{action: "stash-apply-file", key: func(k *Keymap) *string { return &k.StashApplyFile }},
+	{action: "stash-pop", key: func(k *Keymap) *string { return &k.StashPop }},
+	{action: "stash-drop", key: func(k *Keymap) *string { return &k.StashDrop }},
+	{action: "stash-rename", key: func(k *Keymap) *string { return &k.StashRename }},
+	{action: "stash-compare", key: func(k *Keymap) *string { return &k.StashCompare }},
+	{action: "new-tag", key: func(k *Keymap) *string { return &k.NewTag }},
+	{action: "prune", key: func(k *Keymap) *string { return &k.Prune }},
+	{action: "submodule-init", key: func(k *Keymap) *string { return &k.SubmoduleInit }},
+	{action: "submodule-update", key: func(k *Keymap) *string { return &k.SubmoduleUpdate }},
+	{action: "submodule-sync", key: func(k *Keymap) *string { return &k.SubmoduleSync }},
 	{action: "refresh", key: func(k *Keymap) *string { return &k.Refresh }},
+	{action: "ours", key: func(k *Keymap) *string { return &k.Ours }},
+	{action: "theirs", key: func(k *Keymap) *string { return &k.Theirs }},
+	{action: "both", key: func(k *Keymap) *string { return &k.Both }},
+	{action: "split", key: func(k *Keymap) *string { return &k.Split }},
+	{action: "edit-hunk", key: func(k *Keymap) *string { return &k.EditHunk }},
+	{action: "stash-hunks", key: func(k *Keymap) *string { return &k.StashHunks }},
 }
 
 // DefaultKeymap returns the default key bindings
@@ -102,24 +218,33 @@ func DefaultKeymap() *Keymap {
 		Quit:   "q",
 
 		// Actions
-		Stage:      "a",
-		StageAll:   "A",
-		Unstage:    "u",
-		UnstageAll: "U",
-		Discard:    "d",
-		Commit:     "c",
-		CommitEdit: "C",
-		Push:       "p",
-		Stash:      "s",
-		StashAll:   "S",
+		Stage:       "a",
+		StageAll:    "A",
+		Unstage:     "u",
+		UnstageAll:  "U",
+		Discard:     "d",
+		Commit:      "c",
+		CommitEdit:  "C",
+		Amend:       "m",
+		AmendNoEdit: "M",
+		Push:        "p",
+		Fetch:       "F",
+		Pull:        "P",
+		Stash:       "s",
+		StashAll:    "S",
 
 		// Views
-		FileDiff: "l",
-		AllDiffs: "i",
-		FullDiff: "f",
-		Branches: "b",
-		Stashes:  "t",
-		Log:      "L",
+		FileDiff:   "l",
+		AllDiffs:   "i",
+		FullDiff:   "f",
+		Branches:   "b",
+		Stashes:    "t",
+		Log:        "L",
+		Tags:       "T",
+		Worktrees:  "W",
+		Submodules: "O",
+		Reflog:     "H",
+		Discarded:  "D",
 
 		// Other
 		Refresh: "r",
@@ -131,6 +256,65 @@ func DefaultKeymap() *Keymap {
 		VerboseHelp: "/",
 		NewBranch:   "n",
 		Delete:      "d",
+
+		// Branches
+		RemoteBranches: "tab",
+		RenameBranch:   "R",
+		SetUpstream:    "u",
+		UnsetUpstream:  "U",
+		Merge:          "M",
+		Rebase:         "r",
+
+		// History
+		CherryPick: "c",
+		Revert:     "R",
+		Reset:      "x",
+		Undo:       "z",
+		Restore:    "r",
+
+		// Rebase planner
+		MoveUp:     "K",
+		MoveDown:   "J",
+		Pick:       "p",
+		Reword:     "r",
+		EditCommit: "e",
+		Squash:     "s",
+		Fixup:      "f",
+		Drop:       "d",
+
+		// Operations
+		Continue: "+",
+		Skip:     ">",
+		Abort:    "X",
+
+		// Stashes
+		StashApply:     "a",
+		StashApplyFile: "A",
+		StashPop:       "p",
+		StashDrop:      "d",
+		StashRename:    "R",
+		StashCompare:   "c",
+
+		// Tags
+		NewTag: "n",
+
+		// Worktrees
+		Prune: "P",
+
+		// Submodules
+		SubmoduleInit:   "i",
+		SubmoduleUpdate: "u",
+		SubmoduleSync:   "s",
+
+		// Conflicts
+		Ours:   "o",
+		Theirs: "t",
+		Both:   "b",
+
+		// Hunks
+		Split:      "s",
+		EditHunk:   "E",
+		StashHunks: "S",
 	}
 }
 
@@ -52,9 +52,21 @@ func TestDefaultKeymap(t *testing.T) {
 	if km.CommitEdit != "C" {
 		t.Errorf("expected CommitEdit to be 'C', got %q", km.CommitEdit)
 	}
+	if km.Amend != "m" {
+		t.Errorf("expected Amend to be 'm', got %q", km.Amend)
+	}
+	if km.AmendNoEdit != "M" {
+		t.Errorf("expected AmendNoEdit to be 'M', got %q", km.AmendNoEdit)
+	}
 	if km.Push != "p" {
 		t.Errorf("expected Push to be 'p', got %q", km.Push)
 	}
+	if km.Fetch != "F" {
+		t.Errorf("expected Fetch to be 'F', got %q", km.Fetch)
+	}
+	if km.Pull != "P" {
+		t.Errorf("expected Pull to be 'P', got %q", km.Pull)
+	}
 	if km.Stash != "s" {
 		t.Errorf("expected Stash to be 's', got %q", km.Stash)
 	}
@@ -78,6 +90,21 @@ func TestDefaultKeymap(t *testing.T) {
 	if km.Log != "L" {
 		t.Errorf("expected Log to be 'L', got %q", km.Log)
 	}
+	if km.Tags != "T" {
+		t.Errorf("expected Tags to be 'T', got %q", km.Tags)
+	}
+	if km.Worktrees != "W" {
+		t.Errorf("expected Worktrees to be 'W', got %q", km.Worktrees)
+	}
+	if km.Submodules != "O" {
+		t.Errorf("expected Submodules to be 'O', got %q", km.Submodules)
+	}
+	if km.Reflog != "H" {
+		t.Errorf("expected Reflog to be 'H', got %q", km.Reflog)
+	}
+	if km.Discarded != "D" {
+		t.Errorf("expected Discarded to be 'D', got %q", km.Discarded)
+	}
 
 	// Test mode keys
 	if km.Visual != "v" {
@@ -98,6 +125,135 @@ func TestDefaultKeymap(t *testing.T) {
 	if km.Delete != "d" {
 		t.Errorf("expected Delete to be 'd', got %q", km.Delete)
 	}
+	if km.RemoteBranches != "tab" {
+		t.Errorf("expected RemoteBranches to be 'tab', got %q", km.RemoteBranches)
+	}
+	if km.RenameBranch != "R" {
+		t.Errorf("expected RenameBranch to be 'R', got %q", km.RenameBranch)
+	}
+	if km.SetUpstream != "u" {
+		t.Errorf("expected SetUpstream to be 'u', got %q", km.SetUpstream)
+	}
+	if km.UnsetUpstream != "U" {
+		t.Errorf("expected UnsetUpstream to be 'U', got %q", km.UnsetUpstream)
+	}
+	if km.Merge != "M" {
+		t.Errorf("expected Merge to be 'M', got %q", km.Merge)
+	}
+	if km.Rebase != "r" {
+		t.Errorf("expected Rebase to be 'r', got %q", km.Rebase)
+	}
+	if km.CherryPick != "c" {
+		t.Errorf("expected CherryPick to be 'c', got %q", km.CherryPick)
+	}
+	if km.Revert != "R" {
+		t.Errorf("expected Revert to be 'R', got %q", km.Revert)
+	}
+	if km.Reset != "x" {
+		t.Errorf("expected Reset to be 'x', got %q", km.Reset)
+	}
+	if km.Undo != "z" {
+		t.Errorf("expected Undo to be 'z', got %q", km.Undo)
+	}
+	if km.Restore != "r" {
+		t.Errorf("expected Restore to be 'r', got %q", km.Restore)
+	}
+	if km.MoveUp != "K" {
+		t.Errorf("expected MoveUp to be 'K', got %q", km.MoveUp)
+	}
+	if km.MoveDown != "J" {
+		t.Errorf("expected MoveDown to be 'J', got %q", km.MoveDown)
+	}
+	if km.Pick != "p" {
+		t.Errorf("expected Pick to be 'p', got %q", km.Pick)
+	}
+	if km.Reword != "r" {
+		t.Errorf("expected Reword to be 'r', got %q", km.Reword)
+	}
+	if km.EditCommit != "e" {
+		t.Errorf("expected EditCommit to be 'e', got %q", km.EditCommit)
+	}
+	if km.Squash != "s" {
+		t.Errorf("expected Squash to be 's', got %q", km.Squash)
+	}
+	if km.Fixup != "f" {
+		t.Errorf("expected Fixup to be 'f', got %q", km.Fixup)
+	}
+	if km.Drop != "d" {
+		t.Errorf("expected Drop to be 'd', got %q", km.Drop)
+	}
+	if km.Continue != "+" {
+		t.Errorf("expected Continue to be '+', got %q", km.Continue)
+	}
+	if km.Skip != ">" {
+		t.Errorf("expected Skip to be '>', got %q", km.Skip)
+	}
+	if km.Abort != "X" {
+		t.Errorf("expected Abort to be 'X', got %q", km.Abort)
+	}
+
+	// Test stash keys
+	if km.StashApply != "a" {
+		t.Errorf("expected StashApply to be 'a', got %q", km.StashApply)
+	}
+	if km.StashApplyFile != "A" {
+		t.Errorf("expected StashApplyFile to be 'A', got %q", km.StashApplyFile)
+	}
+	if km.StashPop != "p" {
+		t.Errorf("expected StashPop to be 'p', got %q", km.StashPop)
+	}
+	if km.StashDrop != "d" {
+		t.Errorf("expected StashDrop to be 'd', got %q", km.StashDrop)
+	}
+	if km.StashRename != "R" {
+		t.Errorf("expected StashRename to be 'R', got %q", km.StashRename)
+	}
+	if km.StashCompare != "c" {
+		t.Errorf("expected StashCompare to be 'c', got %q", km.StashCompare)
+	}
+
+	// Test tag keys
+	if km.NewTag != "n" {
+		t.Errorf("expected NewTag to be 'n', got %q", km.NewTag)
+	}
+
+	// Test worktree keys
+	if km.Prune != "P" {
+		t.Errorf("expected Prune to be 'P', got %q", km.Prune)
+	}
+
+	// Test submodule keys
+	if km.SubmoduleInit != "i" {
+		t.Errorf("expected SubmoduleInit to be 'i', got %q", km.SubmoduleInit)
+	}
+	if km.SubmoduleUpdate != "u" {
+		t.Errorf("expected SubmoduleUpdate to be 'u', got %q", km.SubmoduleUpdate)
+	}
+	if km.SubmoduleSync != "s" {
+		t.Errorf("expected SubmoduleSync to be 's', got %q", km.SubmoduleSync)
+	}
+
+	// Test conflict keys
+	if km.Ours != "o" {
+		t.Errorf("expected Ours to be 'o', got %q", km.Ours)
+	}
+	if km.Theirs != "t" {
+		t.Errorf("expected Theirs to be 't', got %q", km.Theirs)
+	}
+	if km.Both != "b" {
+		t.Errorf("expected Both to be 'b', got %q", km.Both)
+	}
+
+	// Test hunk keys
+	if km.Split != "s" {
+		t.Errorf("expected Split to be 's', got %q", km.Split)
+	}
+	if km.EditHunk != "E" {
+		t.Errorf("expected EditHunk to be 'E', got %q", km.EditHunk)
+	}
+	if km.StashHunks != "S" {
+		t.Errorf("expected StashHunks to be 'S', got %q", km.StashHunks)
+	}
 }
 
 func TestParseKeymapArg(t *testing.T) {
@@ -255,9 +411,17 @@ func TestListKeymapActions(t *testing.T) {
 		"up", "down", "left", "right", "top", "bottom",
 		"select", "back", "quit",
 		"stage", "stage-all", "unstage", "unstage-all", "discard",
-		"commit", "commit-edit", "push", "stash", "stash-all",
-		"file-diff", "all-diffs", "branches", "stashes", "log",
+		"commit", "commit-edit", "amend", "amend-no-edit", "push", "fetch", "pull", "stash", "stash-all",
+		"file-diff", "all-diffs", "branches", "stashes", "log", "tags", "worktrees", "submodules", "reflog", "discarded",
 		"visual", "help", "verbose-help", "new-branch", "delete",
+		"remote-branches", "rename-branch", "set-upstream", "unset-upstream",
+		"merge", "rebase", "cherry-pick", "revert", "reset", "undo", "restore",
+		"move-up", "move-down", "pick", "reword", "edit-commit", "squash", "fixup", "drop",
+		"continue", "skip", "abort",
+		"stash-apply", "stash-apply-file", "stash-pop", "stash-drop", "stash-rename", "stash-compare",
+		"new-tag", "prune",
+		"submodule-init", "submodule-update", "submodule-sync",
+		"ours", "theirs", "both", "split", "edit-hunk", "stash-hunks",
 	}
 
 	actionSet := make(map[string]bool)
@@ -424,7 +588,11 @@ func TestKeymapBindingsComplete(t *testing.T) {
 		{"discard", func(k *Keymap) string { return k.Discard }},
 		{"commit", func(k *Keymap) string { return k.Commit }},
 		{"commit-edit", func(k *Keymap) string { return k.CommitEdit }},
+		{"amend", func(k *Keymap) string { return k.Amend }},
+		{"amend-no-edit", func(k *Keymap) string { return k.AmendNoEdit }},
 		{"push", func(k *Keymap) string { return k.Push }},
+		{"fetch", func(k *Keymap) string { return k.Fetch }},
+		{"pull", func(k *Keymap) string { return k.Pull }},
 		{"stash", func(k *Keymap) string { return k.Stash }},
 		{"stash-all", func(k *Keymap) string { return k.StashAll }},
 		{"file-diff", func(k *Keymap) string { return k.FileDiff }},
@@ -432,11 +600,55 @@ func TestKeymapBindingsComplete(t *testing.T) {
 		{"branches", func(k *Keymap) string { return k.Branches }},
 		{"stashes", func(k *Keymap) string { return k.Stashes }},
 		{"log", func(k *Keymap) string { return k.Log }},
+		{"tags", func(k *Keymap) string { return k.Tags }},
+		{"worktrees", func(k *Keymap) string { return k.Worktrees }},
+		{"submodules", func(k *Keymap) string { return k.Submodules }},
+		{"reflog", func(k *Keymap) string { return k.Reflog }},
+		{"discarded", func(k *Keymap) string { return k.Discarded }},
 		{"visual", func(k *Keymap) string { return k.Visual }},
 		{"help", func(k *Keymap) string { return k.Help }},
 		{"verbose-help", func(k *Keymap) string { return k.VerboseHelp }},
 		{"new-branch", func(k *Keymap) string { return k.NewBranch }},
 		{"delete", func(k *Keymap) string { return k.Delete }},
+		{"remote-branches", func(k *Keymap) string { return k.RemoteBranches }},
+		{"rename-branch", func(k *Keymap) string { return k.RenameBranch }},
+		{"set-upstream", func(k *Keymap) string { return k.SetUpstream }},
+		{"unset-upstream", func(k *Keymap) string { return k.UnsetUpstream }},
+		{"merge", func(k *Keymap) string { return k.Merge }},
+		{"rebase", func(k *Keymap) string { return k.Rebase }},
+		{"cherry-pick", func(k *Keymap) string { return k.CherryPick }},
+		{"revert", func(k *Keymap) string { return k.Revert }},
+		{"reset", func(k *Keymap) string { return k.Reset }},
+		{"undo", func(k *Keymap) string { return k.Undo }},
+		{"restore", func(k *Keymap) string { return k.Restore }},
+		{"move-up", func(k *Keymap) string { return k.MoveUp }},
+		{"move-down", func(k *Keymap) string { return k.MoveDown }},
+		{"pick", func(k *Keymap) string { return k.Pick }},
+		{"reword", func(k *Keymap) string { return k.Reword }},
+		{"edit-commit", func(k *Keymap) string { return k.EditCommit }},
+		{"squash", func(k *Keymap) string { return k.Squash }},
+		{"fixup", func(k *Keymap) string { return k.Fixup }},
+		{"drop", func(k *Keymap) string { return k.Drop }},
+		{"continue", func(k *Keymap) string { return k.Continue }},
+		{"skip", func(k *Keymap) string { return k.Skip }},
+		{"abort", func(k *Keymap) string { return k.Abort }},
+		{"stash-apply", func(k *Keymap) string { return k.StashApply }},
+		{"stash-apply-file", func(k *Keymap) string { return k.StashApplyFile }},
+		{"stash-pop", func(k *Keymap) string { return k.StashPop }},
+		{"stash-drop", func(k *Keymap) string { return k.StashDrop }},
+		{"stash-rename", func(k *Keymap) string { return k.StashRename }},
+		{"stash-compare", func(k *Keymap) string { return k.StashCompare }},
+		{"new-tag", func(k *Keymap) string { return k.NewTag }},
+		{"prune", func(k *Keymap) string { return k.Prune }},
+		{"submodule-init", func(k *Keymap) string { return k.SubmoduleInit }},
+		{"submodule-update", func(k *Keymap) string { return k.SubmoduleUpdate }},
+		{"submodule-sync", func(k *Keymap) string { return k.SubmoduleSync }},
+		{"ours", func(k *Keymap) string { return k.Ours }},
+		{"theirs", func(k *Keymap) string { return k.Theirs }},
+		{"both", func(k *Keymap) string { return k.Both }},
+		{"split", func(k *Keymap) string { return k.Split }},
+		{"edit-hunk", func(k *Keymap) string { return k.EditHunk }},
+		{"stash-hunks", func(k *Keymap) string { return k.StashHunks }},
 	}
 
 	for _, tc := range testCases {
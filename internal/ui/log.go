@@ -3,22 +3,49 @@ package ui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"go-on-git/internal/git"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// logLimit is the maximum number of commits loaded into the log view
+const logLimit = 100
+
+// logConfirm is the action the log view asks to confirm
+type logConfirm int
+
+const (
+	logConfirmNone logConfirm = iota
+	logConfirmCherryPick
+	logConfirmRevert
+	logConfirmHardReset // requires typing 'yes'
+)
+
+// resetModes are the options of the reset picker
+var resetModes = []git.ResetMode{git.ResetSoft, git.ResetMixed, git.ResetHard}
 
 // LogModel is the bubbletea model for the log view
 type LogModel struct {
-	lines           []string
-	scrollOffset    int
-	showHelp        bool
-	showVerboseHelp bool
-	err             error
-	width           int
-	height          int
+	commits          []git.CommitInfo
+	graph            []graphEntry // graph lines for each commit
+	graphWidth       int
+	loaded           bool
+	cursor           int
+	scrollOffset     int
+	visualMode       bool
+	visualStart      int
+	confirmMode      logConfirm
+	hardResetConfirm typedConfirm
+	resetMode        bool // choosing soft/mixed/hard
+	resetPicker      picker
+	showHelp         bool
+	showVerboseHelp  bool
+	lastKey          string
+	err              error
+	width            int
+	height           int
 }
 
 // NewLogModel creates a new log model
@@ -44,15 +71,15 @@ func NewLogModelWithOptions(width, height int, showVerboseHelp bool) LogModel {
 }
 
 type logMsg struct {
-	content string
+	commits []git.CommitInfo
 }
 
 func refreshLog() tea.Msg {
-	content, err := git.GetLog(100)
+	commits, err := git.GetCommits(git.LogOptions{Limit: logLimit, TopoOrder: true})
 	if err != nil {
 		return errMsg{err}
 	}
-	return logMsg{content}
+	return logMsg{commits}
 }
 
 // Init initializes the model
@@ -60,6 +87,45 @@ func (m LogModel) Init() tea.Cmd {
 	return refreshLog
 }
 
+// SelectedCommit returns the commit under the cursor, if any
+func (m LogModel) SelectedCommit() (git.CommitInfo, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.commits) {
+		return git.CommitInfo{}, false
+	}
+	return m.commits[m.cursor], true
+}
+
+// selectedCommits returns the commits in the visual selection, or the one
+// under the cursor, newest first
+func (m LogModel) selectedCommits() []git.CommitInfo {
+	if len(m.commits) == 0 {
+		return nil
+	}
+	start, end := m.cursor, m.cursor
+	if m.visualMode {
+		start, end = min(m.visualStart, m.cursor), max(m.visualStart, m.cursor)
+	}
+	end = min(end, len(m.commits)-1)
+	return m.commits[start : end+1]
+}
+
+// refuseMerges returns an error if any of the commits is a merge, which git
+// can only cherry-pick or revert given the parent to diff against
+func refuseMerges(commits []git.CommitInfo, action string) error {
+	for _, commit := range commits {
+		if commit.IsMerge() {
+			return fmt.Errorf("can't %s merge commit %s, use git %s -m <parent> instead", action, commit.ShortHash, action)
+		}
+	}
+	return nil
+}
+
+// inPrompt returns true while a confirmation, the reset picker or visual
+// mode is active, so navigation keys stay in the log view
+func (m LogModel) inPrompt() bool {
+	return m.confirmMode != logConfirmNone || m.resetMode || m.visualMode
+}
+
 // Update handles messages
 func (m LogModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -74,54 +140,163 @@ func (m LogModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
-		// Account for header (2 lines) and optionally help bar (2 lines)
-		reservedLines := 4
-		if m.showVerboseHelp {
-			reservedLines = 6
+		// Handle the reset mode picker
+		if m.resetMode {
+			if m.resetPicker.move(key) {
+				return m, nil
+			}
+			switch key {
+			case "enter":
+				m.resetMode = false
+				mode := resetModes[m.resetPicker.cursor]
+				if mode == git.ResetHard {
+					m.confirmMode = logConfirmHardReset
+					m.hardResetConfirm = typedConfirm{}
+					return m, nil
+				}
+				return m, m.doReset(mode)
+			case "esc", Keys.Quit:
+				m.resetMode = false
+			}
+			return m, nil
+		}
+
+		// Handle confirm mode
+		if m.confirmMode == logConfirmHardReset {
+			switch m.hardResetConfirm.update(key) {
+			case typedConfirmAccepted:
+				m.confirmMode = logConfirmNone
+				return m, m.doReset(git.ResetHard)
+			case typedConfirmCancelled:
+				m.confirmMode = logConfirmNone
+			}
+			return m, nil
 		}
-		visibleLines := m.height - reservedLines
-		if visibleLines < 1 {
-			visibleLines = 10
+		if m.confirmMode != logConfirmNone {
+			switch key {
+			case "y", "Y":
+				action := m.confirmMode
+				commits := m.selectedCommits()
+				m.confirmMode = logConfirmNone
+				m.visualMode = false
+				if action == logConfirmRevert {
+					return m, m.doRevert(commits)
+				}
+				return m, m.doCherryPick(commits)
+			case "n", "N", "esc":
+				m.confirmMode = logConfirmNone
+			}
+			return m, nil
+		}
+
+		// Check for gg sequence
+		if m.lastKey == Keys.Top && key == Keys.Top {
+			m.lastKey = ""
+			m.cursor = 0
+			m.ensureCursorVisible()
+			return m, nil
 		}
-		maxOffset := len(m.lines) - visibleLines
-		if maxOffset < 0 {
-			maxOffset = 0
+
+		if key == Keys.Top {
+			m.lastKey = Keys.Top
+			return m, nil
 		}
+		m.lastKey = ""
 
 		switch key {
 		case Keys.Help:
 			m.showHelp = true
 			return m, nil
+		case "esc", Keys.Left, "left", Keys.Quit:
+			// Only reached in visual mode, otherwise these go back to the status view
+			m.visualMode = false
+			return m, nil
+		case Keys.Visual:
+			if len(m.commits) > 0 {
+				m.visualMode = !m.visualMode
+				m.visualStart = m.cursor
+			}
+			return m, nil
+		case Keys.CherryPick:
+			if len(m.commits) > 0 {
+				m.err = refuseMerges(m.selectedCommits(), "cherry-pick")
+				if m.err == nil {
+					m.confirmMode = logConfirmCherryPick
+				}
+			}
+			return m, nil
+		case Keys.Revert:
+			if len(m.commits) > 0 {
+				m.err = refuseMerges(m.selectedCommits(), "revert")
+				if m.err == nil {
+					m.confirmMode = logConfirmRevert
+				}
+			}
+			return m, nil
+		case Keys.Reset:
+			if commit, ok := m.SelectedCommit(); ok {
+				// Reset moves HEAD to a single commit: the one under the cursor
+				m.visualMode = false
+				m.err = nil
+				m.resetMode = true
+				options := make([]string, len(resetModes))
+				for i, mode := range resetModes {
+					options[i] = mode.String()
+				}
+				m.resetPicker = newPicker(fmt.Sprintf("Reset HEAD to %s:", commit.ShortHash), options, 1)
+			}
+			return m, nil
 		case Keys.VerboseHelp:
 			m.showVerboseHelp = !m.showVerboseHelp
+			m.ensureCursorVisible()
 			return m, nil
 		case Keys.Down, "down":
-			m.scrollOffset = min(m.scrollOffset+1, maxOffset)
+			if len(m.commits) > 0 {
+				m.cursor = min(m.cursor+1, len(m.commits)-1)
+				m.ensureCursorVisible()
+			}
 			return m, nil
 		case Keys.Up, "up":
-			m.scrollOffset = max(m.scrollOffset-1, 0)
+			if len(m.commits) > 0 {
+				m.cursor = max(m.cursor-1, 0)
+				m.ensureCursorVisible()
+			}
 			return m, nil
 		case Keys.Bottom:
-			m.scrollOffset = maxOffset
-			return m, nil
-		case Keys.Top:
-			m.scrollOffset = 0
+			if len(m.commits) > 0 {
+				m.cursor = len(m.commits) - 1
+				m.ensureCursorVisible()
+			}
 			return m, nil
 		case "ctrl+d":
-			m.scrollOffset = min(m.scrollOffset+visibleLines/2, maxOffset)
+			if len(m.commits) > 0 {
+				m.cursor = min(m.cursor+m.visibleLines()/2, len(m.commits)-1)
+				m.ensureCursorVisible()
+			}
 			return m, nil
 		case "ctrl+u":
-			m.scrollOffset = max(m.scrollOffset-visibleLines/2, 0)
+			m.cursor = max(m.cursor-m.visibleLines()/2, 0)
+			m.ensureCursorVisible()
 			return m, nil
 		}
 
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		m.ensureCursorVisible()
 		return m, nil
 
 	case logMsg:
-		m.lines = strings.Split(msg.content, "\n")
+		m.visualMode = false
+		m.commits = msg.commits
+		m.graph = buildGraph(msg.commits)
+		m.graphWidth = graphWidth(m.graph)
+		m.loaded = true
+		m.err = nil
+		if m.cursor >= len(m.commits) {
+			m.cursor = max(0, len(m.commits)-1)
+		}
+		m.ensureCursorVisible()
 		return m, nil
 
 	case errMsg:
@@ -132,6 +307,110 @@ func (m LogModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// doCherryPick applies the commits onto HEAD, oldest first
+func (m LogModel) doCherryPick(commits []git.CommitInfo) tea.Cmd {
+	hashes := make([]string, len(commits))
+	for i, c := range commits {
+		hashes[len(commits)-1-i] = c.Hash
+	}
+	return func() tea.Msg {
+		return historyResult(git.CherryPick(hashes...))
+	}
+}
+
+// doRevert reverts the commits, newest first
+func (m LogModel) doRevert(commits []git.CommitInfo) tea.Cmd {
+	hashes := make([]string, len(commits))
+	for i, c := range commits {
+		hashes[i] = c.Hash
+	}
+	return func() tea.Msg {
+		return historyResult(git.Revert(hashes...))
+	}
+}
+
+// doReset moves HEAD to the commit under the cursor
+func (m LogModel) doReset(mode git.ResetMode) tea.Cmd {
+	commit, ok := m.SelectedCommit()
+	if !ok {
+		return nil
+	}
+	return func() tea.Msg {
+		if err := git.Reset(commit.Hash, mode); err != nil {
+			return errMsg{err}
+		}
+		return refreshLog()
+	}
+}
+
+// historyResult reloads the log after a cherry-pick or revert, or hands a
+// stopped one over to the status view to resolve the conflicts
+func historyResult(err error) tea.Msg {
+	if err == nil {
+		return refreshLog()
+	}
+	if git.GetOperation().InProgress() {
+		return operationStoppedMsg{err: err}
+	}
+	return errMsg{err}
+}
+
+// visibleLines returns the number of commit lines that can be displayed
+func (m LogModel) visibleLines() int {
+	// Account for header (2 lines), blank line and optionally help bar (2 lines)
+	reserved := 4
+	if m.showVerboseHelp {
+		reserved = 6
+	}
+	if m.inPrompt() {
+		reserved += 2
+	}
+	if m.err != nil {
+		reserved++
+	}
+	if m.height <= reserved {
+		return 20 // fallback minimum
+	}
+	return m.height - reserved
+}
+
+// entryLines returns the number of display lines used by a commit
+// (its own row plus any graph connector rows below it)
+func (m LogModel) entryLines(i int) int {
+	if i < len(m.graph) {
+		return m.graph[i].lineCount()
+	}
+	return 1
+}
+
+// linesBetween returns the display lines used by commits [from, to)
+func (m LogModel) linesBetween(from, to int) int {
+	lines := 0
+	for i := from; i < to; i++ {
+		lines += m.entryLines(i)
+	}
+	return lines
+}
+
+// ensureCursorVisible adjusts scrollOffset to keep cursor in view
+func (m *LogModel) ensureCursorVisible() {
+	visible := m.visibleLines()
+
+	if m.cursor < m.scrollOffset {
+		m.scrollOffset = m.cursor
+	}
+
+	// Scroll down until the cursor row fits
+	for m.scrollOffset < m.cursor && m.linesBetween(m.scrollOffset, m.cursor)+1 > visible {
+		m.scrollOffset++
+	}
+
+	// Don't leave empty space at the bottom when scrolled
+	for m.scrollOffset > 0 && m.linesBetween(m.scrollOffset-1, len(m.commits)) <= visible {
+		m.scrollOffset--
+	}
+}
+
 // View renders the log view
 func (m LogModel) View() string {
 	if m.showHelp {
@@ -144,7 +423,7 @@ func (m LogModel) View() string {
 	content.WriteString(m.renderHeader())
 	content.WriteString("\n\n")
 
-	if m.err != nil {
+	if m.err != nil && !m.loaded {
 		content.WriteString(StyleUnstaged.Render(fmt.Sprintf("Error: %v", m.err)))
 		content.WriteString("\n")
 		if m.showVerboseHelp {
@@ -154,8 +433,12 @@ func (m LogModel) View() string {
 		return m.anchorBottom(content.String())
 	}
 
-	if len(m.lines) == 0 {
-		content.WriteString(StyleMuted.Render("Loading..."))
+	if !m.loaded || len(m.commits) == 0 {
+		if m.loaded {
+			content.WriteString(StyleEmpty.Render("No commits yet"))
+		} else {
+			content.WriteString(StyleMuted.Render("Loading..."))
+		}
 		content.WriteString("\n")
 		if m.showVerboseHelp {
 			content.WriteString("\n")
@@ -164,30 +447,70 @@ func (m LogModel) View() string {
 		return m.anchorBottom(content.String())
 	}
 
-	// Account for header (2 lines) and optionally help bar (2 lines)
-	reservedLines := 4
-	if m.showVerboseHelp {
-		reservedLines = 6
-	}
-	visibleLines := m.height - reservedLines
-	if visibleLines < 1 {
-		visibleLines = 20
+	if m.err != nil {
+		// An action failed: show the error above the commits
+		content.WriteString(StyleUnstaged.Render(fmt.Sprintf("Error: %v", m.err)))
+		content.WriteString("\n")
 	}
 
-	endIdx := m.scrollOffset + visibleLines
-	if endIdx > len(m.lines) {
-		endIdx = len(m.lines)
-	}
+	visible := m.visibleLines()
+	lines := 0
+	now := time.Now()
+	selStart, selEnd := min(m.visualStart, m.cursor), max(m.visualStart, m.cursor)
+
+	for i := m.scrollOffset; i < len(m.commits) && lines < visible; i++ {
+		c := m.commits[i]
+		selected := m.visualMode && i >= selStart && i <= selEnd
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+		}
 
-	for i := m.scrollOffset; i < endIdx; i++ {
-		line := m.lines[i]
-		if strings.HasPrefix(line, "commit ") {
-			content.WriteString(StyleStaged.Render(line))
-		} else if strings.HasPrefix(line, "Author:") || strings.HasPrefix(line, "Date:") {
-			content.WriteString(StyleMuted.Render(line))
+		if selected {
+			content.WriteString(StyleVisual.Render(prefix))
+		} else {
+			content.WriteString(prefix)
+		}
+		if i < len(m.graph) {
+			content.WriteString(m.graph[i].commit.render(m.graphWidth))
+			content.WriteString(" ")
+		}
+		if selected {
+			content.WriteString(StyleCommitHash.Inherit(StyleVisual).Render(c.ShortHash))
+		} else {
+			content.WriteString(StyleCommitHash.Render(c.ShortHash))
+		}
+		if refs := renderRefs(c.Refs); refs != "" {
+			content.WriteString(" ")
+			content.WriteString(refs)
+		}
+		content.WriteString(" ")
+		if selected {
+			content.WriteString(StyleVisual.Render(c.Subject))
 		} else {
-			content.WriteString(line)
+			content.WriteString(c.Subject)
+		}
+		content.WriteString(StyleMuted.Render(fmt.Sprintf(" - %s, %s", c.AuthorName, formatRelativeTime(c.AuthorDate, now))))
+		content.WriteString("\n")
+		lines++
+
+		// Graph connector rows leading to the next commit
+		if i < len(m.graph) {
+			for _, row := range m.graph[i].connectors {
+				if lines >= visible {
+					break
+				}
+				content.WriteString("  ")
+				content.WriteString(row.render(m.graphWidth))
+				content.WriteString("\n")
+				lines++
+			}
 		}
+	}
+
+	if prompt := m.renderPrompt(); prompt != "" {
+		content.WriteString("\n")
+		content.WriteString(prompt)
 		content.WriteString("\n")
 	}
 
@@ -199,6 +522,92 @@ func (m LogModel) View() string {
 	return m.anchorBottom(content.String())
 }
 
+// renderPrompt renders the active confirmation, the reset picker or the
+// visual mode indicator
+func (m LogModel) renderPrompt() string {
+	commits := m.selectedCommits()
+	target := fmt.Sprintf("%d commits", len(commits))
+	if len(commits) == 1 {
+		target = commits[0].ShortHash
+	}
+
+	switch {
+	case m.resetMode:
+		return m.resetPicker.View()
+	case m.confirmMode == logConfirmCherryPick:
+		return StyleConfirm.Render(fmt.Sprintf("Cherry-pick %s onto HEAD? (y/n) ", target))
+	case m.confirmMode == logConfirmRevert:
+		return StyleConfirm.Render(fmt.Sprintf("Revert %s? (y/n) ", target))
+	case m.confirmMode == logConfirmHardReset:
+		return StyleConfirm.Render(fmt.Sprintf("Hard reset to %s? Uncommitted changes will be lost. Type 'yes' to confirm: %s", target, m.hardResetConfirm.input))
+	case m.visualMode:
+		return StyleVisual.Render("-- VISUAL --")
+	}
+	return ""
+}
+
+// renderRefs renders ref decorations like git log --decorate
+// (e.g., "(HEAD -> main, origin/main, tag: v1.0)")
+func renderRefs(refs []git.Ref) string {
+	if len(refs) == 0 {
+		return ""
+	}
+
+	parts := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		switch ref.Type {
+		case git.RefHead:
+			parts = append(parts, StyleRefHead.Render(ref.Name))
+		case git.RefBranch:
+			name := StyleRefBranch.Render(ref.Name)
+			if ref.IsHead {
+				name = StyleRefHead.Render("HEAD -> ") + name
+			}
+			parts = append(parts, name)
+		case git.RefRemoteBranch:
+			parts = append(parts, StyleRefRemote.Render(ref.Name))
+		case git.RefTag:
+			parts = append(parts, StyleRefTag.Render("tag: "+ref.Name))
+		default:
+			parts = append(parts, StyleMuted.Render(ref.Name))
+		}
+	}
+
+	return StyleCommitHash.Render("(") + strings.Join(parts, StyleCommitHash.Render(", ")) + StyleCommitHash.Render(")")
+}
+
+// formatRelativeTime formats t relative to now (e.g., "3 days ago")
+func formatRelativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	if d < 0 {
+		d = 0
+	}
+
+	plural := func(n int, unit string) string {
+		if n == 1 {
+			return fmt.Sprintf("1 %s ago", unit)
+		}
+		return fmt.Sprintf("%d %ss ago", n, unit)
+	}
+
+	switch {
+	case d < time.Minute:
+		return plural(int(d.Seconds()), "second")
+	case d < time.Hour:
+		return plural(int(d.Minutes()), "minute")
+	case d < 24*time.Hour:
+		return plural(int(d.Hours()), "hour")
+	case d < 14*24*time.Hour:
+		return plural(int(d.Hours()/24), "day")
+	case d < 60*24*time.Hour:
+		return plural(int(d.Hours()/(24*7)), "week")
+	case d < 365*24*time.Hour:
+		return plural(int(d.Hours()/(24*30)), "month")
+	default:
+		return plural(int(d.Hours()/(24*365)), "year")
+	}
+}
+
 func (m LogModel) renderHeader() string {
 	return StyleMuted.Render("> git log") + "  " + StyleMuted.Render("(esc to go back)") + "\n" + StyleMuted.Render("───────────────────────────────────────────────────────────────")
 }
@@ -219,8 +628,12 @@ func (m LogModel) renderHelpBar() string {
 	sb.WriteString("\n")
 
 	items := []struct{ key, desc string }{
-		{formatKeyList(Keys.Down, Keys.Up), "scroll"},
-		{formatKeyList(Keys.Top, Keys.Bottom), "top/bottom"},
+		{formatKeyList(Keys.Down, Keys.Up), "navigate"},
+		{formatKeyList(Keys.Right, "Enter"), "show commit"},
+		{Keys.CherryPick, "cherry-pick"},
+		{Keys.Revert, "revert"},
+		{Keys.Reset, "reset"},
+		{Keys.Rebase, "rebase -i"},
 		{"ctrl+d/u", "page down/up"},
 		{Keys.Help, "help"},
 		{formatKeyList(Keys.Left, "ESC"), "back"},
@@ -244,17 +657,24 @@ func (m LogModel) renderHelp() string {
 
 	moveKeys := formatKeyList(Keys.Down, Keys.Up, "↓", "↑")
 	topKey := formatDoubleKey(Keys.Top)
+	showKeys := formatKeyList(Keys.Right, "→", "Enter")
 	backKeys := formatKeyList(Keys.Left, "←", "ESC")
 
 	help := []struct {
 		key  string
 		desc string
 	}{
-		{moveKeys, "Scroll down/up"},
+		{moveKeys, "Move down/up"},
 		{topKey, "Go to top"},
 		{Keys.Bottom, "Go to bottom"},
 		{"ctrl+d", "Page down"},
 		{"ctrl+u", "Page up"},
+		{showKeys, "Show commit"},
+		{Keys.Visual, "Visual mode (select a range of commits)"},
+		{Keys.CherryPick, "Cherry-pick commit(s) onto HEAD"},
+		{Keys.Revert, "Revert commit(s)"},
+		{Keys.Reset, "Reset HEAD to commit (soft/mixed/hard)"},
+		{Keys.Rebase, "Plan an interactive rebase onto commit"},
 		{Keys.Help, "Toggle help"},
 		{backKeys, "Go back"},
 	}
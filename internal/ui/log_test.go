@@ -4,13 +4,33 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
+
+	"go-on-git/internal/git"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+func makeCommits(n int) []git.CommitInfo {
+	commits := make([]git.CommitInfo, n)
+	for i := range commits {
+		hash := fmt.Sprintf("%040d", i)
+		commits[i] = git.CommitInfo{
+			Hash:       hash,
+			ShortHash:  hash[:7],
+			Subject:    fmt.Sprintf("Commit %d", i),
+			AuthorName: "Test User",
+		}
+	}
+	return commits
+}
+
 func TestNewLogModel(t *testing.T) {
 	m := NewLogModel()
 
+	if m.cursor != 0 {
+		t.Errorf("cursor = %d, want 0", m.cursor)
+	}
 	if m.scrollOffset != 0 {
 		t.Errorf("scrollOffset = %d, want 0", m.scrollOffset)
 	}
@@ -20,8 +40,8 @@ func TestNewLogModel(t *testing.T) {
 	if m.height != 0 {
 		t.Errorf("height = %d, want 0", m.height)
 	}
-	if len(m.lines) != 0 {
-		t.Errorf("lines should be empty, got %d", len(m.lines))
+	if len(m.commits) != 0 {
+		t.Errorf("commits should be empty, got %d", len(m.commits))
 	}
 }
 
@@ -47,84 +67,108 @@ func TestLogModelInit(t *testing.T) {
 
 func TestLogModelNavigation(t *testing.T) {
 	m := NewLogModelWithSize(100, 10)
-	m.lines = make([]string, 100)
+	m.commits = makeCommits(100)
 
-	// Test scroll down
+	// Test move down
 	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
 	m = newModel.(LogModel)
-	if m.scrollOffset != 1 {
-		t.Errorf("after 'j', scrollOffset = %d, want 1", m.scrollOffset)
+	if m.cursor != 1 {
+		t.Errorf("after 'j', cursor = %d, want 1", m.cursor)
 	}
 
-	// Test scroll up
+	// Test move up
 	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
 	m = newModel.(LogModel)
-	if m.scrollOffset != 0 {
-		t.Errorf("after 'k', scrollOffset = %d, want 0", m.scrollOffset)
+	if m.cursor != 0 {
+		t.Errorf("after 'k', cursor = %d, want 0", m.cursor)
 	}
 
-	// Test can't scroll above 0
+	// Test can't move above 0
 	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
 	m = newModel.(LogModel)
-	if m.scrollOffset != 0 {
-		t.Errorf("scrollOffset should stay at 0, got %d", m.scrollOffset)
+	if m.cursor != 0 {
+		t.Errorf("cursor should stay at 0, got %d", m.cursor)
 	}
 
 	// Test jump to bottom
 	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'G'}})
 	m = newModel.(LogModel)
+	if m.cursor != 99 {
+		t.Errorf("after 'G', cursor = %d, want 99", m.cursor)
+	}
 	if m.scrollOffset == 0 {
 		t.Error("scrollOffset should be > 0 after 'G'")
 	}
 
-	// Test jump to top
+	// Test jump to top (gg)
 	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
 	m = newModel.(LogModel)
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	m = newModel.(LogModel)
+	if m.cursor != 0 {
+		t.Errorf("after 'gg', cursor = %d, want 0", m.cursor)
+	}
 	if m.scrollOffset != 0 {
-		t.Errorf("after 'g', scrollOffset = %d, want 0", m.scrollOffset)
+		t.Errorf("after 'gg', scrollOffset = %d, want 0", m.scrollOffset)
 	}
 }
 
 func TestLogModelPageNavigation(t *testing.T) {
 	m := NewLogModelWithSize(100, 20)
-	m.lines = make([]string, 100)
+	m.commits = makeCommits(100)
 
 	// Test ctrl+d (half page down)
-	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}, Alt: false})
-	// Need to use the actual key type for ctrl+d
-	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlD})
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlD})
 	m = newModel.(LogModel)
-	if m.scrollOffset == 0 {
-		t.Error("scrollOffset should be > 0 after ctrl+d")
+	if m.cursor == 0 {
+		t.Error("cursor should be > 0 after ctrl+d")
 	}
 
-	// Save current offset
-	currentOffset := m.scrollOffset
+	// Save current position
+	current := m.cursor
 
 	// Test ctrl+u (half page up)
 	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
 	m = newModel.(LogModel)
-	if m.scrollOffset >= currentOffset {
-		t.Error("scrollOffset should decrease after ctrl+u")
+	if m.cursor >= current {
+		t.Error("cursor should decrease after ctrl+u")
 	}
 }
 
 func TestLogModelArrowKeys(t *testing.T) {
 	m := NewLogModelWithSize(100, 10)
-	m.lines = make([]string, 50)
+	m.commits = makeCommits(50)
 
 	// Test down arrow
 	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
 	m = newModel.(LogModel)
-	if m.scrollOffset != 1 {
-		t.Errorf("after down arrow, scrollOffset = %d, want 1", m.scrollOffset)
+	if m.cursor != 1 {
+		t.Errorf("after down arrow, cursor = %d, want 1", m.cursor)
 	}
 
 	// Test up arrow
 	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
 	m = newModel.(LogModel)
-	if m.scrollOffset != 0 {
-		t.Errorf("after up arrow, scrollOffset = %d, want 0", m.scrollOffset)
+	if m.cursor != 0 {
+		t.Errorf("after up arrow, cursor = %d, want 0", m.cursor)
+	}
+}
+
+func TestLogModelSelectedCommit(t *testing.T) {
+	m := NewLogModel()
+
+	if _, ok := m.SelectedCommit(); ok {
+		t.Error("SelectedCommit should return false with no commits")
+	}
+
+	m.commits = makeCommits(3)
+	m.cursor = 2
+	commit, ok := m.SelectedCommit()
+	if !ok {
+		t.Fatal("SelectedCommit should return true")
+	}
+	if commit.Subject != "Commit 2" {
+		t.Errorf("Subject = %q, want 'Commit 2'", commit.Subject)
 	}
 }
 
@@ -144,14 +188,19 @@ func TestLogModelWindowResize(t *testing.T) {
 
 func TestLogModelLogMsg(t *testing.T) {
 	m := NewLogModel()
+	m.cursor = 10
 
-	content := "commit abc123\nAuthor: Test\nDate: Today\n\n    Message"
-
-	newModel, _ := m.Update(logMsg{content: content})
+	newModel, _ := m.Update(logMsg{commits: makeCommits(5)})
 	m = newModel.(LogModel)
 
-	if len(m.lines) != 5 {
-		t.Errorf("len(lines) = %d, want 5", len(m.lines))
+	if len(m.commits) != 5 {
+		t.Errorf("len(commits) = %d, want 5", len(m.commits))
+	}
+	if !m.loaded {
+		t.Error("loaded should be set")
+	}
+	if m.cursor != 4 {
+		t.Errorf("cursor = %d, want 4 (clamped)", m.cursor)
 	}
 }
 
@@ -168,74 +217,59 @@ func TestLogModelErrMsg(t *testing.T) {
 
 func TestLogModelView(t *testing.T) {
 	m := NewLogModelWithSize(100, 20)
-	m.lines = []string{
-		"commit abc123",
-		"Author: Test User <test@example.com>",
-		"Date:   Mon Jan 1 00:00:00 2024",
-		"",
-		"    Initial commit",
+	m.loaded = true
+	m.commits = []git.CommitInfo{
+		{
+			Hash:       "abc1234def",
+			ShortHash:  "abc1234",
+			Subject:    "Initial commit",
+			AuthorName: "Test User",
+			AuthorDate: time.Now().Add(-2 * time.Hour),
+			Refs: []git.Ref{
+				{Name: "main", Type: git.RefBranch, IsHead: true},
+				{Name: "v1.0", Type: git.RefTag},
+			},
+		},
 	}
 
 	view := m.View()
 
-	if !strings.Contains(view, "commit") {
-		t.Error("view should contain 'commit'")
-	}
-	if !strings.Contains(view, "Author:") {
-		t.Error("view should contain 'Author:'")
+	for _, want := range []string{"abc1234", "Initial commit", "Test User", "2 hours ago", "HEAD -> ", "main", "tag: v1.0", "> "} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q", want)
+		}
 	}
 }
 
 func TestLogModelViewLoading(t *testing.T) {
 	m := NewLogModelWithSize(100, 20)
-	m.lines = nil
 
 	view := m.View()
 
 	if !strings.Contains(view, "Loading") {
-		t.Error("view should show 'Loading' when lines is empty")
+		t.Error("view should show 'Loading' before commits are loaded")
 	}
 }
 
-func TestLogModelViewWithError(t *testing.T) {
+func TestLogModelViewNoCommits(t *testing.T) {
 	m := NewLogModelWithSize(100, 20)
-	m.err = fmt.Errorf("test error")
+	m.loaded = true
 
 	view := m.View()
 
-	if !strings.Contains(view, "Error:") {
-		t.Error("view should show error")
+	if !strings.Contains(view, "No commits yet") {
+		t.Error("view should show 'No commits yet' for an empty repository")
 	}
 }
 
-func TestLogModelViewStyling(t *testing.T) {
-	m := NewLogModelWithSize(100, 30)
-	m.lines = []string{
-		"commit abc123",
-		"Author: Test User",
-		"Date: Today",
-		"Regular line",
-	}
+func TestLogModelViewWithError(t *testing.T) {
+	m := NewLogModelWithSize(100, 20)
+	m.err = fmt.Errorf("test error")
 
 	view := m.View()
 
-	// Just verify it doesn't panic and renders something
-	if len(view) == 0 {
-		t.Error("view should not be empty")
-	}
-}
-
-func TestLogModelScrollBounds(t *testing.T) {
-	m := NewLogModelWithSize(100, 10)
-	m.lines = make([]string, 5) // Fewer lines than visible
-
-	// Scroll down should not go negative
-	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'G'}})
-	m = newModel.(LogModel)
-
-	// maxOffset should be 0 when lines fit in view
-	if m.scrollOffset < 0 {
-		t.Errorf("scrollOffset should not be negative, got %d", m.scrollOffset)
+	if !strings.Contains(view, "Error:") {
+		t.Error("view should show error")
 	}
 }
 
@@ -253,7 +287,8 @@ func TestLogModelAnchorBottom(t *testing.T) {
 
 func TestLogModelSmallHeight(t *testing.T) {
 	m := NewLogModelWithSize(100, 0)
-	m.lines = make([]string, 50)
+	m.loaded = true
+	m.commits = makeCommits(50)
 
 	// Should use default visible lines
 	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
@@ -266,35 +301,297 @@ func TestLogModelSmallHeight(t *testing.T) {
 	}
 }
 
-func TestLogModelVisibleLinesCalculation(t *testing.T) {
-	// When height is very small, visibleLines should have a minimum
-	m := NewLogModelWithSize(100, 2)
-	m.lines = make([]string, 100)
+func TestLogModelScrollBounds(t *testing.T) {
+	m := NewLogModelWithSize(100, 10)
+	m.commits = makeCommits(5) // Fewer commits than visible
 
-	// visibleLines = height - 2, minimum 1 for display
-	// This is handled in the Update function
 	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'G'}})
 	m = newModel.(LogModel)
 
-	// Should not panic and scrollOffset should be reasonable
-	if m.scrollOffset < 0 {
-		t.Error("scrollOffset should not be negative")
+	// scrollOffset should stay 0 when commits fit in view
+	if m.scrollOffset != 0 {
+		t.Errorf("scrollOffset = %d, want 0", m.scrollOffset)
+	}
+	if m.cursor != 4 {
+		t.Errorf("cursor = %d, want 4", m.cursor)
+	}
+}
+
+func TestLogModelViewWithManyCommits(t *testing.T) {
+	m := NewLogModelWithSize(100, 20)
+	m.loaded = true
+	m.commits = makeCommits(100)
+	m.cursor = 50
+	m.ensureCursorVisible()
+
+	view := m.View()
+
+	// Should show commits around the cursor
+	if !strings.Contains(view, "Commit 50") {
+		t.Error("view should show the commit under the cursor")
+	}
+	if strings.Contains(view, "Commit 0 -") {
+		t.Error("view should not show commits scrolled out of view")
 	}
 }
 
-func TestLogModelViewWithManyLines(t *testing.T) {
+func TestLogModelViewHelp(t *testing.T) {
 	m := NewLogModelWithSize(100, 20)
-	lines := make([]string, 100)
-	for i := 0; i < 100; i++ {
-		lines[i] = fmt.Sprintf("Line %d", i)
+	m.showHelp = true
+
+	view := m.View()
+
+	if !strings.Contains(view, "Log Shortcuts") {
+		t.Error("help view should contain title")
+	}
+	if !strings.Contains(view, "Show commit") {
+		t.Error("help view should describe commit drill-down")
 	}
-	m.lines = lines
-	m.scrollOffset = 50
+}
+
+func TestFormatRelativeTime(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		ago  time.Duration
+		want string
+	}{
+		{30 * time.Second, "30 seconds ago"},
+		{time.Minute, "1 minute ago"},
+		{5 * time.Hour, "5 hours ago"},
+		{3 * 24 * time.Hour, "3 days ago"},
+		{21 * 24 * time.Hour, "3 weeks ago"},
+		{90 * 24 * time.Hour, "3 months ago"},
+		{2 * 365 * 24 * time.Hour, "2 years ago"},
+	}
+
+	for _, tt := range tests {
+		if got := formatRelativeTime(now.Add(-tt.ago), now); got != tt.want {
+			t.Errorf("formatRelativeTime(-%v) = %q, want %q", tt.ago, got, tt.want)
+		}
+	}
+}
+
+func TestLogModelViewGraph(t *testing.T) {
+	m := NewLogModelWithSize(100, 20)
+	newModel, _ := m.Update(logMsg{commits: []git.CommitInfo{
+		{Hash: "m", ShortHash: "mmmmmmm", Parents: []string{"b", "f"}, Subject: "Merge feature"},
+		{Hash: "f", ShortHash: "fffffff", Parents: []string{"b"}, Subject: "Feature work"},
+		{Hash: "b", ShortHash: "bbbbbbb", Subject: "Base"},
+	}})
+	m = newModel.(LogModel)
 
 	view := m.View()
 
-	// Should show lines around offset 50
-	if !strings.Contains(view, "Line 50") && !strings.Contains(view, "Line 51") {
-		t.Error("view should show lines near scroll offset")
+	for _, want := range []string{"* ", `|\`, "| * ", "|/"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain graph segment %q", want)
+		}
+	}
+}
+
+func TestLogModelScrollAccountsForGraphRows(t *testing.T) {
+	// Each merge adds a connector row, so fewer commits fit on screen
+	var commits []git.CommitInfo
+	for i := 0; i < 20; i++ {
+		commits = append(commits, git.CommitInfo{
+			Hash:    fmt.Sprintf("m%d", i),
+			Parents: []string{fmt.Sprintf("m%d", i+1), fmt.Sprintf("side%d", i)},
+		})
+	}
+	m := NewLogModelWithSize(100, 14) // 10 visible lines
+	newModel, _ := m.Update(logMsg{commits: commits})
+	m = newModel.(LogModel)
+
+	m.cursor = 6
+	m.ensureCursorVisible()
+
+	if got := m.linesBetween(m.scrollOffset, m.cursor) + 1; got > m.visibleLines() {
+		t.Errorf("cursor row is off screen: %d lines needed, %d visible", got, m.visibleLines())
+	}
+	if m.scrollOffset == 0 {
+		t.Error("should scroll when graph rows push the cursor off screen")
+	}
+}
+
+func TestLogModelVisualSelection(t *testing.T) {
+	m := NewLogModelWithSize(100, 30)
+	m.loaded = true
+	m.commits = makeCommits(5)
+	m.cursor = 1
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	m = newModel.(LogModel)
+	for range 2 {
+		newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+		m = newModel.(LogModel)
+	}
+
+	selected := m.selectedCommits()
+	if len(selected) != 3 || selected[0].Subject != "Commit 1" || selected[2].Subject != "Commit 3" {
+		t.Fatalf("expected commits 1-3 selected, got %+v", selected)
+	}
+	if !m.inPrompt() || !strings.Contains(m.View(), "-- VISUAL --") {
+		t.Error("visual mode should be shown and keep the view's keys")
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(LogModel)
+	if m.visualMode || len(m.selectedCommits()) != 1 {
+		t.Error("esc should leave visual mode")
+	}
+}
+
+func TestLogModelCherryPickConfirm(t *testing.T) {
+	m := NewLogModelWithSize(100, 30)
+	m.loaded = true
+	m.commits = makeCommits(3)
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	m = newModel.(LogModel)
+	if m.confirmMode != logConfirmCherryPick {
+		t.Fatal("c should ask to confirm the cherry-pick")
+	}
+	if view := m.View(); !strings.Contains(view, "Cherry-pick 0000000 onto HEAD?") {
+		t.Errorf("view should show the cherry-pick prompt, got:\n%s", view)
+	}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	m = newModel.(LogModel)
+	if m.confirmMode != logConfirmNone || cmd != nil {
+		t.Error("n should cancel the cherry-pick")
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	m = newModel.(LogModel)
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m = newModel.(LogModel)
+	if m.confirmMode != logConfirmNone || cmd == nil {
+		t.Error("y should cherry-pick the commit")
+	}
+}
+
+func TestLogModelRevertRangeConfirm(t *testing.T) {
+	m := NewLogModelWithSize(100, 30)
+	m.loaded = true
+	m.commits = makeCommits(3)
+	m.visualMode = true
+	m.visualStart = 0
+	m.cursor = 1
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'R'}})
+	m = newModel.(LogModel)
+	if view := m.View(); !strings.Contains(view, "Revert 2 commits?") {
+		t.Errorf("view should show the revert prompt for the range, got:\n%s", view)
+	}
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m = newModel.(LogModel)
+	if cmd == nil || m.visualMode {
+		t.Error("y should revert the range and leave visual mode")
+	}
+}
+
+func TestLogModelRefusesMergeCommits(t *testing.T) {
+	m := NewLogModelWithSize(100, 30)
+	m.loaded = true
+	m.commits = makeCommits(3)
+	m.commits[1].Parents = []string{m.commits[2].Hash, "feature"}
+	m.visualMode = true
+	m.visualStart = 0
+	m.cursor = 1
+
+	for _, key := range []rune{'c', 'R'} {
+		newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{key}})
+		got := newModel.(LogModel)
+		if got.confirmMode != logConfirmNone || cmd != nil {
+			t.Errorf("%c should not offer to pick a range with a merge commit", key)
+		}
+		if got.err == nil || !strings.Contains(got.err.Error(), "merge commit") {
+			t.Errorf("%c error = %v, want the merge commit refused", key, got.err)
+		}
+	}
+
+	// Without the merge commit in the selection the prompt opens
+	m.visualMode = false
+	m.cursor = 0
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	if got := newModel.(LogModel); got.confirmMode != logConfirmCherryPick || got.err != nil {
+		t.Error("c should ask to confirm cherry-picking a regular commit")
+	}
+}
+
+func TestLogModelResetPicker(t *testing.T) {
+	m := NewLogModelWithSize(100, 30)
+	m.loaded = true
+	m.commits = makeCommits(3)
+	m.cursor = 2
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	m = newModel.(LogModel)
+	if !m.resetMode || m.resetPicker.selected() != "mixed" {
+		t.Fatal("x should open the reset picker on mixed")
+	}
+	if view := m.View(); !strings.Contains(view, "Reset HEAD to 0000000:") {
+		t.Errorf("view should show the reset picker, got:\n%s", view)
+	}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'h'}})
+	m = newModel.(LogModel)
+	if cmd != nil || m.resetPicker.selected() != "soft" {
+		t.Error("h should move the picker to soft")
+	}
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(LogModel)
+	if m.resetMode || cmd == nil {
+		t.Error("enter should run a soft reset without confirmation")
+	}
+}
+
+func TestLogModelHardResetConfirm(t *testing.T) {
+	m := NewLogModelWithSize(100, 30)
+	m.loaded = true
+	m.commits = makeCommits(3)
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	m = newModel.(LogModel)
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	m = newModel.(LogModel)
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(LogModel)
+	if m.confirmMode != logConfirmHardReset || cmd != nil {
+		t.Fatal("a hard reset should ask to type 'yes'")
+	}
+
+	for _, r := range "ye" {
+		newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = newModel.(LogModel)
+	}
+	if view := m.View(); !strings.Contains(view, "Hard reset to 0000000?") || !strings.Contains(view, "Type 'yes' to confirm: ye") {
+		t.Errorf("view should show the typed confirmation, got:\n%s", view)
+	}
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(LogModel)
+	if cmd != nil || m.confirmMode != logConfirmHardReset {
+		t.Error("enter should not reset before 'yes' is typed")
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	m = newModel.(LogModel)
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(LogModel)
+	if cmd == nil || m.confirmMode != logConfirmNone {
+		t.Error("enter should reset once 'yes' is typed")
+	}
+}
+
+func TestLogModelActionErrorKeepsCommits(t *testing.T) {
+	m := NewLogModelWithSize(100, 30)
+	m.loaded = true
+	m.commits = makeCommits(3)
+
+	newModel, _ := m.Update(errMsg{err: fmt.Errorf("cherry-pick failed")})
+	m = newModel.(LogModel)
+	view := m.View()
+	if !strings.Contains(view, "Error: cherry-pick failed") || !strings.Contains(view, "Commit 2") {
+		t.Errorf("view should show the error above the commits, got:\n%s", view)
 	}
 }
@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"strings"
+)
+
+// picker is an inline prompt for choosing one of a few options, such as a
+// remote or a pull mode. Options are laid out on a single line.
+type picker struct {
+	title   string
+	options []string
+	cursor  int
+}
+
+func newPicker(title string, options []string, cursor int) picker {
+	return picker{
+		title:   title,
+		options: options,
+		cursor:  max(0, min(cursor, len(options)-1)),
+	}
+}
+
+// selected returns the option under the cursor
+func (p picker) selected() string {
+	if p.cursor < 0 || p.cursor >= len(p.options) {
+		return ""
+	}
+	return p.options[p.cursor]
+}
+
+// move handles the navigation keys and reports whether the key was used
+func (p *picker) move(key string) bool {
+	switch key {
+	case Keys.Up, Keys.Left, "up", "left":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+		return true
+	case Keys.Down, Keys.Right, "down", "right":
+		if p.cursor < len(p.options)-1 {
+			p.cursor++
+		}
+		return true
+	}
+	return false
+}
+
+// View renders the title and the options, highlighting the selected one
+func (p picker) View() string {
+	var sb strings.Builder
+	sb.WriteString(p.title)
+	sb.WriteString(" ")
+	for i, option := range p.options {
+		if i > 0 {
+			sb.WriteString(" ")
+		}
+		if i == p.cursor {
+			sb.WriteString(StyleSelected.Render(" " + option + " "))
+		} else {
+			sb.WriteString(" " + option + " ")
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPickerMove(t *testing.T) {
+	p := newPicker("Fetch from:", []string{"origin", "upstream", "fork"}, 0)
+
+	if p.selected() != "origin" {
+		t.Errorf("selected() = %q, want origin", p.selected())
+	}
+	if !p.move(Keys.Down) || p.selected() != "upstream" {
+		t.Errorf("down should select upstream, got %q", p.selected())
+	}
+	p.move("right")
+	p.move("right")
+	if p.selected() != "fork" {
+		t.Errorf("cursor should stop at the last option, got %q", p.selected())
+	}
+	p.move(Keys.Up)
+	if p.selected() != "upstream" {
+		t.Errorf("up should select upstream, got %q", p.selected())
+	}
+	if p.move("x") {
+		t.Error("move should ignore non-navigation keys")
+	}
+}
+
+func TestPickerClampsCursor(t *testing.T) {
+	p := newPicker("Pick:", []string{"a", "b"}, 5)
+	if p.selected() != "b" {
+		t.Errorf("selected() = %q, want the last option", p.selected())
+	}
+}
+
+func TestPickerView(t *testing.T) {
+	p := newPicker("Pull 'origin/main' with:", []string{"merge", "rebase", "ff-only"}, 1)
+	view := p.View()
+	for _, want := range []string{"Pull 'origin/main' with:", "merge", "rebase", "ff-only"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q, got %q", want, view)
+		}
+	}
+}
@@ -0,0 +1,477 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"go-on-git/internal/git"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// RebaseModel is the bubbletea model for the interactive rebase planner.
+// It lists the commits after a base commit, oldest first like git's todo
+// list, and runs the rebase with the planned actions.
+type RebaseModel struct {
+	base            git.CommitInfo
+	steps           []git.RebaseStep
+	loaded          bool
+	cursor          int
+	scrollOffset    int
+	rewordMode      bool // editing the new message of the commit under the cursor
+	composer        commitComposer
+	confirmMode     bool // confirm starting the rebase
+	confirmAbort    bool
+	running         bool
+	operation       git.Operation // rebase stopped on conflicts or to edit a commit
+	conflicted      bool
+	showHelp        bool
+	showVerboseHelp bool
+	err             error
+	width           int
+	height          int
+}
+
+// NewRebaseModel creates a rebase planner for the commits after base
+func NewRebaseModel(base git.CommitInfo, width, height int, showVerboseHelp bool) RebaseModel {
+	composer := newCommitComposer()
+	composer.SetWidth(width - 2)
+	return RebaseModel{
+		base:            base,
+		composer:        composer,
+		width:           width,
+		height:          height,
+		showVerboseHelp: showVerboseHelp,
+	}
+}
+
+type rebaseCommitsMsg struct {
+	commits []git.CommitInfo
+}
+
+// rebaseResultMsg is sent after starting, continuing or aborting the rebase
+type rebaseResultMsg struct {
+	operation  git.Operation // still in progress if the rebase stopped
+	conflicted bool
+	err        error
+}
+
+// finished returns true if the rebase completed or was aborted
+func (msg rebaseResultMsg) finished() bool {
+	return msg.err == nil && !msg.operation.InProgress()
+}
+
+// Init loads the commits to plan
+func (m RebaseModel) Init() tea.Cmd {
+	base := m.base.Hash
+	return func() tea.Msg {
+		commits, err := git.GetRebaseCommits(base)
+		if err != nil {
+			return errMsg{err}
+		}
+		return rebaseCommitsMsg{commits}
+	}
+}
+
+// inPrompt returns true while the planner handles esc and navigation itself
+func (m RebaseModel) inPrompt() bool {
+	return m.rewordMode || m.confirmMode || m.confirmAbort || m.showHelp
+}
+
+// stopped returns true if the rebase started from the planner is waiting
+// to be continued
+func (m RebaseModel) stopped() bool {
+	return m.operation.InProgress()
+}
+
+// Update handles messages
+func (m RebaseModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		key := msg.String()
+
+		if m.showHelp {
+			if key == Keys.Help || key == "esc" || key == Keys.Quit {
+				m.showHelp = false
+			}
+			return m, nil
+		}
+
+		if m.rewordMode {
+			switch key {
+			case "ctrl+s":
+				message := git.CleanCommitMessage(m.composer.Value())
+				if message == "" {
+					m.err = fmt.Errorf("the commit message is empty")
+					return m, nil
+				}
+				m.steps[m.cursor].Action = git.RebaseReword
+				m.steps[m.cursor].Message = message
+				m.rewordMode = false
+				m.composer.Blur()
+				m.err = nil
+				return m, nil
+			case "esc":
+				m.rewordMode = false
+				m.composer.Blur()
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.composer, cmd = m.composer.Update(msg)
+			return m, cmd
+		}
+
+		if m.confirmMode || m.confirmAbort {
+			switch key {
+			case "y", "Y", "enter":
+				abort := m.confirmAbort
+				m.confirmMode = false
+				m.confirmAbort = false
+				m.running = true
+				m.err = nil
+				if abort {
+					return m, m.doAbort()
+				}
+				return m, m.doRebase()
+			case "n", "N", "esc":
+				m.confirmMode = false
+				m.confirmAbort = false
+			}
+			return m, nil
+		}
+
+		if m.running {
+			return m, nil
+		}
+
+		switch key {
+		case Keys.Help:
+			m.showHelp = true
+			return m, nil
+		case Keys.VerboseHelp:
+			m.showVerboseHelp = !m.showVerboseHelp
+			return m, nil
+		}
+
+		// Once the rebase has stopped the plan can't be changed
+		if m.stopped() {
+			switch key {
+			case Keys.Continue:
+				m.running = true
+				m.err = nil
+				return m, m.doContinue()
+			case Keys.Abort:
+				m.confirmAbort = true
+			}
+			return m, nil
+		}
+
+		if len(m.steps) == 0 {
+			return m, nil
+		}
+
+		switch key {
+		case Keys.Down, "down":
+			m.cursor = min(m.cursor+1, len(m.steps)-1)
+			m.ensureCursorVisible()
+		case Keys.Up, "up":
+			m.cursor = max(m.cursor-1, 0)
+			m.ensureCursorVisible()
+		case Keys.Bottom:
+			m.cursor = len(m.steps) - 1
+			m.ensureCursorVisible()
+		case Keys.MoveUp:
+			if m.cursor > 0 {
+				m.steps[m.cursor], m.steps[m.cursor-1] = m.steps[m.cursor-1], m.steps[m.cursor]
+				m.cursor--
+				m.ensureCursorVisible()
+			}
+		case Keys.MoveDown:
+			if m.cursor < len(m.steps)-1 {
+				m.steps[m.cursor], m.steps[m.cursor+1] = m.steps[m.cursor+1], m.steps[m.cursor]
+				m.cursor++
+				m.ensureCursorVisible()
+			}
+		case Keys.Pick:
+			m.setAction(git.RebasePick)
+		case Keys.EditCommit:
+			m.setAction(git.RebaseEdit)
+		case Keys.Squash:
+			m.setAction(git.RebaseSquash)
+		case Keys.Fixup:
+			m.setAction(git.RebaseFixup)
+		case Keys.Drop:
+			m.setAction(git.RebaseDrop)
+		case Keys.Reword:
+			step := m.steps[m.cursor]
+			message := step.Message
+			if message == "" {
+				message = step.Commit.Message()
+			}
+			m.rewordMode = true
+			m.composer.SetValue(message)
+			return m, m.composer.Focus()
+		case "enter":
+			m.confirmMode = true
+		}
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.composer.SetWidth(msg.Width - 2)
+		m.ensureCursorVisible()
+		return m, nil
+
+	case rebaseCommitsMsg:
+		m.steps = make([]git.RebaseStep, len(msg.commits))
+		for i, c := range msg.commits {
+			m.steps[i] = git.RebaseStep{Action: git.RebasePick, Commit: c}
+		}
+		m.loaded = true
+		m.cursor = 0
+		m.scrollOffset = 0
+		return m, nil
+
+	case rebaseResultMsg:
+		m.running = false
+		m.operation = msg.operation
+		m.conflicted = msg.conflicted
+		m.err = msg.err
+		return m, nil
+
+	case errMsg:
+		m.running = false
+		m.err = msg.err
+		return m, nil
+	}
+
+	return m, nil
+}
+
+// setAction sets the action of the commit under the cursor, dropping any
+// reworded message
+func (m *RebaseModel) setAction(action git.RebaseAction) {
+	m.steps[m.cursor].Action = action
+	m.steps[m.cursor].Message = ""
+}
+
+// doRebase runs the planned rebase
+func (m RebaseModel) doRebase() tea.Cmd {
+	base := m.base.Hash
+	steps := append([]git.RebaseStep(nil), m.steps...)
+	return func() tea.Msg {
+		return rebaseResult(git.InteractiveRebase(base, steps))
+	}
+}
+
+// doContinue continues the stopped rebase
+func (m RebaseModel) doContinue() tea.Cmd {
+	op := m.operation
+	return func() tea.Msg {
+		return rebaseResult(git.ContinueOperation(op))
+	}
+}
+
+// doAbort abandons the stopped rebase
+func (m RebaseModel) doAbort() tea.Cmd {
+	op := m.operation
+	return func() tea.Msg {
+		return rebaseResult(git.AbortOperation(op))
+	}
+}
+
+// rebaseResult reports whether the rebase finished or stopped
+func rebaseResult(err error) tea.Msg {
+	op := git.GetOperation()
+	if op.Kind != git.OperationRebase {
+		op = git.Operation{}
+	}
+	return rebaseResultMsg{operation: op, conflicted: git.HasConflicts(), err: err}
+}
+
+// visibleLines returns the number of commit lines that can be displayed
+func (m RebaseModel) visibleLines() int {
+	// Header (2 lines), blank lines, the prompt or banner and optionally the help bar
+	reserved := 6
+	if m.showVerboseHelp {
+		reserved += 2
+	}
+	if m.rewordMode {
+		reserved += composerHeight + 2
+	}
+	if m.height <= reserved {
+		return 10 // fallback minimum
+	}
+	return m.height - reserved
+}
+
+// ensureCursorVisible adjusts scrollOffset to keep cursor in view
+func (m *RebaseModel) ensureCursorVisible() {
+	visible := m.visibleLines()
+	if m.cursor < m.scrollOffset {
+		m.scrollOffset = m.cursor
+	}
+	if m.cursor >= m.scrollOffset+visible {
+		m.scrollOffset = m.cursor - visible + 1
+	}
+}
+
+// View renders the rebase planner
+func (m RebaseModel) View() string {
+	if m.showHelp {
+		return m.renderHelp()
+	}
+
+	var content strings.Builder
+	content.WriteString(m.renderHeader())
+	content.WriteString("\n\n")
+
+	if m.err != nil {
+		content.WriteString(StyleUnstaged.Render(fmt.Sprintf("Error: %v", m.err)))
+		content.WriteString("\n")
+	}
+
+	switch {
+	case !m.loaded:
+		content.WriteString(StyleMuted.Render("Loading..."))
+		content.WriteString("\n")
+	case len(m.steps) == 0:
+		content.WriteString(StyleEmpty.Render(fmt.Sprintf("No commits after %s to rebase", m.base.ShortHash)))
+		content.WriteString("\n")
+	default:
+		visibleEnd := min(m.scrollOffset+m.visibleLines(), len(m.steps))
+		for i := m.scrollOffset; i < visibleEnd; i++ {
+			content.WriteString(m.renderStep(i))
+			content.WriteString("\n")
+		}
+	}
+
+	content.WriteString("\n")
+	switch {
+	case m.rewordMode:
+		content.WriteString(fmt.Sprintf("Reword %s ", m.steps[m.cursor].Commit.ShortHash))
+		content.WriteString(StyleMuted.Render("(ctrl+s to save, esc to cancel)"))
+		content.WriteString("\n")
+		content.WriteString(m.composer.View())
+		content.WriteString("\n")
+	case m.confirmMode:
+		content.WriteString(StyleConfirm.Render(fmt.Sprintf("Rebase %d commits onto %s? (y/n) ", len(m.steps), m.base.ShortHash)))
+		content.WriteString("\n")
+	case m.confirmAbort:
+		content.WriteString(StyleConfirm.Render("Abort the rebase and restore the branch? (y/n) "))
+		content.WriteString("\n")
+	case m.running:
+		content.WriteString(StyleMuted.Render("Rebasing..."))
+		content.WriteString("\n")
+	case m.stopped():
+		content.WriteString(m.renderStopped())
+		content.WriteString("\n")
+	}
+
+	if m.showVerboseHelp {
+		content.WriteString("\n")
+		content.WriteString(m.renderHelpBar())
+	}
+
+	return content.String()
+}
+
+// renderStep renders one line of the todo list
+func (m RebaseModel) renderStep(i int) string {
+	step := m.steps[i]
+	prefix := "  "
+	if i == m.cursor {
+		prefix = "> "
+	}
+
+	action := fmt.Sprintf("%-6s", step.Action)
+	subject := step.Commit.Subject
+	switch step.Action {
+	case git.RebaseReword:
+		action = StyleUntracked.Render(action)
+		subject = strings.SplitN(step.Message, "\n", 2)[0]
+	case git.RebaseEdit:
+		action = StyleUntracked.Render(action)
+	case git.RebaseSquash, git.RebaseFixup:
+		action = StyleStaged.Render(action)
+	case git.RebaseDrop:
+		action = StyleUnstaged.Render(action)
+		subject = StyleMuted.Render(subject)
+	}
+
+	return prefix + action + " " + StyleCommitHash.Render(step.Commit.ShortHash) + " " + subject
+}
+
+// renderStopped explains why the rebase stopped and how to go on
+func (m RebaseModel) renderStopped() string {
+	title := fmt.Sprintf("The %s stopped to edit a commit.", m.operation)
+	hint := fmt.Sprintf("Amend the commit, then press %s to continue or %s to abort.", Keys.Continue, Keys.Abort)
+	if m.conflicted {
+		title = fmt.Sprintf("The %s stopped with conflicts.", m.operation)
+		hint = fmt.Sprintf("Resolve them from the status view, then press %s to continue or %s to abort.", Keys.Continue, Keys.Abort)
+	}
+	return StyleConfirm.Render(title) + "\n" + StyleMuted.Render(hint)
+}
+
+func (m RebaseModel) renderHeader() string {
+	return StyleMuted.Render(fmt.Sprintf("> git rebase -i %s", m.base.ShortHash)) + "  " + StyleMuted.Render("(oldest first, esc to go back)") + "\n" + StyleMuted.Render("───────────────────────────────────────────────────────────────")
+}
+
+func (m RebaseModel) renderHelpBar() string {
+	var sb strings.Builder
+
+	sb.WriteString(StyleMuted.Render("───────────────────────────────────────────────────────────────"))
+	sb.WriteString("\n")
+
+	items := []struct{ key, desc string }{
+		{formatKeyList(Keys.MoveUp, Keys.MoveDown), "move"},
+		{formatKeyList(Keys.Pick, Keys.Reword, Keys.EditCommit), "pick/reword/edit"},
+		{formatKeyList(Keys.Squash, Keys.Fixup, Keys.Drop), "squash/fixup/drop"},
+		{"Enter", "rebase"},
+		{Keys.Help, "help"},
+	}
+
+	for _, item := range items {
+		sb.WriteString(StyleHelpKey.Render(item.key))
+		sb.WriteString(" ")
+		sb.WriteString(StyleHelpDesc.Render(item.desc))
+		sb.WriteString("  ")
+	}
+
+	return sb.String()
+}
+
+func (m RebaseModel) renderHelp() string {
+	var sb strings.Builder
+
+	sb.WriteString(StyleHelpTitle.Render("Rebase Planner Shortcuts"))
+	sb.WriteString("\n\n")
+
+	help := []struct {
+		key  string
+		desc string
+	}{
+		{formatKeyList(Keys.Down, Keys.Up, "↓", "↑"), "Move down/up"},
+		{formatKeyList(Keys.MoveUp, Keys.MoveDown), "Move commit up/down"},
+		{Keys.Pick, "Pick commit"},
+		{Keys.Reword, "Reword commit"},
+		{Keys.EditCommit, "Stop at commit to amend it"},
+		{Keys.Squash, "Squash into previous commit"},
+		{Keys.Fixup, "Fixup into previous commit (keep its message)"},
+		{Keys.Drop, "Drop commit"},
+		{"Enter", "Start the rebase"},
+		{Keys.Continue, "Continue a stopped rebase"},
+		{Keys.Abort, "Abort a stopped rebase"},
+		{Keys.Help, "Toggle help"},
+		{formatKeyList(Keys.Left, "ESC"), "Go back"},
+	}
+
+	for _, h := range help {
+		sb.WriteString(fmt.Sprintf("  %s  %s\n",
+			StyleHelpKey.Render(fmt.Sprintf("%-8s", h.key)),
+			StyleHelpDesc.Render(h.desc)))
+	}
+
+	return sb.String()
+}
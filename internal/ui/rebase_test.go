@@ -0,0 +1,222 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"go-on-git/internal/git"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newLoadedRebaseModel returns a planner with three commits to rebase
+func newLoadedRebaseModel() RebaseModel {
+	commits := makeCommits(4)
+	for i := range commits {
+		commits[i].ShortHash = string(rune('a'+i)) + "000000"
+	}
+	m := NewRebaseModel(commits[0], 100, 30, false)
+	newModel, _ := m.Update(rebaseCommitsMsg{commits: commits[1:]})
+	return newModel.(RebaseModel)
+}
+
+func pressRebaseKey(m RebaseModel, key string) (RebaseModel, tea.Cmd) {
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	switch key {
+	case "enter":
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		msg = tea.KeyMsg{Type: tea.KeyEsc}
+	case "ctrl+s":
+		msg = tea.KeyMsg{Type: tea.KeyCtrlS}
+	}
+	newModel, cmd := m.Update(msg)
+	return newModel.(RebaseModel), cmd
+}
+
+func TestRebaseModelView(t *testing.T) {
+	m := newLoadedRebaseModel()
+
+	view := m.View()
+	for _, want := range []string{"git rebase -i a000000", "pick   b000000 Commit 1", "pick   d000000 Commit 3"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q, got:\n%s", want, view)
+		}
+	}
+	if strings.Index(view, "Commit 1") > strings.Index(view, "Commit 3") {
+		t.Error("commits should be listed oldest first")
+	}
+}
+
+func TestRebaseModelEmpty(t *testing.T) {
+	m := NewRebaseModel(makeCommits(1)[0], 100, 30, false)
+	newModel, _ := m.Update(rebaseCommitsMsg{})
+	m = newModel.(RebaseModel)
+
+	if view := m.View(); !strings.Contains(view, "No commits after") {
+		t.Errorf("view should say there is nothing to rebase, got:\n%s", view)
+	}
+	if m, _ = pressRebaseKey(m, "enter"); m.confirmMode {
+		t.Error("enter should do nothing without commits")
+	}
+}
+
+func TestRebaseModelReorder(t *testing.T) {
+	m := newLoadedRebaseModel()
+
+	m, _ = pressRebaseKey(m, "J")
+	if m.cursor != 1 || m.steps[1].Commit.Subject != "Commit 1" || m.steps[0].Commit.Subject != "Commit 2" {
+		t.Fatalf("J should move the commit down, got %q then %q", m.steps[0].Commit.Subject, m.steps[1].Commit.Subject)
+	}
+	m, _ = pressRebaseKey(m, "K")
+	m, _ = pressRebaseKey(m, "K")
+	if m.cursor != 0 || m.steps[0].Commit.Subject != "Commit 1" {
+		t.Error("K should move the commit back up and stop at the top")
+	}
+}
+
+func TestRebaseModelActions(t *testing.T) {
+	m := newLoadedRebaseModel()
+
+	tests := []struct {
+		key  string
+		want git.RebaseAction
+	}{
+		{"e", git.RebaseEdit},
+		{"s", git.RebaseSquash},
+		{"f", git.RebaseFixup},
+		{"d", git.RebaseDrop},
+		{"p", git.RebasePick},
+	}
+	for _, tt := range tests {
+		m, _ = pressRebaseKey(m, tt.key)
+		if m.steps[0].Action != tt.want {
+			t.Errorf("%s should set %s, got %s", tt.key, tt.want, m.steps[0].Action)
+		}
+	}
+
+	m, _ = pressRebaseKey(m, "d")
+	if view := m.View(); !strings.Contains(view, "drop") {
+		t.Errorf("view should show the drop action, got:\n%s", view)
+	}
+}
+
+func TestRebaseModelReword(t *testing.T) {
+	m := newLoadedRebaseModel()
+	m.steps[0].Commit.Body = "Old body"
+
+	m, _ = pressRebaseKey(m, "r")
+	if !m.rewordMode || m.composer.Value() != "Commit 1\n\nOld body" {
+		t.Fatalf("r should open the composer with the current message, got %q", m.composer.Value())
+	}
+	if !m.inPrompt() {
+		t.Error("reword should keep esc in the planner")
+	}
+
+	m.composer.SetValue("New subject\n\n# comment\nNew body")
+	m, _ = pressRebaseKey(m, "ctrl+s")
+	if m.rewordMode {
+		t.Fatal("ctrl+s should save the message")
+	}
+	if m.steps[0].Action != git.RebaseReword || m.steps[0].Message != "New subject\n\nNew body" {
+		t.Errorf("expected a reword with the cleaned message, got %s %q", m.steps[0].Action, m.steps[0].Message)
+	}
+	if view := m.View(); !strings.Contains(view, "New subject") {
+		t.Errorf("view should show the new subject, got:\n%s", view)
+	}
+
+	// Reopening starts from the new message, esc keeps it
+	m, _ = pressRebaseKey(m, "r")
+	if m.composer.Value() != "New subject\n\nNew body" {
+		t.Errorf("expected the new message, got %q", m.composer.Value())
+	}
+	m, _ = pressRebaseKey(m, "esc")
+	if m.rewordMode || m.steps[0].Message != "New subject\n\nNew body" {
+		t.Error("esc should cancel without changing the message")
+	}
+}
+
+func TestRebaseModelRewordEmptyMessage(t *testing.T) {
+	m := newLoadedRebaseModel()
+
+	m, _ = pressRebaseKey(m, "r")
+	m.composer.SetValue("# only a comment")
+	m, _ = pressRebaseKey(m, "ctrl+s")
+	if !m.rewordMode || m.err == nil {
+		t.Error("an empty message should be refused")
+	}
+}
+
+func TestRebaseModelConfirm(t *testing.T) {
+	m := newLoadedRebaseModel()
+
+	m, _ = pressRebaseKey(m, "enter")
+	if !m.confirmMode {
+		t.Fatal("enter should ask to confirm the rebase")
+	}
+	if view := m.View(); !strings.Contains(view, "Rebase 3 commits onto a000000?") {
+		t.Errorf("view should show the confirm prompt, got:\n%s", view)
+	}
+
+	m, cmd := pressRebaseKey(m, "n")
+	if m.confirmMode || cmd != nil {
+		t.Error("n should cancel")
+	}
+
+	m, _ = pressRebaseKey(m, "enter")
+	m, cmd = pressRebaseKey(m, "y")
+	if !m.running || cmd == nil {
+		t.Error("y should start the rebase")
+	}
+}
+
+func TestRebaseModelStopped(t *testing.T) {
+	m := newLoadedRebaseModel()
+	m.running = true
+
+	newModel, _ := m.Update(rebaseResultMsg{
+		operation:  git.Operation{Kind: git.OperationRebase, Step: 2, Total: 3},
+		conflicted: true,
+		err:        errors.New("could not apply"),
+	})
+	m = newModel.(RebaseModel)
+	if m.running || !m.stopped() {
+		t.Fatal("the planner should know the rebase stopped")
+	}
+	view := m.View()
+	if !strings.Contains(view, "The rebase 2/3 stopped with conflicts.") || !strings.Contains(view, "press + to continue or X to abort") {
+		t.Errorf("view should offer continue and abort, got:\n%s", view)
+	}
+
+	// The plan can't be changed any more
+	m, _ = pressRebaseKey(m, "d")
+	if m.steps[0].Action != git.RebasePick {
+		t.Error("actions should be ignored once stopped")
+	}
+
+	m, cmd := pressRebaseKey(m, "+")
+	if !m.running || cmd == nil {
+		t.Error("+ should continue the rebase")
+	}
+
+	m.running = false
+	m, _ = pressRebaseKey(m, "X")
+	if !m.confirmAbort {
+		t.Fatal("X should ask to confirm the abort")
+	}
+	m, cmd = pressRebaseKey(m, "y")
+	if !m.running || cmd == nil {
+		t.Error("y should abort the rebase")
+	}
+}
+
+func TestRebaseModelStoppedForEdit(t *testing.T) {
+	m := newLoadedRebaseModel()
+
+	newModel, _ := m.Update(rebaseResultMsg{operation: git.Operation{Kind: git.OperationRebase, Step: 1, Total: 3}})
+	m = newModel.(RebaseModel)
+	if view := m.View(); !strings.Contains(view, "stopped to edit a commit") {
+		t.Errorf("view should say the rebase stopped for an edit, got:\n%s", view)
+	}
+}
@@ -0,0 +1,543 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go-on-git/internal/git"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// reflogLimit caps the number of reflog entries loaded
+const reflogLimit = 500
+
+// reflogConfirm is the action the reflog view asks to confirm
+type reflogConfirm int
+
+const (
+	reflogConfirmNone reflogConfirm = iota
+	reflogConfirmCheckout
+	reflogConfirmHardReset // requires typing 'yes'
+	reflogConfirmUndo
+)
+
+// ReflogModel is the bubbletea model for the reflog view
+type ReflogModel struct {
+	entries          []git.ReflogEntry
+	loaded           bool
+	cursor           int
+	scrollOffset     int
+	confirmMode      reflogConfirm
+	hardResetConfirm typedConfirm
+	resetMode        bool // choosing soft/mixed/hard
+	resetPicker      picker
+	branchMode       bool // typing the name of a branch at the entry
+	branchInput      textinput.Model
+	undoPlan         git.UndoPlan
+	notice           string // result of the last action
+	showHelp         bool
+	showVerboseHelp  bool
+	lastKey          string
+	err              error
+	width            int
+	height           int
+}
+
+// NewReflogModel creates a new reflog model
+func NewReflogModel() ReflogModel {
+	return NewReflogModelWithOptions(false)
+}
+
+// NewReflogModelWithOptions creates a new reflog model with options
+func NewReflogModelWithOptions(showVerboseHelp bool) ReflogModel {
+	bi := textinput.New()
+	bi.Placeholder = "Branch name"
+	bi.CharLimit = 100
+	bi.Width = 40
+
+	return ReflogModel{
+		branchInput:     bi,
+		showVerboseHelp: showVerboseHelp,
+	}
+}
+
+type reflogMsg struct {
+	entries []git.ReflogEntry
+	notice  string
+}
+
+// Init initializes the model
+func (m ReflogModel) Init() tea.Cmd {
+	return refreshReflog
+}
+
+func refreshReflog() tea.Msg {
+	entries, err := git.GetReflog(reflogLimit)
+	if err != nil {
+		return errMsg{err}
+	}
+	return reflogMsg{entries: entries}
+}
+
+// inPrompt returns true while an input, confirmation or picker is open
+func (m ReflogModel) inPrompt() bool {
+	return m.showHelp || m.confirmMode != reflogConfirmNone || m.resetMode || m.branchMode
+}
+
+// SelectedEntry returns the reflog entry under the cursor
+func (m ReflogModel) SelectedEntry() (git.ReflogEntry, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.entries) {
+		return git.ReflogEntry{}, false
+	}
+	return m.entries[m.cursor], true
+}
+
+// Update handles messages
+func (m ReflogModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		key := msg.String()
+
+		// Handle help mode
+		if m.showHelp {
+			if key == Keys.Help || key == "esc" || key == Keys.Quit {
+				m.showHelp = false
+			}
+			return m, nil
+		}
+
+		// Handle the branch name prompt
+		if m.branchMode {
+			switch key {
+			case "enter":
+				name := strings.TrimSpace(m.branchInput.Value())
+				m.branchMode = false
+				m.branchInput.Blur()
+				if name == "" {
+					return m, nil
+				}
+				return m, m.doCreateBranch(name)
+			case "esc":
+				m.branchMode = false
+				m.branchInput.Blur()
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.branchInput, cmd = m.branchInput.Update(msg)
+			return m, cmd
+		}
+
+		// Handle the reset mode picker
+		if m.resetMode {
+			if m.resetPicker.move(key) {
+				return m, nil
+			}
+			switch key {
+			case "enter":
+				m.resetMode = false
+				mode := resetModes[m.resetPicker.cursor]
+				if mode == git.ResetHard {
+					m.confirmMode = reflogConfirmHardReset
+					m.hardResetConfirm = typedConfirm{}
+					return m, nil
+				}
+				return m, m.doReset(mode)
+			case "esc", Keys.Quit:
+				m.resetMode = false
+			}
+			return m, nil
+		}
+
+		// Handle confirm mode
+		if m.confirmMode == reflogConfirmHardReset {
+			switch m.hardResetConfirm.update(key) {
+			case typedConfirmAccepted:
+				m.confirmMode = reflogConfirmNone
+				return m, m.doReset(git.ResetHard)
+			case typedConfirmCancelled:
+				m.confirmMode = reflogConfirmNone
+			}
+			return m, nil
+		}
+		if m.confirmMode != reflogConfirmNone {
+			switch key {
+			case "y", "Y":
+				action := m.confirmMode
+				m.confirmMode = reflogConfirmNone
+				if action == reflogConfirmUndo {
+					return m, m.doUndo()
+				}
+				return m, m.doCheckout()
+			case "n", "N", "esc":
+				m.confirmMode = reflogConfirmNone
+			}
+			return m, nil
+		}
+
+		// Check for gg sequence
+		if m.lastKey == Keys.Top && key == Keys.Top {
+			m.lastKey = ""
+			m.cursor = 0
+			m.ensureCursorVisible()
+			return m, nil
+		}
+
+		if key == Keys.Top {
+			m.lastKey = Keys.Top
+			return m, nil
+		}
+		m.lastKey = ""
+
+		switch key {
+		case Keys.Help:
+			m.showHelp = true
+			return m, nil
+		case Keys.VerboseHelp:
+			m.showVerboseHelp = !m.showVerboseHelp
+			return m, nil
+		case Keys.Down, "down":
+			if len(m.entries) > 0 {
+				m.cursor = min(m.cursor+1, len(m.entries)-1)
+				m.ensureCursorVisible()
+			}
+			return m, nil
+		case Keys.Up, "up":
+			if len(m.entries) > 0 {
+				m.cursor = max(m.cursor-1, 0)
+				m.ensureCursorVisible()
+			}
+			return m, nil
+		case Keys.Bottom:
+			if len(m.entries) > 0 {
+				m.cursor = len(m.entries) - 1
+				m.ensureCursorVisible()
+			}
+			return m, nil
+		case Keys.Right, "right", "enter":
+			if _, ok := m.SelectedEntry(); ok {
+				m.err = nil
+				m.notice = ""
+				m.confirmMode = reflogConfirmCheckout
+			}
+			return m, nil
+		case Keys.Reset:
+			if entry, ok := m.SelectedEntry(); ok {
+				m.err = nil
+				m.notice = ""
+				m.resetMode = true
+				options := make([]string, len(resetModes))
+				for i, mode := range resetModes {
+					options[i] = mode.String()
+				}
+				m.resetPicker = newPicker(fmt.Sprintf("Reset HEAD to %s (%s):", entry.Selector, git.ShortHash(entry.Hash)), options, 1)
+			}
+			return m, nil
+		case Keys.NewBranch:
+			if _, ok := m.SelectedEntry(); ok {
+				m.err = nil
+				m.notice = ""
+				m.branchMode = true
+				m.branchInput.Reset()
+				m.branchInput.Focus()
+				return m, textinput.Blink
+			}
+			return m, nil
+		case Keys.Undo:
+			plan, err := git.GetUndoPlan()
+			if err != nil {
+				m.err = err
+				return m, nil
+			}
+			m.err = nil
+			m.notice = ""
+			m.undoPlan = plan
+			m.confirmMode = reflogConfirmUndo
+			return m, nil
+		}
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case reflogMsg:
+		m.entries = msg.entries
+		m.loaded = true
+		m.err = nil
+		m.notice = msg.notice
+		if m.cursor >= len(m.entries) {
+			m.cursor = max(0, len(m.entries)-1)
+		}
+		m.ensureCursorVisible()
+		return m, nil
+
+	case errMsg:
+		m.err = msg.err
+		m.notice = ""
+		return m, nil
+	}
+
+	return m, nil
+}
+
+func (m ReflogModel) doCheckout() tea.Cmd {
+	entry, ok := m.SelectedEntry()
+	if !ok {
+		return nil
+	}
+	return func() tea.Msg {
+		if err := git.CheckoutCommit(entry.Hash); err != nil {
+			return errMsg{err}
+		}
+		return withReflogNotice(refreshReflog(), fmt.Sprintf("Checked out %s (detached HEAD)", git.ShortHash(entry.Hash)))
+	}
+}
+
+func (m ReflogModel) doReset(mode git.ResetMode) tea.Cmd {
+	entry, ok := m.SelectedEntry()
+	if !ok {
+		return nil
+	}
+	return func() tea.Msg {
+		if err := git.Reset(entry.Hash, mode); err != nil {
+			return errMsg{err}
+		}
+		return withReflogNotice(refreshReflog(), fmt.Sprintf("Reset (%s) to %s", mode, git.ShortHash(entry.Hash)))
+	}
+}
+
+func (m ReflogModel) doCreateBranch(name string) tea.Cmd {
+	entry, ok := m.SelectedEntry()
+	if !ok {
+		return nil
+	}
+	return func() tea.Msg {
+		if err := git.CreateBranchAt(name, entry.Hash); err != nil {
+			return errMsg{err}
+		}
+		return withReflogNotice(refreshReflog(), fmt.Sprintf("Created branch '%s' at %s", name, git.ShortHash(entry.Hash)))
+	}
+}
+
+func (m ReflogModel) doUndo() tea.Cmd {
+	plan := m.undoPlan
+	return func() tea.Msg {
+		if err := git.Undo(plan); err != nil {
+			return errMsg{err}
+		}
+		return withReflogNotice(refreshReflog(), fmt.Sprintf("Undid %s", plan.Entry.Action))
+	}
+}
+
+// withReflogNotice adds a notice to a refreshed reflog
+func withReflogNotice(msg tea.Msg, notice string) tea.Msg {
+	if reflog, ok := msg.(reflogMsg); ok {
+		reflog.notice = notice
+		return reflog
+	}
+	return msg
+}
+
+// undoPrompt asks to confirm an undo, spelling out the command it runs
+func undoPrompt(plan git.UndoPlan) string {
+	what := plan.Entry.Action
+	if plan.Entry.Message != "" {
+		what += fmt.Sprintf(" '%s'", plan.Entry.Message)
+	}
+	return fmt.Sprintf("Undo %s? This runs %s (y/n) ", what, plan.Command())
+}
+
+// visibleLines returns the number of reflog lines that can be displayed
+func (m ReflogModel) visibleLines() int {
+	// Reserve lines for: header (~3), help bar (~3 if shown), prompts, and buffer
+	reserved := 8
+	if m.showVerboseHelp {
+		reserved += 3
+	}
+	if m.height <= reserved {
+		return 10 // fallback minimum
+	}
+	return m.height - reserved
+}
+
+// ensureCursorVisible adjusts scrollOffset to keep cursor in view
+func (m *ReflogModel) ensureCursorVisible() {
+	visible := m.visibleLines()
+	if m.cursor < m.scrollOffset {
+		m.scrollOffset = m.cursor
+	}
+	if m.cursor >= m.scrollOffset+visible {
+		m.scrollOffset = m.cursor - visible + 1
+	}
+	m.scrollOffset = max(0, min(m.scrollOffset, len(m.entries)-visible))
+}
+
+// View renders the model
+func (m ReflogModel) View() string {
+	if m.showHelp {
+		return m.renderHelp()
+	}
+
+	var sb strings.Builder
+
+	if m.err != nil {
+		sb.WriteString(StyleUnstaged.Render(fmt.Sprintf("Error: %v", m.err)))
+		sb.WriteString("\n\n")
+	}
+
+	sb.WriteString(m.renderHeader())
+	sb.WriteString("\n\n")
+
+	switch {
+	case !m.loaded:
+		sb.WriteString(StyleMuted.Render("Loading..."))
+		sb.WriteString("\n")
+	case len(m.entries) == 0:
+		sb.WriteString(StyleEmpty.Render("No reflog entries"))
+		sb.WriteString("\n")
+	}
+
+	visibleEnd := min(m.scrollOffset+m.visibleLines(), len(m.entries))
+	if m.scrollOffset > 0 {
+		sb.WriteString(StyleMuted.Render(fmt.Sprintf("  ↑ %d more above", m.scrollOffset)))
+		sb.WriteString("\n")
+	}
+
+	now := time.Now()
+	for i := m.scrollOffset; i < visibleEnd; i++ {
+		entry := m.entries[i]
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+		}
+
+		sb.WriteString(prefix)
+		sb.WriteString(StyleCommitHash.Render(git.ShortHash(entry.Hash)))
+		sb.WriteString(" ")
+		sb.WriteString(StyleRefHead.Render(entry.Selector))
+		sb.WriteString(" ")
+		sb.WriteString(StyleRefBranch.Render(entry.Action + ":"))
+		sb.WriteString(" ")
+		sb.WriteString(entry.Message)
+		if entry.Subject != "" && entry.Subject != entry.Message {
+			sb.WriteString(StyleMuted.Render(fmt.Sprintf(" (%s)", entry.Subject)))
+		}
+		if !entry.Date.IsZero() {
+			sb.WriteString(StyleMuted.Render(" - " + formatRelativeTime(entry.Date, now)))
+		}
+		sb.WriteString("\n")
+	}
+
+	if visibleEnd < len(m.entries) {
+		sb.WriteString(StyleMuted.Render(fmt.Sprintf("  ↓ %d more below", len(m.entries)-visibleEnd)))
+		sb.WriteString("\n")
+	}
+
+	if m.notice != "" {
+		sb.WriteString("\n")
+		sb.WriteString(StyleMuted.Render(m.notice))
+		sb.WriteString("\n")
+	}
+
+	// Prompts
+	if prompt := m.renderPrompt(); prompt != "" {
+		sb.WriteString("\n")
+		sb.WriteString(prompt)
+	}
+
+	// Help bar (only show when showVerboseHelp is on and not in a prompt)
+	if m.showVerboseHelp && !m.inPrompt() {
+		sb.WriteString("\n\n")
+		sb.WriteString(m.renderHelpBar())
+	}
+
+	return sb.String()
+}
+
+// renderPrompt renders the active confirmation, picker or input
+func (m ReflogModel) renderPrompt() string {
+	entry, _ := m.SelectedEntry()
+	target := fmt.Sprintf("%s (%s)", entry.Selector, git.ShortHash(entry.Hash))
+
+	switch {
+	case m.branchMode:
+		return fmt.Sprintf("New branch at %s: ", target) + m.branchInput.View() + StyleMuted.Render("  (enter to create, esc to cancel)")
+	case m.resetMode:
+		return m.resetPicker.View()
+	case m.confirmMode == reflogConfirmCheckout:
+		return StyleConfirm.Render(fmt.Sprintf("Check out %s? HEAD will be detached (y/n) ", target))
+	case m.confirmMode == reflogConfirmHardReset:
+		return StyleConfirm.Render(fmt.Sprintf("Hard reset to %s? Uncommitted changes will be lost. Type 'yes' to confirm: %s", target, m.hardResetConfirm.input))
+	case m.confirmMode == reflogConfirmUndo:
+		return StyleConfirm.Render(undoPrompt(m.undoPlan))
+	}
+	return ""
+}
+
+func (m ReflogModel) renderHeader() string {
+	return StyleMuted.Render("> git reflog") + "  " + StyleMuted.Render("(esc to go back)") + "\n" + StyleMuted.Render("───────────────────────────────────────────────────────────────")
+}
+
+func (m ReflogModel) renderHelpBar() string {
+	var sb strings.Builder
+
+	sb.WriteString(StyleMuted.Render("───────────────────────────────────────────────────────────────"))
+	sb.WriteString("\n")
+
+	items := []struct{ key, desc string }{
+		{formatKeyList(Keys.Down, Keys.Up), "navigate"},
+		{formatKeyList(Keys.Right, "Enter"), "checkout"},
+		{Keys.Reset, "reset"},
+		{Keys.NewBranch, "branch"},
+		{Keys.Undo, "undo"},
+		{Keys.Help, "help"},
+		{formatKeyList(Keys.Left, "ESC"), "back"},
+	}
+
+	for _, item := range items {
+		sb.WriteString(StyleHelpKey.Render(item.key))
+		sb.WriteString(" ")
+		sb.WriteString(StyleHelpDesc.Render(item.desc))
+		sb.WriteString("  ")
+	}
+
+	return sb.String()
+}
+
+func (m ReflogModel) renderHelp() string {
+	var sb strings.Builder
+
+	sb.WriteString(StyleHelpTitle.Render("Reflog Shortcuts"))
+	sb.WriteString("\n\n")
+
+	moveKeys := formatKeyList(Keys.Down, Keys.Up, "↓", "↑")
+	topKey := formatDoubleKey(Keys.Top)
+	checkoutKeys := formatKeyList(Keys.Right, "→", "Enter")
+	backKeys := formatKeyList(Keys.Left, "←", "ESC")
+
+	help := []struct {
+		key  string
+		desc string
+	}{
+		{moveKeys, "Move down/up"},
+		{topKey, "Go to top"},
+		{Keys.Bottom, "Go to bottom"},
+		{checkoutKeys, "Check out entry (detached HEAD)"},
+		{Keys.Reset, "Reset HEAD to entry (soft/mixed/hard)"},
+		{Keys.NewBranch, "New branch at entry"},
+		{Keys.Undo, "Undo the last git operation"},
+		{Keys.Help, "Toggle help"},
+		{backKeys, "Go back"},
+	}
+
+	for _, h := range help {
+		sb.WriteString(fmt.Sprintf("  %s  %s\n",
+			StyleHelpKey.Render(fmt.Sprintf("%-8s", h.key)),
+			StyleHelpDesc.Render(h.desc)))
+	}
+
+	return sb.String()
+}
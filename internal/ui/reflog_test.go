@@ -0,0 +1,195 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go-on-git/internal/git"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func makeReflog() []git.ReflogEntry {
+	now := time.Now()
+	return []git.ReflogEntry{
+		{Selector: "HEAD@{0}", Hash: "3333333333333333333333333333333333333333", Action: "reset", Message: "moving to HEAD~1", Subject: "Second", Date: now},
+		{Selector: "HEAD@{1}", Hash: "2222222222222222222222222222222222222222", Action: "commit", Message: "Third", Subject: "Third", Date: now.Add(-time.Hour)},
+		{Selector: "HEAD@{2}", Hash: "1111111111111111111111111111111111111111", Action: "checkout", Message: "moving from main to feature", Subject: "Second", Date: now.Add(-2 * time.Hour)},
+	}
+}
+
+func loadedReflogModel() ReflogModel {
+	m := NewReflogModel()
+	m.entries = makeReflog()
+	m.loaded = true
+	return m
+}
+
+func TestNewReflogModel(t *testing.T) {
+	m := NewReflogModel()
+
+	if m.cursor != 0 {
+		t.Errorf("cursor = %d, want 0", m.cursor)
+	}
+	if m.loaded {
+		t.Error("loaded should be false initially")
+	}
+	if m.Init() == nil {
+		t.Error("Init() should return a command")
+	}
+}
+
+func TestReflogModelView(t *testing.T) {
+	m := loadedReflogModel()
+
+	view := m.View()
+
+	if !strings.Contains(view, "> git reflog") {
+		t.Error("view should contain the header")
+	}
+	for _, want := range []string{"HEAD@{0}", "3333333", "reset:", "moving to HEAD~1", "(Second)", "1 hour ago"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q", want)
+		}
+	}
+	// A commit's message is its subject, so it isn't repeated
+	if strings.Contains(view, "(Third)") {
+		t.Error("view should not repeat the subject of a commit entry")
+	}
+}
+
+func TestReflogModelViewEmpty(t *testing.T) {
+	m := NewReflogModel()
+
+	newModel, _ := m.Update(reflogMsg{})
+	m = newModel.(ReflogModel)
+
+	if !strings.Contains(m.View(), "No reflog entries") {
+		t.Error("view should show 'No reflog entries' when there are none")
+	}
+}
+
+func TestReflogModelCheckout(t *testing.T) {
+	m := loadedReflogModel()
+	m.cursor = 1
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(ReflogModel)
+	if m.confirmMode != reflogConfirmCheckout || !m.inPrompt() {
+		t.Fatal("enter should ask to confirm the checkout")
+	}
+	if view := m.View(); !strings.Contains(view, "Check out HEAD@{1} (2222222)? HEAD will be detached") {
+		t.Errorf("view should show the checkout prompt, got:\n%s", view)
+	}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	m = newModel.(ReflogModel)
+	if m.confirmMode != reflogConfirmNone || cmd != nil {
+		t.Error("n should cancel the checkout")
+	}
+
+	m.confirmMode = reflogConfirmCheckout
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if cmd == nil {
+		t.Error("y should check out the entry")
+	}
+}
+
+func TestReflogModelResetPicker(t *testing.T) {
+	m := loadedReflogModel()
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	m = newModel.(ReflogModel)
+	if !m.resetMode {
+		t.Fatal("x should open the reset picker")
+	}
+	if m.resetPicker.selected() != "mixed" {
+		t.Errorf("picker should default to mixed, got %q", m.resetPicker.selected())
+	}
+	if view := m.View(); !strings.Contains(view, "Reset HEAD to HEAD@{0} (3333333):") {
+		t.Errorf("view should show the reset picker, got:\n%s", view)
+	}
+
+	// Choosing hard asks to type 'yes'
+	m.resetPicker.cursor = 2
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(ReflogModel)
+	if cmd != nil || m.confirmMode != reflogConfirmHardReset {
+		t.Fatal("a hard reset should ask for confirmation")
+	}
+	for _, r := range "yes" {
+		newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = newModel.(ReflogModel)
+	}
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(ReflogModel)
+	if cmd == nil || m.confirmMode != reflogConfirmNone {
+		t.Error("typing 'yes' should run the hard reset")
+	}
+}
+
+func TestReflogModelNewBranch(t *testing.T) {
+	m := loadedReflogModel()
+	m.cursor = 2
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	m = newModel.(ReflogModel)
+	if !m.branchMode {
+		t.Fatal("n should open the branch name prompt")
+	}
+	if view := m.View(); !strings.Contains(view, "New branch at HEAD@{2} (1111111):") {
+		t.Errorf("view should show the branch prompt, got:\n%s", view)
+	}
+
+	// An empty name cancels
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(ReflogModel)
+	if m.branchMode || cmd != nil {
+		t.Error("enter with an empty name should cancel")
+	}
+
+	m.branchMode = true
+	m.branchInput.SetValue("rescued")
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(ReflogModel)
+	if m.branchMode || cmd == nil {
+		t.Error("enter with a name should create the branch")
+	}
+}
+
+func TestReflogModelUndoPrompt(t *testing.T) {
+	m := loadedReflogModel()
+	m.undoPlan = git.UndoPlan{
+		Entry:  m.entries[0],
+		Target: m.entries[1],
+		Mode:   git.ResetKeep,
+	}
+	m.confirmMode = reflogConfirmUndo
+
+	if view := m.View(); !strings.Contains(view, "Undo reset 'moving to HEAD~1'? This runs git reset --keep 2222222 (y/n)") {
+		t.Errorf("view should show what the undo runs, got:\n%s", view)
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if cmd == nil {
+		t.Error("y should run the undo")
+	}
+}
+
+func TestReflogModelNotice(t *testing.T) {
+	m := loadedReflogModel()
+
+	newModel, _ := m.Update(withReflogNotice(reflogMsg{entries: makeReflog()}, "Created branch 'rescued' at 1111111"))
+	m = newModel.(ReflogModel)
+	if !strings.Contains(m.View(), "Created branch 'rescued' at 1111111") {
+		t.Error("view should show the notice")
+	}
+
+	newModel, _ = m.Update(errMsg{errors.New("boom")})
+	m = newModel.(ReflogModel)
+	if m.notice != "" || !strings.Contains(m.View(), "Error: boom") {
+		t.Error("an error should replace the notice")
+	}
+}
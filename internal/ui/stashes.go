@@ -3,19 +3,27 @@ package ui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"go-on-git/internal/git"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 // StashDiffModel is a simplified diff view for stash contents
 type StashDiffModel struct {
-	diff         *git.CombinedDiffResult
+	diff         *git.StashDiff
 	hunks        []git.Hunk
+	parts        []git.StashPart // part of the stash each hunk belongs to
 	cursor       int
 	viewingHunk  bool
 	scrollOffset int
+	visualMode   bool // true when selecting a range of hunks to apply
+	visualStart  int  // hunk where the visual selection started
+	compare      bool // showing the stash against the working tree
+	stashIndex   int  // stash being compared
+	notice       string
 	showHelp     bool
 	err          error
 	width        int
@@ -30,6 +38,43 @@ func NewStashDiffModel(width, height int) StashDiffModel {
 	}
 }
 
+// NewStashCompareModel creates a stash diff model showing how the working
+// tree differs from a stash
+func NewStashCompareModel(index, width, height int) StashDiffModel {
+	return StashDiffModel{
+		compare:    true,
+		stashIndex: index,
+		width:      width,
+		height:     height,
+	}
+}
+
+type stashCompareMsg struct {
+	diff   *git.DiffResult
+	notice string
+}
+
+// stashAppliedMsg reports hunks applied from a stash
+type stashAppliedMsg struct {
+	notice string
+}
+
+func loadStashCompare(index int) tea.Cmd {
+	return func() tea.Msg {
+		diff, err := git.GetStashCompareDiff(index)
+		if err != nil {
+			return errMsg{err}
+		}
+		return stashCompareMsg{diff: diff}
+	}
+}
+
+// inPrompt returns true while the help is open or hunks are selected,
+// where esc doesn't go back
+func (m StashDiffModel) inPrompt() bool {
+	return m.showHelp || m.visualMode
+}
+
 // Init initializes the model
 func (m StashDiffModel) Init() tea.Cmd {
 	return nil
@@ -77,6 +122,10 @@ func (m StashDiffModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case Keys.Top:
 				m.scrollOffset = 0
 				return m, nil
+			case Keys.StashApply:
+				return m, m.applyHunks(m.cursor, m.cursor)
+			case Keys.StashApplyFile:
+				return m, m.applyFile()
 			case Keys.Help:
 				m.showHelp = true
 				return m, nil
@@ -88,8 +137,27 @@ func (m StashDiffModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case Keys.Help:
 			m.showHelp = true
 			return m, nil
+		case "esc":
+			m.visualMode = false
+			return m, nil
+		case Keys.Visual:
+			if m.visualMode {
+				m.visualMode = false
+			} else if len(m.hunks) > 0 {
+				m.visualMode = true
+				m.visualStart = m.cursor
+			}
+			return m, nil
+		case Keys.StashApply:
+			start, end := m.selectionRange()
+			m.visualMode = false
+			return m, m.applyHunks(start, end)
+		case Keys.StashApplyFile:
+			m.visualMode = false
+			return m, m.applyFile()
 		case Keys.Right, "right":
 			if len(m.hunks) > 0 && m.cursor < len(m.hunks) {
+				m.visualMode = false
 				m.viewingHunk = true
 				m.scrollOffset = 0
 			}
@@ -120,24 +188,159 @@ func (m StashDiffModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case stashDiffMsg:
-		m.diff = msg.diff
-		m.hunks = msg.diff.GetAllHunksCombined()
-		m.cursor = 0
-		// Auto-enter detail view when there's only one hunk
-		if len(m.hunks) == 1 {
-			m.viewingHunk = true
-			m.scrollOffset = 0
+		m.setDiff(msg.diff)
+		return m, nil
+
+	case stashCompareMsg:
+		cursor := m.cursor
+		m.setDiff(&git.StashDiff{Worktree: msg.diff})
+		m.cursor = min(cursor, max(len(m.hunks)-1, 0))
+		if len(m.hunks) != 1 {
+			m.viewingHunk = false
 		}
+		m.err = nil
+		m.notice = msg.notice
+		return m, nil
+
+	case stashAppliedMsg:
+		m.err = nil
+		m.notice = msg.notice
 		return m, nil
 
 	case errMsg:
 		m.err = msg.err
+		m.notice = ""
 		return m, nil
 	}
 
 	return m, nil
 }
 
+// selectionRange returns the range of hunks in the visual selection, or
+// the hunk under the cursor
+func (m StashDiffModel) selectionRange() (int, int) {
+	if !m.visualMode {
+		return m.cursor, m.cursor
+	}
+	return min(m.visualStart, m.cursor), max(m.visualStart, m.cursor)
+}
+
+// applyFile applies the hunks of the file under the cursor that are in the
+// same part of the stash
+func (m StashDiffModel) applyFile() tea.Cmd {
+	if m.cursor >= len(m.hunks) {
+		return nil
+	}
+	start, end := m.cursor, m.cursor
+	same := func(i int) bool {
+		return m.hunks[i].FilePath == m.hunks[m.cursor].FilePath && m.partOf(i) == m.partOf(m.cursor)
+	}
+	for start > 0 && same(start-1) {
+		start--
+	}
+	for end < len(m.hunks)-1 && same(end+1) {
+		end++
+	}
+	return m.applyHunks(start, end)
+}
+
+// applyHunks applies the hunks from start to end to the working tree with
+// git apply, leaving the stash alone
+func (m StashDiffModel) applyHunks(start, end int) tea.Cmd {
+	if m.diff == nil || start < 0 || end >= len(m.hunks) {
+		return nil
+	}
+	var patch strings.Builder
+	for i := start; i <= end; i++ {
+		hunk := m.hunks[i]
+		diff := m.diff.Part(m.partOf(i))
+		if diff == nil || hunk.FileIndex >= len(diff.Files) {
+			continue
+		}
+		patch.WriteString(hunk.GeneratePatch(&diff.Files[hunk.FileIndex]))
+	}
+	if patch.Len() == 0 {
+		return nil
+	}
+
+	notice := fmt.Sprintf("Applied %d hunks to the working tree", end-start+1)
+	if start == end {
+		notice = fmt.Sprintf("Applied hunk from '%s' to the working tree", m.hunks[start].DisplayFilePath)
+	}
+	compare, index := m.compare, m.stashIndex
+	return func() tea.Msg {
+		if err := git.ApplyStashPatch(patch.String()); err != nil {
+			return errMsg{err}
+		}
+		if compare {
+			// The working tree moved closer to the stash
+			msg := loadStashCompare(index)()
+			if compared, ok := msg.(stashCompareMsg); ok {
+				compared.notice = notice
+				return compared
+			}
+			return msg
+		}
+		return stashAppliedMsg{notice: notice}
+	}
+}
+
+// setDiff lists the hunks of the stash, grouped by part
+func (m *StashDiffModel) setDiff(diff *git.StashDiff) {
+	m.diff = diff
+	m.hunks = nil
+	m.parts = nil
+	for _, part := range git.StashParts {
+		if d := diff.Part(part); d != nil {
+			for _, hunk := range d.GetAllHunks() {
+				m.hunks = append(m.hunks, hunk)
+				m.parts = append(m.parts, part)
+			}
+		}
+	}
+	m.cursor = 0
+	// Auto-enter detail view when there's only one hunk
+	if len(m.hunks) == 1 {
+		m.viewingHunk = true
+		m.scrollOffset = 0
+	}
+}
+
+// partOf returns the part of the stash the i-th hunk is in
+func (m StashDiffModel) partOf(i int) git.StashPart {
+	if i < len(m.parts) {
+		return m.parts[i]
+	}
+	return git.StashWorktree
+}
+
+// partTitle is the heading of a part of the stash in the hunk list
+func (m StashDiffModel) partTitle(part git.StashPart) string {
+	if m.compare {
+		return "Working tree → stash"
+	}
+	switch part {
+	case git.StashIndex:
+		return "Staged (index)"
+	case git.StashWorktree:
+		return "Unstaged (working tree)"
+	default:
+		return "Untracked files"
+	}
+}
+
+// renderStashPartLabel labels a hunk with the part of the stash it is in
+func renderStashPartLabel(part git.StashPart) string {
+	switch part {
+	case git.StashIndex:
+		return StyleHunkHeaderStaged.Render("[S]")
+	case git.StashWorktree:
+		return StyleHunkHeaderUnstaged.Render("[U]")
+	default:
+		return StyleUntracked.Render("[?]")
+	}
+}
+
 func (m StashDiffModel) visibleLines() int {
 	if m.height <= 5 {
 		return 40
@@ -168,8 +371,16 @@ func (m StashDiffModel) View() string {
 	}
 
 	if m.diff == nil || len(m.hunks) == 0 {
-		sb.WriteString(StyleEmpty.Render("No changes in stash"))
+		if m.compare && m.diff != nil {
+			sb.WriteString(StyleEmpty.Render("The working tree matches the stash"))
+		} else {
+			sb.WriteString(StyleEmpty.Render("No changes in stash"))
+		}
 		sb.WriteString("\n")
+		if m.notice != "" {
+			sb.WriteString(StyleMuted.Render(m.notice))
+			sb.WriteString("\n")
+		}
 		return sb.String()
 	}
 
@@ -180,6 +391,11 @@ func (m StashDiffModel) View() string {
 
 	// Hunk list view with preview
 	fixedLines := len(m.hunks)
+	for i := range m.hunks {
+		if i == 0 || m.partOf(i) != m.partOf(i-1) {
+			fixedLines++ // part heading
+		}
+	}
 	availableForDetail := 50
 	if m.height > fixedLines+5 {
 		availableForDetail = m.height - fixedLines - 3
@@ -188,7 +404,7 @@ func (m StashDiffModel) View() string {
 	// Show current hunk preview first (above the list)
 	if m.cursor < len(m.hunks) && availableForDetail > 0 {
 		hunk := m.hunks[m.cursor]
-		sb.WriteString(fmt.Sprintf("─── %s %s ───", hunk.DisplayFilePath, hunk.Header))
+		sb.WriteString(fmt.Sprintf("─── %s %s %s ───", renderStashPartLabel(m.partOf(m.cursor)), hunk.DisplayFilePath, hunk.Header))
 		sb.WriteString("\n")
 
 		totalLines := len(hunk.Lines)
@@ -216,8 +432,14 @@ func (m StashDiffModel) View() string {
 		sb.WriteString("\n")
 	}
 
-	// Show hunk list at the bottom
+	// Show hunk list at the bottom, grouped by part
+	selStart, selEnd := m.selectionRange()
 	for i, h := range m.hunks {
+		if i == 0 || m.partOf(i) != m.partOf(i-1) {
+			sb.WriteString(StyleSectionHeader.Render(m.partTitle(m.partOf(i))))
+			sb.WriteString("\n")
+		}
+
 		cursor := "  "
 		if i == m.cursor {
 			cursor = "> "
@@ -233,14 +455,26 @@ func (m StashDiffModel) View() string {
 			}
 		}
 
-		if i == m.cursor {
+		switch {
+		case m.visualMode && i >= selStart && i <= selEnd:
+			sb.WriteString(StyleVisual.Render(fmt.Sprintf("%s@@ %s +%d -%d", cursor, h.DisplayFilePath, adds, dels)))
+		case i == m.cursor:
 			sb.WriteString(StyleSelected.Render(fmt.Sprintf("%s@@ %s +%d -%d", cursor, h.DisplayFilePath, adds, dels)))
-		} else {
+		default:
 			sb.WriteString(fmt.Sprintf("%s@@ %s +%d -%d", cursor, h.DisplayFilePath, adds, dels))
 		}
 		sb.WriteString("\n")
 	}
 
+	if m.visualMode {
+		sb.WriteString(StyleVisual.Render("-- VISUAL --"))
+		sb.WriteString("\n")
+	}
+	if m.notice != "" {
+		sb.WriteString(StyleMuted.Render(m.notice))
+		sb.WriteString("\n")
+	}
+
 	return m.anchorBottom(sb.String())
 }
 
@@ -276,9 +510,14 @@ func (m StashDiffModel) renderHunkDetail() string {
 		sb.WriteString("\n")
 	}
 
-	sb.WriteString(fmt.Sprintf("─── %s %s ───", hunk.DisplayFilePath, hunk.Header))
+	sb.WriteString(fmt.Sprintf("─── %s %s %s ───", renderStashPartLabel(m.partOf(m.cursor)), hunk.DisplayFilePath, hunk.Header))
 	sb.WriteString("\n")
 
+	if m.notice != "" {
+		sb.WriteString(StyleMuted.Render(m.notice))
+		sb.WriteString("\n")
+	}
+
 	return sb.String()
 }
 
@@ -301,6 +540,9 @@ func (m StashDiffModel) renderHelp() string {
 		{backKeys, "Go back"},
 		{moveKeys, "Navigate / scroll"},
 		{topBottomKeys, "Go to top/bottom"},
+		{Keys.Visual, "Select hunks"},
+		{Keys.StashApply, "Apply the hunk or selected hunks to the working tree"},
+		{Keys.StashApplyFile, "Apply the file's hunks to the working tree"},
 		{Keys.Help, "Toggle help"},
 	}
 
@@ -322,6 +564,11 @@ type StashesModel struct {
 	showVerboseHelp bool
 	confirmMode     bool
 	confirmAction   string // "drop", "pop"
+	branchMode      bool   // typing the name of a branch for the stash
+	branchInput     textinput.Model
+	renameMode      bool // typing a new message for the stash
+	renameInput     textinput.Model
+	notice          string // result of the last action
 	diffModel       StashDiffModel
 	lastKey         string
 	err             error
@@ -336,11 +583,28 @@ func NewStashesModel() StashesModel {
 
 // NewStashesModelWithOptions creates a new stashes model with options
 func NewStashesModelWithOptions(showVerboseHelp bool) StashesModel {
+	bi := textinput.New()
+	bi.Placeholder = "Branch name"
+	bi.CharLimit = 100
+	bi.Width = 40
+
+	ri := textinput.New()
+	ri.Placeholder = "Stash message"
+	ri.CharLimit = 200
+	ri.Width = 50
+
 	return StashesModel{
+		branchInput:     bi,
+		renameInput:     ri,
 		showVerboseHelp: showVerboseHelp,
 	}
 }
 
+// inPrompt returns true while the help, a confirmation or an input is open
+func (m StashesModel) inPrompt() bool {
+	return m.showHelp || m.confirmMode || m.branchMode || m.renameMode
+}
+
 // Init initializes the model
 func (m StashesModel) Init() tea.Cmd {
 	return refreshStashes
@@ -351,7 +615,16 @@ func refreshStashes() tea.Msg {
 	if err != nil {
 		return errMsg{err}
 	}
-	return stashesMsg{stashes}
+	return stashesMsg{stashes: stashes}
+}
+
+// withStashesNotice adds a notice to a refreshed stash list
+func withStashesNotice(msg tea.Msg, notice string) tea.Msg {
+	if stashes, ok := msg.(stashesMsg); ok {
+		stashes.notice = notice
+		return stashes
+	}
+	return msg
 }
 
 // Update handles messages
@@ -390,6 +663,48 @@ func (m StashesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		// Handle the branch name prompt
+		if m.branchMode {
+			switch key {
+			case "enter":
+				name := strings.TrimSpace(m.branchInput.Value())
+				m.branchMode = false
+				m.branchInput.Blur()
+				if name == "" {
+					return m, nil
+				}
+				return m, m.doStashBranch(name)
+			case "esc":
+				m.branchMode = false
+				m.branchInput.Blur()
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.branchInput, cmd = m.branchInput.Update(msg)
+			return m, cmd
+		}
+
+		// Handle the rename prompt
+		if m.renameMode {
+			switch key {
+			case "enter":
+				message := strings.TrimSpace(m.renameInput.Value())
+				m.renameMode = false
+				m.renameInput.Blur()
+				if message == "" {
+					return m, nil
+				}
+				return m, m.doRenameStash(message)
+			case "esc":
+				m.renameMode = false
+				m.renameInput.Blur()
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.renameInput, cmd = m.renameInput.Update(msg)
+			return m, cmd
+		}
+
 		// Check for gg sequence
 		if m.lastKey == Keys.Top && key == Keys.Top {
 			m.lastKey = ""
@@ -429,26 +744,50 @@ func (m StashesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.ensureCursorVisible()
 			}
 			return m, nil
-		case "a":
+		case Keys.StashApply:
 			// Apply stash (keep in list)
 			if len(m.stashes) > 0 && m.cursor < len(m.stashes) {
+				m.notice = ""
 				return m, m.doApplyStash()
 			}
 			return m, nil
-		case "p":
+		case Keys.StashPop:
 			// Pop stash (apply and remove, with confirmation)
 			if len(m.stashes) > 0 && m.cursor < len(m.stashes) {
+				m.notice = ""
 				m.confirmMode = true
 				m.confirmAction = "pop"
 			}
 			return m, nil
-		case "d":
+		case Keys.StashDrop:
 			// Drop stash (with confirmation)
 			if len(m.stashes) > 0 && m.cursor < len(m.stashes) {
+				m.notice = ""
 				m.confirmMode = true
 				m.confirmAction = "drop"
 			}
 			return m, nil
+		case Keys.NewBranch:
+			if len(m.stashes) > 0 && m.cursor < len(m.stashes) {
+				m.err = nil
+				m.notice = ""
+				m.branchMode = true
+				m.branchInput.Reset()
+				m.branchInput.Focus()
+				return m, textinput.Blink
+			}
+			return m, nil
+		case Keys.StashRename:
+			if len(m.stashes) > 0 && m.cursor < len(m.stashes) {
+				m.err = nil
+				m.notice = ""
+				m.renameMode = true
+				m.renameInput.SetValue(m.stashes[m.cursor].Message)
+				m.renameInput.CursorEnd()
+				m.renameInput.Focus()
+				return m, textinput.Blink
+			}
+			return m, nil
 		}
 
 	case tea.WindowSizeMsg:
@@ -458,6 +797,8 @@ func (m StashesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case stashesMsg:
 		m.stashes = msg.stashes
+		m.err = nil
+		m.notice = msg.notice
 		if m.cursor >= len(m.stashes) {
 			m.cursor = max(0, len(m.stashes)-1)
 		}
@@ -465,22 +806,44 @@ func (m StashesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case stashDiffMsg:
-		m.diffModel.diff = msg.diff
-		m.diffModel.hunks = msg.diff.GetAllHunksCombined()
-		m.diffModel.cursor = 0
-		if len(m.diffModel.hunks) == 1 {
-			m.diffModel.viewingHunk = true
-		}
+		m.diffModel.setDiff(msg.diff)
 		return m, nil
 
 	case errMsg:
 		m.err = msg.err
+		m.notice = ""
 		return m, nil
 	}
 
 	return m, nil
 }
 
+func (m StashesModel) doStashBranch(name string) tea.Cmd {
+	if m.cursor >= len(m.stashes) {
+		return nil
+	}
+	stash := m.stashes[m.cursor]
+	return func() tea.Msg {
+		if err := git.StashBranch(stash.Index, name); err != nil {
+			return errMsg{err}
+		}
+		return withStashesNotice(refreshStashes(), fmt.Sprintf("Created branch '%s' from stash@{%d} and applied it", name, stash.Index))
+	}
+}
+
+func (m StashesModel) doRenameStash(message string) tea.Cmd {
+	if m.cursor >= len(m.stashes) {
+		return nil
+	}
+	stash := m.stashes[m.cursor]
+	return func() tea.Msg {
+		if err := git.RenameStash(stash.Index, message); err != nil {
+			return errMsg{err}
+		}
+		return withStashesNotice(refreshStashes(), fmt.Sprintf("Renamed stash@{%d}", stash.Index))
+	}
+}
+
 func (m StashesModel) doApplyStash() tea.Cmd {
 	if m.cursor >= len(m.stashes) {
 		return nil
@@ -566,6 +929,21 @@ func (m *StashesModel) ensureCursorVisible() {
 	}
 }
 
+// stashSummary describes the size and age of a stash, e.g. "3 files, 2 hours ago"
+func stashSummary(stash git.Stash, now time.Time) string {
+	var parts []string
+	switch {
+	case stash.Files == 1:
+		parts = append(parts, "1 file")
+	case stash.Files > 1:
+		parts = append(parts, fmt.Sprintf("%d files", stash.Files))
+	}
+	if !stash.Date.IsZero() {
+		parts = append(parts, formatRelativeTime(stash.Date, now))
+	}
+	return strings.Join(parts, ", ")
+}
+
 // View renders the model
 func (m StashesModel) View() string {
 	if m.showHelp {
@@ -601,6 +979,7 @@ func (m StashesModel) View() string {
 		sb.WriteString("\n")
 	}
 
+	now := time.Now()
 	for i := visibleStart; i < visibleEnd; i++ {
 		stash := m.stashes[i]
 		prefix := "  "
@@ -614,7 +993,18 @@ func (m StashesModel) View() string {
 		}
 		label += ": " + stash.Message
 
-		sb.WriteString(prefix + label)
+		sb.WriteString(prefix)
+		if stash.Hash != "" {
+			sb.WriteString(StyleCommitHash.Render(git.ShortHash(stash.Hash)))
+			sb.WriteString(" ")
+		}
+		sb.WriteString(label)
+		if stash.Options != (git.StashOptions{}) {
+			sb.WriteString(StyleMuted.Render(" [" + stash.Options.String() + "]"))
+		}
+		if stash.Files > 0 || !stash.Date.IsZero() {
+			sb.WriteString(StyleMuted.Render(" - " + stashSummary(stash, now)))
+		}
 		sb.WriteString("\n")
 	}
 
@@ -624,6 +1014,12 @@ func (m StashesModel) View() string {
 		sb.WriteString("\n")
 	}
 
+	if m.notice != "" {
+		sb.WriteString("\n")
+		sb.WriteString(StyleMuted.Render(m.notice))
+		sb.WriteString("\n")
+	}
+
 	// Confirm prompt
 	if m.confirmMode && m.cursor < len(m.stashes) {
 		sb.WriteString("\n")
@@ -636,8 +1032,19 @@ func (m StashesModel) View() string {
 		}
 	}
 
-	// Help bar (only show when showVerboseHelp is on and not in confirm mode)
-	if m.showVerboseHelp && !m.confirmMode {
+	// Inputs
+	if (m.branchMode || m.renameMode) && m.cursor < len(m.stashes) {
+		sb.WriteString("\n")
+		stash := m.stashes[m.cursor]
+		if m.branchMode {
+			sb.WriteString(fmt.Sprintf("New branch from stash@{%d}: ", stash.Index) + m.branchInput.View() + StyleMuted.Render("  (enter to create, esc to cancel)"))
+		} else {
+			sb.WriteString(fmt.Sprintf("Rename stash@{%d}: ", stash.Index) + m.renameInput.View() + StyleMuted.Render("  (enter to rename, esc to cancel)"))
+		}
+	}
+
+	// Help bar (only show when showVerboseHelp is on and not in a prompt)
+	if m.showVerboseHelp && !m.inPrompt() {
 		sb.WriteString("\n\n")
 		sb.WriteString(m.renderHelpBar())
 	}
@@ -658,9 +1065,12 @@ func (m StashesModel) renderHelpBar() string {
 	items := []struct{ key, desc string }{
 		{formatKeyList(Keys.Down, Keys.Up), "navigate"},
 		{formatKeyList(Keys.Right, "→"), "view diff"},
-		{"a", "apply"},
-		{"p", "pop"},
-		{"d", "drop"},
+		{Keys.StashCompare, "compare"},
+		{Keys.StashApply, "apply"},
+		{Keys.StashPop, "pop"},
+		{Keys.StashDrop, "drop"},
+		{Keys.NewBranch, "branch"},
+		{Keys.StashRename, "rename"},
 		{Keys.Help, "help"},
 		{formatKeyList(Keys.Left, "ESC"), "back"},
 	}
@@ -694,9 +1104,12 @@ func (m StashesModel) renderHelp() string {
 		{topKey, "Go to top"},
 		{Keys.Bottom, "Go to bottom"},
 		{drillKeys, "View stash diff"},
-		{"a", "Apply stash (keep in list)"},
-		{"p", "Pop stash (apply and remove)"},
-		{"d", "Drop stash (delete)"},
+		{Keys.StashCompare, "Compare stash with the working tree"},
+		{Keys.StashApply, "Apply stash (keep in list)"},
+		{Keys.StashPop, "Pop stash (apply and remove)"},
+		{Keys.StashDrop, "Drop stash (delete)"},
+		{Keys.NewBranch, "Create a branch from the stash and apply it there"},
+		{Keys.StashRename, "Rename stash"},
 		{Keys.Help, "Toggle help"},
 		{backKeys, "Go back"},
 	}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"go-on-git/internal/git"
 
@@ -173,8 +174,8 @@ func TestStashDiffModelWindowResize(t *testing.T) {
 func TestStashDiffModelStashDiffMsg(t *testing.T) {
 	m := NewStashDiffModel(100, 50)
 
-	diff := &git.CombinedDiffResult{
-		UnstagedDiff: &git.DiffResult{
+	diff := &git.StashDiff{
+		Worktree: &git.DiffResult{
 			Files: []git.FileDiff{
 				{
 					Path: "file1.txt",
@@ -203,8 +204,8 @@ func TestStashDiffModelStashDiffMsg(t *testing.T) {
 func TestStashDiffModelAutoEnterDetailForSingleHunk(t *testing.T) {
 	m := NewStashDiffModel(100, 50)
 
-	diff := &git.CombinedDiffResult{
-		UnstagedDiff: &git.DiffResult{
+	diff := &git.StashDiff{
+		Worktree: &git.DiffResult{
 			Files: []git.FileDiff{
 				{
 					Path: "file1.txt",
@@ -235,7 +236,7 @@ func TestStashDiffModelErrMsg(t *testing.T) {
 
 func TestStashDiffModelView(t *testing.T) {
 	m := NewStashDiffModel(100, 50)
-	m.diff = &git.CombinedDiffResult{}
+	m.diff = &git.StashDiff{}
 	m.hunks = []git.Hunk{
 		{
 			FilePath:        "file1.txt",
@@ -254,6 +255,110 @@ func TestStashDiffModelView(t *testing.T) {
 	}
 }
 
+func TestStashDiffModelGroupsParts(t *testing.T) {
+	m := NewStashDiffModel(100, 50)
+
+	file := func(path string) git.FileDiff {
+		return git.FileDiff{Path: path, Hunks: []git.Hunk{{FilePath: path, DisplayFilePath: path}}}
+	}
+	diff := &git.StashDiff{
+		Index:     &git.DiffResult{Files: []git.FileDiff{file("staged.txt")}},
+		Worktree:  &git.DiffResult{Files: []git.FileDiff{file("changed.txt"), file("other.txt")}},
+		Untracked: &git.DiffResult{Files: []git.FileDiff{file("new.txt")}},
+	}
+
+	newModel, _ := m.Update(stashDiffMsg{diff: diff})
+	m = newModel.(StashDiffModel)
+
+	if len(m.hunks) != 4 {
+		t.Fatalf("len(hunks) = %d, want 4", len(m.hunks))
+	}
+	want := []git.StashPart{git.StashIndex, git.StashWorktree, git.StashWorktree, git.StashUntracked}
+	for i, part := range want {
+		if m.parts[i] != part {
+			t.Errorf("parts[%d] = %v, want %v", i, m.parts[i], part)
+		}
+	}
+
+	view := m.View()
+	staged := strings.Index(view, "Staged (index)")
+	unstaged := strings.Index(view, "Unstaged (working tree)")
+	untracked := strings.Index(view, "Untracked files")
+	if staged < 0 || unstaged < staged || untracked < unstaged {
+		t.Errorf("view should show the parts in order, got:\n%s", view)
+	}
+	if strings.Count(view, "Unstaged (working tree)") != 1 {
+		t.Error("each part should be labelled once")
+	}
+}
+
+func TestStashDiffModelApplyHunks(t *testing.T) {
+	m := NewStashDiffModel(100, 50)
+	file := git.FileDiff{
+		Path: "file1.txt",
+		Hunks: []git.Hunk{
+			{FilePath: "file1.txt", DisplayFilePath: "file1.txt", HunkIndex: 0},
+			{FilePath: "file1.txt", DisplayFilePath: "file1.txt", HunkIndex: 1},
+		},
+	}
+	newModel, _ := m.Update(stashDiffMsg{diff: &git.StashDiff{Worktree: &git.DiffResult{Files: []git.FileDiff{file}}}})
+	m = newModel.(StashDiffModel)
+
+	// Select both hunks
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	m = newModel.(StashDiffModel)
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m = newModel.(StashDiffModel)
+	if !m.inPrompt() {
+		t.Fatal("v should start selecting hunks")
+	}
+	if start, end := m.selectionRange(); start != 0 || end != 1 {
+		t.Errorf("selectionRange() = %d, %d, want 0, 1", start, end)
+	}
+	if !strings.Contains(m.View(), "-- VISUAL --") {
+		t.Error("view should show the visual mode")
+	}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	m = newModel.(StashDiffModel)
+	if cmd == nil || m.visualMode {
+		t.Error("a should apply the selected hunks and end the selection")
+	}
+
+	newModel, _ = m.Update(stashAppliedMsg{notice: "Applied 2 hunks to the working tree"})
+	m = newModel.(StashDiffModel)
+	if !strings.Contains(m.View(), "Applied 2 hunks to the working tree") {
+		t.Error("view should show what was applied")
+	}
+}
+
+func TestStashDiffModelCompare(t *testing.T) {
+	m := NewStashCompareModel(2, 100, 50)
+	if !m.compare || m.stashIndex != 2 {
+		t.Fatal("expected a comparison of stash@{2}")
+	}
+
+	diff := &git.DiffResult{Files: []git.FileDiff{{
+		Path:  "file1.txt",
+		Hunks: []git.Hunk{{FilePath: "file1.txt", DisplayFilePath: "file1.txt"}, {FilePath: "file1.txt", DisplayFilePath: "file1.txt"}},
+	}}}
+	newModel, _ := m.Update(stashCompareMsg{diff: diff})
+	m = newModel.(StashDiffModel)
+
+	if len(m.hunks) != 2 {
+		t.Fatalf("len(hunks) = %d, want 2", len(m.hunks))
+	}
+	if view := m.View(); !strings.Contains(view, "Working tree → stash") {
+		t.Errorf("view should label the comparison, got:\n%s", view)
+	}
+
+	newModel, _ = m.Update(stashCompareMsg{diff: &git.DiffResult{}})
+	m = newModel.(StashDiffModel)
+	if !strings.Contains(m.View(), "The working tree matches the stash") {
+		t.Error("view should say when there is nothing left to compare")
+	}
+}
+
 func TestStashDiffModelViewEmpty(t *testing.T) {
 	m := NewStashDiffModel(100, 50)
 
@@ -277,7 +382,7 @@ func TestStashDiffModelViewHelp(t *testing.T) {
 
 func TestStashDiffModelViewHunkDetail(t *testing.T) {
 	m := NewStashDiffModel(100, 20)
-	m.diff = &git.CombinedDiffResult{}
+	m.diff = &git.StashDiff{}
 	m.hunks = []git.Hunk{
 		{
 			FilePath:        "file1.txt",
@@ -415,6 +520,78 @@ func TestStashesModelApplyStash(t *testing.T) {
 	}
 }
 
+func TestStashesModelStashBranch(t *testing.T) {
+	m := NewStashesModel()
+	m.stashes = []git.Stash{{Index: 0, Message: "stash 1"}}
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	m = newModel.(StashesModel)
+	if !m.branchMode || !m.inPrompt() {
+		t.Fatal("n should open the branch name prompt")
+	}
+	if view := m.View(); !strings.Contains(view, "New branch from stash@{0}:") {
+		t.Errorf("view should show the branch prompt, got:\n%s", view)
+	}
+
+	// An empty name cancels
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(StashesModel)
+	if m.branchMode || cmd != nil {
+		t.Error("enter with an empty name should cancel")
+	}
+
+	m.branchMode = true
+	m.branchInput.SetValue("from-stash")
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(StashesModel)
+	if m.branchMode || cmd == nil {
+		t.Error("enter with a name should create the branch")
+	}
+}
+
+func TestStashesModelRenameStash(t *testing.T) {
+	m := NewStashesModel()
+	m.stashes = []git.Stash{{Index: 0, Message: "old message"}}
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'R'}})
+	m = newModel.(StashesModel)
+	if !m.renameMode {
+		t.Fatal("R should open the rename prompt")
+	}
+	if m.renameInput.Value() != "old message" {
+		t.Errorf("the prompt should start with the current message, got %q", m.renameInput.Value())
+	}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(StashesModel)
+	if m.renameMode || cmd != nil {
+		t.Error("esc should cancel the rename")
+	}
+
+	m.renameMode = true
+	m.renameInput.SetValue("new message")
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Error("enter should rename the stash")
+	}
+}
+
+func TestStashesModelNotice(t *testing.T) {
+	m := NewStashesModel()
+
+	newModel, _ := m.Update(withStashesNotice(stashesMsg{stashes: []git.Stash{{Index: 0, Message: "renamed"}}}, "Renamed stash@{0}"))
+	m = newModel.(StashesModel)
+	if !strings.Contains(m.View(), "Renamed stash@{0}") {
+		t.Error("view should show the notice")
+	}
+
+	newModel, _ = m.Update(errMsg{fmt.Errorf("boom")})
+	m = newModel.(StashesModel)
+	if m.notice != "" {
+		t.Error("an error should replace the notice")
+	}
+}
+
 func TestStashesModelPopStash(t *testing.T) {
 	m := NewStashesModel()
 	m.stashes = []git.Stash{
@@ -524,8 +701,8 @@ func TestStashesModelStashesMsg(t *testing.T) {
 func TestStashesModelStashDiffMsg(t *testing.T) {
 	m := NewStashesModel()
 
-	diff := &git.CombinedDiffResult{
-		UnstagedDiff: &git.DiffResult{
+	diff := &git.StashDiff{
+		Worktree: &git.DiffResult{
 			Files: []git.FileDiff{
 				{
 					Path: "file1.txt",
@@ -574,6 +751,39 @@ func TestStashesModelView(t *testing.T) {
 	}
 }
 
+func TestStashesModelViewMetadata(t *testing.T) {
+	m := NewStashesModel()
+	m.stashes = []git.Stash{
+		{Index: 0, Hash: "abcdef1234567890", Message: "WIP", Files: 3, Date: time.Now().Add(-2 * time.Hour)},
+		{Index: 1, Hash: "1234567abcdef890", Message: "single", Files: 1},
+	}
+
+	view := m.View()
+
+	for _, want := range []string{"abcdef1", "3 files, 2 hours ago", "1234567", "single - 1 file"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q, got:\n%s", want, view)
+		}
+	}
+}
+
+func TestStashesModelViewStashOptions(t *testing.T) {
+	m := NewStashesModel()
+	m.stashes = []git.Stash{
+		{Index: 0, Message: "WIP on main", Options: git.StashOptions{IncludeUntracked: true, KeepIndex: true}},
+		{Index: 1, Message: "plain"},
+	}
+
+	view := m.View()
+
+	if !strings.Contains(view, "[include untracked, keep index]") {
+		t.Errorf("view should show the stash mode, got:\n%s", view)
+	}
+	if strings.Count(view, "[") != 1 {
+		t.Error("a plain stash should not show a mode")
+	}
+}
+
 func TestStashesModelViewEmpty(t *testing.T) {
 	m := NewStashesModel()
 	m.stashes = nil
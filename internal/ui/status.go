@@ -1,7 +1,9 @@
 package ui
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -17,9 +19,12 @@ import (
 // StatusItem represents a selectable item in the status view
 type StatusItem struct {
 	File    git.FileStatus
-	Section string // "staged", "unstaged", "untracked"
+	Section string // "staged", "conflicted", "unstaged", "untracked"
 }
 
+// pullModes are the options of the pull picker
+var pullModes = []git.PullMode{git.PullMerge, git.PullRebase, git.PullFFOnly}
+
 type confirmAction int
 
 const (
@@ -28,6 +33,19 @@ const (
 	confirmPush
 	confirmPushNew
 	confirmStash
+	confirmAmend // amend without editing the message
+	confirmAmendPushed
+	confirmAbort // abort the merge, rebase, cherry-pick, revert or bisect in progress
+	confirmUndo  // undo the last operation in the reflog
+)
+
+// pickerAction is the action the inline picker chooses an option for
+type pickerAction int
+
+const (
+	pickerNone  pickerAction = iota
+	pickerFetch              // choose the remote to fetch from
+	pickerPull               // choose how to integrate the upstream branch
 )
 
 type stashMode int
@@ -38,32 +56,73 @@ const (
 	stashAll
 )
 
+// stashToggles are the stash options toggled from the stash prompt
+var stashToggles = []struct {
+	key   string
+	label string
+	flag  func(o *git.StashOptions) *bool
+}{
+	{"u", "untracked", func(o *git.StashOptions) *bool { return &o.IncludeUntracked }},
+	{"k", "keep index", func(o *git.StashOptions) *bool { return &o.KeepIndex }},
+	{"s", "staged only", func(o *git.StashOptions) *bool { return &o.Staged }},
+}
+
+// toggleStashOption flips the option of the toggle key. --staged can't be
+// combined with the others, so turning it on turns them off and vice versa.
+func toggleStashOption(opts git.StashOptions, key string) git.StashOptions {
+	for _, t := range stashToggles {
+		if t.key != key {
+			continue
+		}
+		on := !*t.flag(&opts)
+		if on && key == "s" {
+			opts = git.StashOptions{}
+		} else if on {
+			opts.Staged = false
+		}
+		*t.flag(&opts) = on
+	}
+	return opts
+}
+
 // StatusModel is the bubbletea model for the status view
 type StatusModel struct {
-	items           []StatusItem
-	cursor          int
-	scrollOffset    int
-	selected        map[int]bool
-	visualMode      bool
-	visualStart     int
-	status          *git.StatusResult
-	branchStatus    git.BranchStatus
-	showHelp        bool
-	showVerboseHelp bool
-	confirmMode     confirmAction
-	confirmInput    string
+	items               []StatusItem
+	cursor              int
+	scrollOffset        int
+	selected            map[int]bool
+	visualMode          bool
+	visualStart         int
+	status              *git.StatusResult
+	branchStatus        git.BranchStatus
+	showHelp            bool
+	showVerboseHelp     bool
+	confirmMode         confirmAction
+	confirmInput        string
 	pendingPushRemote   string
 	stashMode           stashMode
 	stashInput          textinput.Model
 	pendingStashMode    stashMode
 	pendingStashMessage string
+	stashOpts           git.StashOptions
+	stashOptsFocus      bool // editing the stash options instead of the message
 	commitMode          bool
-	commitInput     textinput.Model
-	quitting        bool
-	lastKey         string
-	err             error
-	width           int
-	height          int
+	commitInput         commitComposer
+	amendMode           bool           // commit input amends HEAD instead of creating a commit
+	amendNoEdit         bool           // pending amend keeps HEAD's message
+	amendHead           git.CommitInfo // commit being amended
+	pickerMode          pickerAction
+	picker              picker
+	fetchPrune          bool          // fetch with --prune
+	progress            string        // remote operation in progress, cleared on refresh
+	operation           git.Operation // merge, rebase, etc. in progress
+	operationTarget     string        // branch being merged or rebased onto, when started from go-on-git
+	undoPlan            git.UndoPlan  // what confirming the undo runs
+	quitting            bool
+	lastKey             string
+	err                 error
+	width               int
+	height              int
 }
 
 // NewStatusModel creates a new status model
@@ -78,15 +137,10 @@ func NewStatusModelWithHelp(showHelp bool) StatusModel {
 	ti.CharLimit = 200
 	ti.Width = 40
 
-	ci := textinput.New()
-	ci.Placeholder = "Commit message"
-	ci.CharLimit = 200
-	ci.Width = 50
-
 	return StatusModel{
 		selected:        make(map[int]bool),
 		stashInput:      ti,
-		commitInput:     ci,
+		commitInput:     newCommitComposer(),
 		showVerboseHelp: showHelp,
 	}
 }
@@ -135,7 +189,7 @@ func (m StatusModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							message := m.pendingStashMessage
 							m.pendingStashMode = stashNone
 							m.pendingStashMessage = ""
-							return m, m.doStash(mode, message)
+							return m, m.doStash(mode, message, m.stashOpts)
 						}
 					}
 					return m, nil
@@ -153,15 +207,28 @@ func (m StatusModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return m, nil
 				}
 			}
-			// Simple y/n confirmation for push
+			// Simple y/n confirmation for push and amend
 			switch key {
 			case "y", "Y":
 				action := m.confirmMode
 				remote := m.pendingPushRemote
 				m.confirmMode = confirmNone
 				m.pendingPushRemote = ""
-				if action == confirmPushNew {
+				switch action {
+				case confirmPushNew:
 					return m, m.doPushSetUpstream(remote)
+				case confirmAmend:
+					return m, m.doAmend("")
+				case confirmAbort:
+					return m, m.doAbort()
+				case confirmUndo:
+					return m, m.doUndo()
+				case confirmAmendPushed:
+					if m.amendNoEdit {
+						return m, m.doAmend("")
+					}
+					cmd := m.startAmend()
+					return m, cmd
 				}
 				return m, m.doPush()
 			case "n", "N", "esc":
@@ -172,6 +239,36 @@ func (m StatusModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		// Handle remote / pull mode picker
+		if m.pickerMode != pickerNone {
+			if m.picker.move(key) {
+				return m, nil
+			}
+			switch key {
+			case "tab":
+				if m.pickerMode == pickerFetch {
+					m.fetchPrune = !m.fetchPrune
+				}
+				return m, nil
+			case "enter":
+				action := m.pickerMode
+				m.pickerMode = pickerNone
+				m.err = nil
+				if action == pickerFetch {
+					remote := m.picker.selected()
+					m.progress = fmt.Sprintf("Fetching from '%s'...", remote)
+					return m, m.doFetch(remote, m.fetchPrune)
+				}
+				mode := pullModes[m.picker.cursor]
+				m.progress = fmt.Sprintf("Pulling from '%s' (%s)...", m.branchStatus.Remote, mode)
+				return m, m.doPull(mode)
+			case "esc":
+				m.pickerMode = pickerNone
+				return m, nil
+			}
+			return m, nil
+		}
+
 		// Handle stash input mode
 		if m.stashMode != stashNone {
 			switch key {
@@ -180,36 +277,56 @@ func (m StatusModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.pendingStashMode = m.stashMode
 				m.pendingStashMessage = m.stashInput.Value()
 				m.stashMode = stashNone
+				m.stashOptsFocus = false
 				m.stashInput.Reset()
 				m.stashInput.Blur()
 				m.confirmMode = confirmStash
 				return m, nil
 			case "esc":
 				m.stashMode = stashNone
+				m.stashOptsFocus = false
 				m.stashInput.Reset()
 				m.stashInput.Blur()
 				return m, nil
-			default:
-				var cmd tea.Cmd
-				m.stashInput, cmd = m.stashInput.Update(msg)
-				return m, cmd
+			case "tab", "shift+tab":
+				// Switch between the message and the option toggles
+				m.stashOptsFocus = !m.stashOptsFocus
+				if m.stashOptsFocus {
+					m.stashInput.Blur()
+					return m, nil
+				}
+				return m, m.stashInput.Focus()
+			}
+			if m.stashOptsFocus {
+				m.stashOpts = toggleStashOption(m.stashOpts, key)
+				return m, nil
 			}
+			var cmd tea.Cmd
+			m.stashInput, cmd = m.stashInput.Update(msg)
+			return m, cmd
 		}
 
 		// Handle commit input mode
 		if m.commitMode {
 			switch key {
-			case "enter":
-				message := m.commitInput.Value()
+			case "ctrl+s":
+				// Enter starts a new line, so the message is submitted with ctrl+s
+				message := git.CleanCommitMessage(m.commitInput.Value())
+				amend := m.amendMode
 				m.commitMode = false
+				m.amendMode = false
 				m.commitInput.Reset()
 				m.commitInput.Blur()
+				if message != "" && amend {
+					return m, m.doAmend(message)
+				}
 				if message != "" {
 					return m, m.doCommit(message)
 				}
 				return m, nil
 			case "esc":
 				m.commitMode = false
+				m.amendMode = false
 				m.commitInput.Reset()
 				m.commitInput.Blur()
 				return m, nil
@@ -374,26 +491,104 @@ func (m StatusModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 			return m, m.doPush()
+		case key == Keys.Continue:
+			if !m.operation.CanContinue() {
+				return m, nil
+			}
+			if m.status != nil && len(m.status.Conflicted) > 0 {
+				m.err = fmt.Errorf("resolve the conflicted files before continuing the %s", m.operation.Kind)
+				return m, nil
+			}
+			return m, m.doContinue()
+		case key == Keys.Skip:
+			if m.operation.CanSkip() {
+				return m, m.doSkip()
+			}
+			return m, nil
+		case key == Keys.Abort:
+			if m.operation.InProgress() {
+				m.confirmMode = confirmAbort
+			}
+			return m, nil
+		case key == Keys.Undo:
+			// Work out the undo now so the prompt can show what it resets
+			plan, err := git.GetUndoPlan()
+			if err != nil {
+				m.err = err
+				return m, nil
+			}
+			m.err = nil
+			m.undoPlan = plan
+			m.confirmMode = confirmUndo
+			return m, nil
+		case key == Keys.Fetch:
+			remotes, err := git.GetRemotes()
+			if err != nil {
+				m.err = err
+				return m, nil
+			}
+			if len(remotes) == 0 {
+				m.err = fmt.Errorf("no remotes configured")
+				return m, nil
+			}
+			// Start on the remote of the upstream branch
+			cursor := 0
+			for i, remote := range remotes {
+				if strings.HasPrefix(m.branchStatus.Remote, remote+"/") {
+					cursor = i
+				}
+			}
+			m.selected = make(map[int]bool)
+			m.visualMode = false
+			m.fetchPrune = false
+			m.picker = newPicker("Fetch from:", remotes, cursor)
+			m.pickerMode = pickerFetch
+			return m, nil
+		case key == Keys.Pull:
+			if m.branchStatus.Remote == "" {
+				m.err = fmt.Errorf("branch '%s' has no upstream to pull from", m.branchStatus.Name)
+				return m, nil
+			}
+			m.selected = make(map[int]bool)
+			m.visualMode = false
+			modes := make([]string, len(pullModes))
+			for i, mode := range pullModes {
+				modes[i] = mode.String()
+			}
+			m.picker = newPicker(fmt.Sprintf("Pull '%s' with:", m.branchStatus.Remote), modes, 0)
+			m.pickerMode = pickerPull
+			return m, nil
 		case key == Keys.Commit:
 			// Inline commit with message
 			if m.status != nil && len(m.status.Staged) > 0 {
 				m.selected = make(map[int]bool)
 				m.visualMode = false
+				template, err := git.GetCommitTemplate()
+				if err != nil {
+					m.err = err
+					return m, nil
+				}
 				m.commitMode = true
-				m.commitInput.Focus()
-				return m, textinput.Blink
+				m.commitInput.SetValue(template)
+				return m, m.commitInput.Focus()
 			}
 			return m, nil
+		case key == Keys.Amend || key == Keys.AmendNoEdit:
+			m.selected = make(map[int]bool)
+			m.visualMode = false
+			m.err = nil
+			return m, loadAmendHead(key == Keys.AmendNoEdit)
 		case key == Keys.CommitEdit:
 			// Run git commit with editor
 			m.selected = make(map[int]bool)
 			m.visualMode = false
-			m.quitting = true
+			m.err = nil
 			return m, runGitCommit()
 		case key == Keys.Stash:
 			// Stash selected file(s)
 			if len(m.items) > 0 {
 				m.stashMode = stashFiles
+				m.stashOpts = defaultStashOptions(m.getSelectedItems())
 				m.stashInput.Focus()
 				return m, textinput.Blink
 			}
@@ -402,6 +597,7 @@ func (m StatusModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Stash all changes
 			if len(m.items) > 0 {
 				m.stashMode = stashAll
+				m.stashOpts = defaultStashOptions(m.items)
 				m.stashInput.Focus()
 				return m, textinput.Blink
 			}
@@ -411,6 +607,7 @@ func (m StatusModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		m.commitInput.SetWidth(msg.Width - 2)
 		return m, nil
 
 	case statusMsg:
@@ -418,6 +615,11 @@ func (m StatusModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.visualMode || len(m.selected) > 0 {
 			return m, nil
 		}
+		m.progress = ""
+		m.operation = msg.operation
+		if !m.operation.InProgress() {
+			m.operationTarget = ""
+		}
 		m.status = msg.status
 		m.branchStatus = msg.branchStatus
 		m.items = buildItems(msg.status)
@@ -428,8 +630,24 @@ func (m StatusModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.selected = make(map[int]bool)
 		return m, nil
 
+	case amendHeadMsg:
+		m.amendHead = msg.head
+		m.amendNoEdit = msg.noEdit
+		switch {
+		case m.branchStatus.HeadPushed():
+			// Warn before rewriting a commit that is already on the upstream
+			m.confirmMode = confirmAmendPushed
+			return m, nil
+		case m.amendNoEdit:
+			m.confirmMode = confirmAmend
+			return m, nil
+		}
+		cmd := m.startAmend()
+		return m, cmd
+
 	case errMsg:
 		m.err = msg.err
+		m.progress = ""
 		return m, nil
 	}
 
@@ -455,6 +673,13 @@ func (m StatusModel) visibleLines() int {
 	if m.showVerboseHelp {
 		reserved += 3
 	}
+	if m.commitMode {
+		// Commit message composer and its column indicator
+		reserved += composerHeight + 2
+	}
+	if m.operation.InProgress() {
+		reserved += 2
+	}
 	if m.height <= reserved {
 		return 10 // fallback minimum
 	}
@@ -525,6 +750,9 @@ func buildItems(status *git.StatusResult) []StatusItem {
 	for _, f := range status.Staged {
 		items = append(items, StatusItem{File: f, Section: "staged"})
 	}
+	for _, f := range status.Conflicted {
+		items = append(items, StatusItem{File: f, Section: "conflicted"})
+	}
 	for _, f := range status.Unstaged {
 		items = append(items, StatusItem{File: f, Section: "unstaged"})
 	}
@@ -549,6 +777,9 @@ func (m StatusModel) toggleStage() tea.Cmd {
 				err = git.UnstageFile(item.File.Path)
 			case "unstaged", "untracked":
 				err = git.StageFile(item.File.Path)
+			case "conflicted":
+				// Refuses while conflict markers remain
+				err = git.MarkResolved(item.File.Path)
 			}
 			if err != nil {
 				return errMsg{err}
@@ -572,6 +803,11 @@ func (m StatusModel) stageFiles() tea.Cmd {
 					return errMsg{err}
 				}
 			}
+			if item.Section == "conflicted" {
+				if err := git.MarkResolved(item.File.Path); err != nil {
+					return errMsg{err}
+				}
+			}
 		}
 		return refreshStatus()
 	}
@@ -597,9 +833,39 @@ func (m StatusModel) unstageFiles() tea.Cmd {
 }
 
 func (m StatusModel) stageAll() tea.Cmd {
+	if m.status == nil || len(m.status.Conflicted) == 0 {
+		return func() tea.Msg {
+			if err := git.StageAll(); err != nil {
+				return errMsg{err}
+			}
+			return refreshStatus()
+		}
+	}
+
+	// git add -A would mark every conflicted file resolved, so stage the
+	// other files one by one and resolve the conflicted ones like Keys.Stage
+	var paths []string
+	for _, f := range m.status.Unstaged {
+		paths = append(paths, f.Path)
+	}
+	for _, f := range m.status.Untracked {
+		paths = append(paths, f.Path)
+	}
+	conflicted := make([]string, 0, len(m.status.Conflicted))
+	for _, f := range m.status.Conflicted {
+		conflicted = append(conflicted, f.Path)
+	}
 	return func() tea.Msg {
-		if err := git.StageAll(); err != nil {
-			return errMsg{err}
+		for _, path := range paths {
+			if err := git.StageFile(path); err != nil {
+				return errMsg{err}
+			}
+		}
+		for _, path := range conflicted {
+			// Refuses while conflict markers remain
+			if err := git.MarkResolved(path); err != nil {
+				return errMsg{err}
+			}
 		}
 		return refreshStatus()
 	}
@@ -671,10 +937,215 @@ func (m StatusModel) doCommit(message string) tea.Cmd {
 	}
 }
 
-func (m StatusModel) doStash(mode stashMode, message string) tea.Cmd {
+// doFetch fetches from the remote and refreshes the ahead/behind counts
+func (m StatusModel) doFetch(remote string, prune bool) tea.Cmd {
+	return func() tea.Msg {
+		if err := git.Fetch(remote, prune); err != nil {
+			return errMsg{err}
+		}
+		return refreshStatus()
+	}
+}
+
+// doPull pulls the upstream branch and refreshes the status
+func (m StatusModel) doPull(mode git.PullMode) tea.Cmd {
+	pull := func() tea.Msg {
+		if err := git.Pull(mode); err != nil {
+			return errMsg{err}
+		}
+		return nil
+	}
+	// Refresh even if the pull failed: stopping on conflicts still changes the working tree
+	return tea.Sequence(pull, refreshStatus)
+}
+
+// doContinue continues the operation in progress and refreshes the status
+func (m StatusModel) doContinue() tea.Cmd {
+	op := m.operation
+	cont := func() tea.Msg {
+		if err := git.ContinueOperation(op); err != nil {
+			return errMsg{err}
+		}
+		return nil
+	}
+	// Refresh even if it failed: a rebase can stop again on the next commit
+	return tea.Sequence(cont, refreshStatus)
+}
+
+// doSkip skips the current commit of the operation in progress
+func (m StatusModel) doSkip() tea.Cmd {
+	op := m.operation
+	skip := func() tea.Msg {
+		if err := git.SkipOperation(op); err != nil {
+			return errMsg{err}
+		}
+		return nil
+	}
+	return tea.Sequence(skip, refreshStatus)
+}
+
+// doAbort abandons the operation in progress
+func (m StatusModel) doAbort() tea.Cmd {
+	op := m.operation
+	return func() tea.Msg {
+		if err := git.AbortOperation(op); err != nil {
+			return errMsg{err}
+		}
+		return refreshStatus()
+	}
+}
+
+func (m StatusModel) doUndo() tea.Cmd {
+	plan := m.undoPlan
+	return func() tea.Msg {
+		if err := git.Undo(plan); err != nil {
+			return errMsg{err}
+		}
+		return refreshStatus()
+	}
+}
+
+// renderOperationBanner shows the operation in progress with its progress
+// and the keys that move it along
+func (m StatusModel) renderOperationBanner() string {
+	title := m.operation.String()
+	if m.operation.Branch != "" {
+		title += fmt.Sprintf(" of '%s'", m.operation.Branch)
+	}
+	if m.operationTarget != "" {
+		switch m.operation.Kind {
+		case git.OperationMerge:
+			title += fmt.Sprintf(" of '%s'", m.operationTarget)
+		case git.OperationRebase:
+			title += fmt.Sprintf(" onto '%s'", m.operationTarget)
+		}
+	}
+	conflicted := 0
+	if m.status != nil {
+		conflicted = len(m.status.Conflicted)
+	}
+	switch conflicted {
+	case 0:
+		title += " in progress."
+	case 1:
+		title += " stopped with conflicts in 1 file."
+	default:
+		title += fmt.Sprintf(" stopped with conflicts in %d files.", conflicted)
+	}
+
+	var choices []string
+	if m.operation.CanContinue() {
+		choices = append(choices, Keys.Continue+" to continue")
+	}
+	if m.operation.CanSkip() {
+		choices = append(choices, Keys.Skip+" to skip")
+	}
+	choices = append(choices, Keys.Abort+" to abort")
+	hint := "Press " + joinChoices(choices) + "."
+	if conflicted > 0 {
+		hint = "Resolve the conflicted files, then press " + joinChoices(choices) + "."
+	}
+	return StyleConfirm.Render(title) + "\n" + StyleMuted.Render(hint)
+}
+
+// joinChoices joins a list as "a, b or c"
+func joinChoices(choices []string) string {
+	if len(choices) < 2 {
+		return strings.Join(choices, "")
+	}
+	return strings.Join(choices[:len(choices)-1], ", ") + " or " + choices[len(choices)-1]
+}
+
+// renderPicker renders the fetch remote or pull mode picker
+func (m StatusModel) renderPicker() string {
+	var sb strings.Builder
+	sb.WriteString(m.picker.View())
+	if m.pickerMode == pickerFetch {
+		prune := "off"
+		if m.fetchPrune {
+			prune = "on"
+		}
+		sb.WriteString(StyleMuted.Render(fmt.Sprintf("  prune: %s (tab to toggle)", prune)))
+	}
+	sb.WriteString(StyleMuted.Render("  (enter to confirm, esc to cancel)"))
+	return sb.String()
+}
+
+// amendHeadMsg carries the HEAD commit to amend
+type amendHeadMsg struct {
+	head   git.CommitInfo
+	noEdit bool
+}
+
+// loadAmendHead loads the HEAD commit so its message can prefill the amend
+func loadAmendHead(noEdit bool) tea.Cmd {
+	return func() tea.Msg {
+		commits, err := git.GetCommits(git.LogOptions{Limit: 1})
+		if err != nil {
+			return errMsg{err}
+		}
+		if len(commits) == 0 {
+			return errMsg{fmt.Errorf("no commits to amend")}
+		}
+		return amendHeadMsg{head: commits[0], noEdit: noEdit}
+	}
+}
+
+// startAmend opens the commit composer prefilled with the message of the commit being amended
+func (m *StatusModel) startAmend() tea.Cmd {
+	m.commitMode = true
+	m.amendMode = true
+	m.commitInput.SetValue(m.amendHead.Message())
+	return m.commitInput.Focus()
+}
+
+// doAmend amends HEAD with the staged changes. An empty message keeps HEAD's message.
+func (m StatusModel) doAmend(message string) tea.Cmd {
+	return func() tea.Msg {
+		var err error
+		if message == "" {
+			err = git.AmendCommitNoEdit()
+		} else {
+			err = git.AmendCommit(message)
+		}
+		if err != nil {
+			return errMsg{err}
+		}
+		return refreshStatus()
+	}
+}
+
+// renderAmendPrompt renders the amend confirmation or message input
+func (m StatusModel) renderAmendPrompt() string {
+	var sb strings.Builder
+	head := StyleCommitHash.Render(m.amendHead.ShortHash) + " " + m.amendHead.Subject
+	switch m.confirmMode {
+	case confirmAmendPushed:
+		sb.WriteString(StyleConfirm.Render(fmt.Sprintf("%s is already on '%s'. Amending rewrites published history.", m.amendHead.ShortHash, m.branchStatus.Remote)))
+		sb.WriteString("\n")
+		sb.WriteString("Amend anyway? (y/n) ")
+	case confirmAmend:
+		sb.WriteString(fmt.Sprintf("Amend %s, keeping its message? (y/n) ", head))
+	default:
+		sb.WriteString(fmt.Sprintf("Amend %s ", head))
+		if m.branchStatus.HeadPushed() {
+			sb.WriteString(StyleConfirm.Render(fmt.Sprintf("(already pushed to '%s')", m.branchStatus.Remote)))
+		} else {
+			sb.WriteString(StyleMuted.Render("(not pushed)"))
+		}
+		sb.WriteString("\n")
+		sb.WriteString("Commit message ")
+		sb.WriteString(StyleMuted.Render("(ctrl+s to amend, esc to cancel)"))
+		sb.WriteString("\n")
+		sb.WriteString(m.commitInput.View())
+	}
+	return sb.String()
+}
+
+func (m StatusModel) doStash(mode stashMode, message string, opts git.StashOptions) tea.Cmd {
 	if mode == stashAll {
 		return func() tea.Msg {
-			if err := git.StashAll(message); err != nil {
+			if err := git.StashPush(nil, message, opts); err != nil {
 				return errMsg{err}
 			}
 			return refreshStatus()
@@ -687,19 +1158,91 @@ func (m StatusModel) doStash(mode stashMode, message string) tea.Cmd {
 		return nil
 	}
 
-	paths := make([]string, len(items))
-	for i, item := range items {
-		paths[i] = item.File.Path
+	paths := stashPaths(items, opts)
+	if len(paths) == 0 {
+		return func() tea.Msg {
+			if opts.Staged {
+				return errMsg{fmt.Errorf("none of the selected files have staged changes")}
+			}
+			return errMsg{fmt.Errorf("only untracked files selected, include untracked files to stash them")}
+		}
 	}
 
 	return func() tea.Msg {
-		if err := git.StashFiles(paths, message); err != nil {
+		if err := git.StashPush(paths, message, opts); err != nil {
 			return errMsg{err}
 		}
 		return refreshStatus()
 	}
 }
 
+// defaultStashOptions includes untracked files when there are some among
+// items, so they aren't left behind
+func defaultStashOptions(items []StatusItem) git.StashOptions {
+	for _, item := range items {
+		if item.Section == "untracked" {
+			return git.StashOptions{IncludeUntracked: true}
+		}
+	}
+	return git.StashOptions{}
+}
+
+// stashPaths returns the paths of the items the stash options apply to:
+// only staged files for --staged, and untracked files only when included
+// (git stash rejects paths it has nothing to stash for)
+func stashPaths(items []StatusItem, opts git.StashOptions) []string {
+	var paths []string
+	seen := make(map[string]bool)
+	for _, item := range items {
+		switch {
+		case opts.Staged && item.Section != "staged":
+			continue
+		case !opts.IncludeUntracked && item.Section == "untracked":
+			continue
+		case seen[item.File.Path]:
+			continue
+		}
+		seen[item.File.Path] = true
+		paths = append(paths, item.File.Path)
+	}
+	return paths
+}
+
+// renderStashToggles renders the stash options with their toggle keys
+func (m StatusModel) renderStashToggles() string {
+	var sb strings.Builder
+	sb.WriteString("options:")
+	for _, t := range stashToggles {
+		box := "[ ]"
+		if *t.flag(&m.stashOpts) {
+			box = "[x]"
+		}
+		option := fmt.Sprintf(" %s %s (%s)", box, t.label, t.key)
+		if m.stashOptsFocus {
+			sb.WriteString(option)
+		} else {
+			sb.WriteString(StyleMuted.Render(option))
+		}
+	}
+	if m.stashOptsFocus {
+		sb.WriteString(StyleMuted.Render("  (u/k/s to toggle, tab to edit the message)"))
+	} else {
+		sb.WriteString(StyleMuted.Render("  (tab to change)"))
+	}
+	return sb.String()
+}
+
+// stashOptionLabel describes the stash options in the prompts
+func stashOptionLabel(opts git.StashOptions) string {
+	if opts == (git.StashOptions{}) {
+		return "tracked changes"
+	}
+	if opts.Staged {
+		return "staged changes only"
+	}
+	return opts.String()
+}
+
 func (m StatusModel) View() string {
 	if m.showHelp {
 		return m.renderHelp()
@@ -711,6 +1254,10 @@ func (m StatusModel) View() string {
 		content.WriteString(StyleUnstaged.Render(fmt.Sprintf("Error: %v", m.err)))
 		content.WriteString("\n")
 	}
+	if m.progress != "" {
+		content.WriteString(StyleMuted.Render(m.progress))
+		content.WriteString("\n")
+	}
 
 	if m.status == nil {
 		content.WriteString(StyleMuted.Render("Loading..."))
@@ -744,6 +1291,10 @@ func (m StatusModel) View() string {
 			}
 			content.WriteString("\n")
 		}
+		if m.operation.InProgress() {
+			content.WriteString(m.renderOperationBanner())
+			content.WriteString("\n")
+		}
 		content.WriteString("\n")
 		content.WriteString(StyleEmpty.Render("Nothing to commit, working tree clean"))
 		content.WriteString("\n")
@@ -759,6 +1310,18 @@ func (m StatusModel) View() string {
 		} else if m.confirmMode == confirmPushNew {
 			content.WriteString("\n")
 			content.WriteString(fmt.Sprintf("Push branch '%s' to '%s'? (y/n) ", m.branchStatus.Name, m.pendingPushRemote))
+		} else if m.confirmMode == confirmAmend || m.confirmMode == confirmAmendPushed || m.amendMode {
+			content.WriteString("\n")
+			content.WriteString(m.renderAmendPrompt())
+		} else if m.pickerMode != pickerNone {
+			content.WriteString("\n")
+			content.WriteString(m.renderPicker())
+		} else if m.confirmMode == confirmAbort {
+			content.WriteString("\n")
+			content.WriteString(StyleConfirm.Render(fmt.Sprintf("Abort the %s and discard its changes? (y/n) ", m.operation.Kind)))
+		} else if m.confirmMode == confirmUndo {
+			content.WriteString("\n")
+			content.WriteString(StyleConfirm.Render(undoPrompt(m.undoPlan)))
 		}
 
 		if m.showVerboseHelp {
@@ -793,6 +1356,10 @@ func (m StatusModel) View() string {
 		}
 		content.WriteString("\n")
 	}
+	if m.operation.InProgress() {
+		content.WriteString(m.renderOperationBanner())
+		content.WriteString("\n")
+	}
 	if m.visualMode && !m.quitting {
 		content.WriteString(StyleVisual.Render("-- VISUAL --"))
 	}
@@ -835,6 +1402,27 @@ func (m StatusModel) View() string {
 		}
 	}
 
+	if len(m.status.Conflicted) > 0 {
+		conflictedStart := itemIndex
+		conflictedEnd := itemIndex + len(m.status.Conflicted)
+		// Show section header if any conflicted items are visible
+		if conflictedEnd > visibleStart && conflictedStart < visibleEnd {
+			content.WriteString("Unmerged paths:\n")
+			for i, f := range m.status.Conflicted {
+				if itemIndex >= visibleStart && itemIndex < visibleEnd {
+					content.WriteString(m.renderItem(itemIndex, f, "conflicted"))
+					content.WriteString("\n")
+				}
+				itemIndex++
+				if i == len(m.status.Conflicted)-1 && itemIndex <= visibleEnd {
+					content.WriteString("\n")
+				}
+			}
+		} else {
+			itemIndex += len(m.status.Conflicted)
+		}
+	}
+
 	if len(m.status.Unstaged) > 0 {
 		unstagedStart := itemIndex
 		unstagedEnd := itemIndex + len(m.status.Unstaged)
@@ -899,15 +1487,20 @@ func (m StatusModel) View() string {
 		}
 	} else if m.confirmMode == confirmPushNew {
 		content.WriteString(fmt.Sprintf("Push branch '%s' to '%s'? (y/n) ", m.branchStatus.Name, m.pendingPushRemote))
+	} else if m.confirmMode == confirmAbort {
+		content.WriteString(StyleConfirm.Render(fmt.Sprintf("Abort the %s and discard its changes? (y/n) ", m.operation.Kind)))
+	} else if m.confirmMode == confirmUndo {
+		content.WriteString(StyleConfirm.Render(undoPrompt(m.undoPlan)))
 	} else if m.confirmMode == confirmStash {
+		mode := stashOptionLabel(m.stashOpts)
 		if m.pendingStashMode == stashAll {
-			content.WriteString(StyleConfirm.Render(fmt.Sprintf("Stash all changes? Type 'yes' to confirm: %s", m.confirmInput)))
+			content.WriteString(StyleConfirm.Render(fmt.Sprintf("Stash all changes (%s)? Type 'yes' to confirm: %s", mode, m.confirmInput)))
 		} else {
 			items := m.getSelectedItems()
 			if len(items) == 1 {
-				content.WriteString(StyleConfirm.Render(fmt.Sprintf("Stash '%s'? Type 'yes' to confirm: %s", items[0].File.DisplayPath, m.confirmInput)))
+				content.WriteString(StyleConfirm.Render(fmt.Sprintf("Stash '%s' (%s)? Type 'yes' to confirm: %s", items[0].File.DisplayPath, mode, m.confirmInput)))
 			} else {
-				content.WriteString(StyleConfirm.Render(fmt.Sprintf("Stash %d files? Type 'yes' to confirm: %s", len(items), m.confirmInput)))
+				content.WriteString(StyleConfirm.Render(fmt.Sprintf("Stash %d files (%s)? Type 'yes' to confirm: %s", len(items), mode, m.confirmInput)))
 			}
 		}
 	} else if m.stashMode != stashNone {
@@ -923,10 +1516,17 @@ func (m StatusModel) View() string {
 		}
 		content.WriteString(m.stashInput.View())
 		content.WriteString(StyleMuted.Render("  (enter to confirm, esc to cancel)"))
+		content.WriteString("\n")
+		content.WriteString(m.renderStashToggles())
+	} else if m.confirmMode == confirmAmend || m.confirmMode == confirmAmendPushed || m.amendMode {
+		content.WriteString(m.renderAmendPrompt())
+	} else if m.pickerMode != pickerNone {
+		content.WriteString(m.renderPicker())
 	} else if m.commitMode {
-		content.WriteString("Commit message: ")
+		content.WriteString("Commit message ")
+		content.WriteString(StyleMuted.Render("(ctrl+s to commit, esc to cancel)"))
+		content.WriteString("\n")
 		content.WriteString(m.commitInput.View())
-		content.WriteString(StyleMuted.Render("  (enter to commit, esc to cancel)"))
 	}
 
 	// Show persistent help bar when in help mode
@@ -944,6 +1544,14 @@ func (m StatusModel) renderItem(index int, f git.FileStatus, section string) str
 		path = fmt.Sprintf("%s → %s", f.OriginalDisplayPath, f.DisplayPath)
 	}
 
+	// Submodules show what changed inside them, like git status does
+	suffix := ""
+	if section == "unstaged" {
+		if state := f.Submodule.Description(); state != "" {
+			suffix = StyleMuted.Render(" (" + state + ")")
+		}
+	}
+
 	var pathStyle lipgloss.Style
 	switch section {
 	case "staged":
@@ -952,12 +1560,14 @@ func (m StatusModel) renderItem(index int, f git.FileStatus, section string) str
 		pathStyle = StyleUnstaged
 	case "untracked":
 		pathStyle = StyleUntracked
+	case "conflicted":
+		pathStyle = StyleUnstaged
 	}
 
 	// When quitting, render without any cursor or selection highlighting
 	if m.quitting {
 		statusChar := StatusChar(f.IndexStatus, f.WorkStatus, section)
-		return fmt.Sprintf("        %s%s", statusChar, pathStyle.Render(path))
+		return fmt.Sprintf("        %s%s%s", statusChar, pathStyle.Render(path), suffix)
 	}
 
 	isSelected := m.selected[index]
@@ -971,11 +1581,11 @@ func (m StatusModel) renderItem(index int, f git.FileStatus, section string) str
 	// Apply visual mode highlight for selected items
 	if isSelected {
 		statusChar := StatusCharStyled(f.IndexStatus, f.WorkStatus, section, StyleVisual)
-		return StyleVisual.Render(prefix) + statusChar + pathStyle.Inherit(StyleVisual).Render(path)
+		return StyleVisual.Render(prefix) + statusChar + pathStyle.Inherit(StyleVisual).Render(path) + suffix
 	}
 
 	statusChar := StatusChar(f.IndexStatus, f.WorkStatus, section)
-	return fmt.Sprintf("%s%s%s", prefix, statusChar, pathStyle.Render(path))
+	return fmt.Sprintf("%s%s%s%s", prefix, statusChar, pathStyle.Render(path), suffix)
 }
 
 func (m StatusModel) renderHelp() string {
@@ -991,6 +1601,7 @@ func (m StatusModel) renderHelp() string {
 	stageKeys := formatKeyList(Keys.Stage, Keys.StageAll)
 	unstageKeys := formatKeyList(Keys.Unstage, Keys.UnstageAll)
 	commitKeys := formatKeyList(Keys.Commit, Keys.CommitEdit)
+	amendKeys := formatKeyList(Keys.Amend, Keys.AmendNoEdit)
 	stashKeys := formatKeyList(Keys.Stash, Keys.StashAll)
 	fileDiffKeys := formatKeyList(Keys.FileDiff, Keys.Right)
 	quitKeys := formatKeyList(Keys.Quit, "ESC")
@@ -1018,8 +1629,12 @@ func (m StatusModel) renderHelp() string {
 			title: "Actions",
 			items: []struct{ key, desc string }{
 				{commitKeys, "commit"},
+				{amendKeys, "amend"},
 				{Keys.Push, "push"},
+				{Keys.Fetch, "fetch"},
+				{Keys.Pull, "pull"},
 				{stashKeys, "stash"},
+				{Keys.Undo, "undo"},
 			},
 		},
 		{
@@ -1030,6 +1645,11 @@ func (m StatusModel) renderHelp() string {
 				{Keys.Branches, "branches"},
 				{Keys.Stashes, "stashes"},
 				{Keys.Log, "log"},
+				{Keys.Tags, "tags"},
+				{Keys.Worktrees, "worktrees"},
+				{Keys.Submodules, "submodules"},
+				{Keys.Reflog, "reflog"},
+				{Keys.Discarded, "discarded"},
 			},
 		},
 		{
@@ -1108,6 +1728,11 @@ func (m StatusModel) renderHelpBar() string {
 		{Keys.Branches, "branches"},
 		{Keys.Stashes, "stashes"},
 		{Keys.Log, "log"},
+		{Keys.Tags, "tags"},
+		{Keys.Worktrees, "worktrees"},
+		{Keys.Submodules, "submodules"},
+		{Keys.Reflog, "reflog"},
+		{Keys.Discarded, "discarded"},
 		{Keys.Refresh, "refresh"},
 		{Keys.VerboseHelp, "hide help"},
 	}
@@ -1130,30 +1755,56 @@ func (m StatusModel) renderHelpBar() string {
 	return sb.String()
 }
 
+// runGitCommit runs git commit with the configured editor and then returns
+// to the status view. Hook failures and aborted commits are shown as errors.
 func runGitCommit() tea.Cmd {
+	stderr := &bytes.Buffer{}
 	c := exec.Command("git", "commit")
+	// Hook output goes to stderr as well, so keep a copy of it for the error
+	c.Stderr = io.MultiWriter(os.Stderr, stderr)
 	return tea.ExecProcess(c, func(err error) tea.Msg {
-		return tea.Quit()
+		if err != nil {
+			return errMsg{commitError(err, stderr.String())}
+		}
+		return refreshStatus()
 	})
 }
 
+// commitError builds the error for a failed git commit from its stderr,
+// leaving out the "hint:" lines git prints while waiting for the editor
+func commitError(err error, stderr string) error {
+	var lines []string
+	for _, line := range strings.Split(stderr, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "hint:") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		return fmt.Errorf("git commit: %w", err)
+	}
+	return fmt.Errorf("git commit: %s", strings.Join(lines, "\n"))
+}
+
 func openInEditor(path string, line int) tea.Cmd {
+	c := editorCommand(filepath.Join(git.GetRepoRoot(), path), line)
+	return tea.ExecProcess(c, func(err error) tea.Msg {
+		return refreshStatus()
+	})
+}
+
+// editorCommand builds the $EDITOR invocation for an absolute path
+func editorCommand(fullPath string, line int) *exec.Cmd {
 	editor := os.Getenv("EDITOR")
 	if editor == "" {
 		editor = "vi"
 	}
-	// Convert to absolute path from repo root
-	fullPath := filepath.Join(git.GetRepoRoot(), path)
-	var c *exec.Cmd
 	if line > 0 {
 		// Most editors support +line syntax (vim, nano, etc.)
-		c = exec.Command(editor, fmt.Sprintf("+%d", line), fullPath)
-	} else {
-		c = exec.Command(editor, fullPath)
+		return exec.Command(editor, fmt.Sprintf("+%d", line), fullPath)
 	}
-	return tea.ExecProcess(c, func(err error) tea.Msg {
-		return refreshStatus()
-	})
+	return exec.Command(editor, fullPath)
 }
 
 func max(a, b int) int {
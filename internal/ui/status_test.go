@@ -1,7 +1,11 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -248,6 +252,18 @@ func TestStatusModelCommitMode(t *testing.T) {
 		t.Error("should be in commit mode after 'c' with staged files")
 	}
 
+	// Enter starts a new line instead of committing
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Subject")})
+	m = newModel.(StatusModel)
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(StatusModel)
+	if !m.commitMode {
+		t.Fatal("enter should not submit the commit message")
+	}
+	if got := m.commitInput.Value(); got != "Subject\n" {
+		t.Errorf("commit input = %q, want a new line after the subject", got)
+	}
+
 	// Press esc to cancel
 	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
 	m = newModel.(StatusModel)
@@ -256,6 +272,332 @@ func TestStatusModelCommitMode(t *testing.T) {
 	}
 }
 
+func TestStatusModelCommitEditStaysOpen(t *testing.T) {
+	m := NewStatusModel()
+	m.status = &git.StatusResult{
+		Staged: []git.FileStatus{
+			{Path: "file1.txt", IndexStatus: 'A'},
+		},
+	}
+	m.items = buildItems(m.status)
+	m.err = errors.New("previous error")
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'C'}})
+	m = newModel.(StatusModel)
+	if m.quitting {
+		t.Error("committing with the editor should not quit the app")
+	}
+	if cmd == nil {
+		t.Error("expected a command to run git commit")
+	}
+	if m.err != nil {
+		t.Errorf("previous error should be cleared, got %v", m.err)
+	}
+}
+
+func TestCommitError(t *testing.T) {
+	exitErr := errors.New("exit status 1")
+
+	err := commitError(exitErr, "hint: Waiting for your editor to close the file...\nAborting commit due to empty commit message.\n")
+	if err.Error() != "git commit: Aborting commit due to empty commit message." {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err = commitError(exitErr, "lint failed\n  main.go:3: unused import\n")
+	if err.Error() != "git commit: lint failed\nmain.go:3: unused import" {
+		t.Errorf("hook output should be kept, got: %v", err)
+	}
+
+	err = commitError(exitErr, "")
+	if !errors.Is(err, exitErr) {
+		t.Errorf("without stderr the exit error should be wrapped, got: %v", err)
+	}
+}
+
+func TestStatusModelPullWithoutUpstream(t *testing.T) {
+	m := NewStatusModel()
+	m.status = &git.StatusResult{}
+	m.branchStatus = git.BranchStatus{Name: "feature"}
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'P'}})
+	m = newModel.(StatusModel)
+	if m.pickerMode != pickerNone {
+		t.Error("pull should not open the mode picker without an upstream")
+	}
+	if m.err == nil || !strings.Contains(m.err.Error(), "no upstream") {
+		t.Errorf("expected a no upstream error, got %v", m.err)
+	}
+}
+
+func TestStatusModelPullModePicker(t *testing.T) {
+	m := NewStatusModel()
+	m.status = &git.StatusResult{}
+	m.branchStatus = git.BranchStatus{Name: "main", Remote: "origin/main", Behind: 2}
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'P'}})
+	m = newModel.(StatusModel)
+	if m.pickerMode != pickerPull {
+		t.Fatal("pull should open the mode picker")
+	}
+	view := m.View()
+	if !strings.Contains(view, "Pull 'origin/main' with:") || !strings.Contains(view, "ff-only") {
+		t.Errorf("view should show the pull mode picker, got:\n%s", view)
+	}
+
+	// Navigation keys move the picker, not the file cursor
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m = newModel.(StatusModel)
+	if got := pullModes[m.picker.cursor]; got != git.PullRebase {
+		t.Errorf("picker mode = %v, want rebase", got)
+	}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(StatusModel)
+	if m.pickerMode != pickerNone {
+		t.Error("enter should close the picker")
+	}
+	if cmd == nil {
+		t.Error("enter should return the pull command")
+	}
+	if !strings.Contains(m.progress, "rebase") {
+		t.Errorf("progress = %q, want the pull mode", m.progress)
+	}
+
+	// The refresh clears the progress line
+	newModel, _ = m.Update(statusMsg{status: &git.StatusResult{}, branchStatus: m.branchStatus})
+	m = newModel.(StatusModel)
+	if m.progress != "" {
+		t.Errorf("progress should be cleared on refresh, got %q", m.progress)
+	}
+}
+
+func TestStatusModelFetchPicker(t *testing.T) {
+	m := NewStatusModel()
+	m.status = &git.StatusResult{}
+	m.picker = newPicker("Fetch from:", []string{"origin", "upstream"}, 0)
+	m.pickerMode = pickerFetch
+
+	// tab toggles --prune
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = newModel.(StatusModel)
+	if !m.fetchPrune {
+		t.Error("tab should turn on prune")
+	}
+	if view := m.View(); !strings.Contains(view, "prune: on") {
+		t.Errorf("view should show the prune state, got:\n%s", view)
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(StatusModel)
+	if m.pickerMode != pickerNone {
+		t.Error("esc should close the picker")
+	}
+	if m.quitting {
+		t.Error("esc in the picker should not quit")
+	}
+}
+
+func TestStatusModelStoppedMergeAbort(t *testing.T) {
+	m := NewStatusModel()
+	m.status = &git.StatusResult{
+		Conflicted: []git.FileStatus{{Path: "file.txt", IndexStatus: 'U', WorkStatus: 'U'}},
+	}
+	m.items = buildItems(m.status)
+	m.branchStatus = git.BranchStatus{Name: "main"}
+	m.operation = git.Operation{Kind: git.OperationMerge}
+	m.operationTarget = "feature"
+
+	view := m.View()
+	if !strings.Contains(view, "merge of 'feature' stopped with conflicts in 1 file") ||
+		!strings.Contains(view, "press + to continue or X to abort") {
+		t.Errorf("view should show the stopped merge banner, got:\n%s", view)
+	}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'+'}})
+	m = newModel.(StatusModel)
+	if cmd != nil || m.err == nil {
+		t.Error("+ should refuse to continue while files are conflicted")
+	}
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'>'}})
+	m = newModel.(StatusModel)
+	if cmd != nil {
+		t.Error("> should do nothing for a merge")
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'X'}})
+	m = newModel.(StatusModel)
+	if m.confirmMode != confirmAbort {
+		t.Fatal("X should ask to confirm the abort")
+	}
+	if view := m.View(); !strings.Contains(view, "Abort the merge") {
+		t.Errorf("view should show the abort prompt, got:\n%s", view)
+	}
+
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	m = newModel.(StatusModel)
+	if m.confirmMode != confirmNone || cmd != nil {
+		t.Error("n should cancel the abort")
+	}
+}
+
+func TestStatusModelOperationBanner(t *testing.T) {
+	m := NewStatusModel()
+	m.status = &git.StatusResult{}
+	m.branchStatus = git.BranchStatus{Name: "topic"}
+	m.operation = git.Operation{Kind: git.OperationRebase, Branch: "topic", Step: 3, Total: 7}
+	m.operationTarget = "main"
+
+	view := m.View()
+	if !strings.Contains(view, "rebase 3/7 of 'topic' onto 'main' in progress") ||
+		!strings.Contains(view, "Press + to continue, > to skip or X to abort") {
+		t.Errorf("view should show the rebase banner, got:\n%s", view)
+	}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'+'}})
+	m = newModel.(StatusModel)
+	if cmd == nil || m.err != nil {
+		t.Error("+ should continue the rebase")
+	}
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'>'}})
+	if cmd == nil {
+		t.Error("> should skip the current commit")
+	}
+
+	m.operation = git.Operation{Kind: git.OperationBisect}
+	if view := m.View(); !strings.Contains(view, "Press > to skip or X to abort") {
+		t.Errorf("a bisect can't be continued, got:\n%s", view)
+	}
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'+'}})
+	if cmd != nil {
+		t.Error("+ should do nothing during a bisect")
+	}
+}
+
+func TestStatusModelAbortWithoutStoppedOp(t *testing.T) {
+	m := NewStatusModel()
+	m.status = &git.StatusResult{}
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'X'}})
+	m = newModel.(StatusModel)
+	if m.confirmMode != confirmNone {
+		t.Error("X should do nothing without an operation in progress")
+	}
+}
+
+func TestStatusModelOperationClearedWhenFinished(t *testing.T) {
+	m := NewStatusModel()
+	m.operationTarget = "main"
+
+	newModel, _ := m.Update(statusMsg{status: &git.StatusResult{}, operation: git.Operation{Kind: git.OperationRebase}})
+	m = newModel.(StatusModel)
+	if !m.operation.InProgress() || m.operationTarget != "main" {
+		t.Error("banner should stay while the rebase is in progress")
+	}
+
+	newModel, _ = m.Update(statusMsg{status: &git.StatusResult{}})
+	m = newModel.(StatusModel)
+	if m.operation.InProgress() || m.operationTarget != "" {
+		t.Error("banner should go away once the rebase is finished")
+	}
+}
+
+func TestStatusModelAmendMode(t *testing.T) {
+	m := NewStatusModel()
+	m.status = &git.StatusResult{}
+	m.branchStatus = git.BranchStatus{Name: "main"}
+	m.amendHead = git.CommitInfo{ShortHash: "abc1234", Subject: "Fix bug", Body: "Details"}
+
+	m.startAmend()
+	if !m.commitMode || !m.amendMode {
+		t.Fatal("startAmend should open the commit input in amend mode")
+	}
+	if got := m.commitInput.Value(); got != "Fix bug\n\nDetails" {
+		t.Errorf("commit input = %q, want HEAD's full message", got)
+	}
+
+	view := m.View()
+	if !strings.Contains(view, "Amend") || !strings.Contains(view, "not pushed") {
+		t.Errorf("view should show the amend prompt with the pushed state, got:\n%s", view)
+	}
+
+	// Press esc to cancel
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(StatusModel)
+	if m.commitMode || m.amendMode {
+		t.Error("should exit amend mode after esc")
+	}
+}
+
+func TestStatusModelAmendHeadMsg(t *testing.T) {
+	m := NewStatusModel()
+	m.status = &git.StatusResult{}
+	m.branchStatus = git.BranchStatus{Name: "main"}
+
+	newModel, cmd := m.Update(amendHeadMsg{head: git.CommitInfo{ShortHash: "abc1234", Subject: "Fix bug"}})
+	m = newModel.(StatusModel)
+	if cmd == nil {
+		t.Error("loading HEAD should focus the amend input")
+	}
+	if !m.commitMode || !m.amendMode {
+		t.Fatal("loading HEAD should open the commit input in amend mode")
+	}
+	if got := m.commitInput.Value(); got != "Fix bug" {
+		t.Errorf("commit input = %q, want HEAD's message", got)
+	}
+
+	m = NewStatusModel()
+	m.status = &git.StatusResult{}
+	newModel, _ = m.Update(amendHeadMsg{head: git.CommitInfo{ShortHash: "abc1234"}, noEdit: true})
+	m = newModel.(StatusModel)
+	if m.confirmMode != confirmAmend || m.commitMode {
+		t.Error("amending without editing should ask for confirmation instead")
+	}
+}
+
+func TestStatusModelAmendPushedWarning(t *testing.T) {
+	m := NewStatusModel()
+	m.status = &git.StatusResult{}
+	m.branchStatus = git.BranchStatus{Name: "main", Remote: "origin/main"}
+	m.amendHead = git.CommitInfo{ShortHash: "abc1234", Subject: "Fix bug"}
+	m.confirmMode = confirmAmendPushed
+
+	view := m.View()
+	if !strings.Contains(view, "rewrites published history") {
+		t.Errorf("view should warn about amending a pushed commit, got:\n%s", view)
+	}
+
+	// Confirming continues to the message input
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m = newModel.(StatusModel)
+	if m.confirmMode != confirmNone || !m.amendMode {
+		t.Error("confirming the warning should open the amend input")
+	}
+	if !strings.Contains(m.View(), "already pushed") {
+		t.Error("amend input should show that HEAD is already pushed")
+	}
+}
+
+func TestStatusModelAmendNoEditConfirm(t *testing.T) {
+	m := NewStatusModel()
+	m.status = &git.StatusResult{}
+	m.amendHead = git.CommitInfo{ShortHash: "abc1234", Subject: "Fix bug"}
+	m.amendNoEdit = true
+	m.confirmMode = confirmAmend
+
+	if !strings.Contains(m.View(), "keeping its message") {
+		t.Error("view should ask to amend keeping the message")
+	}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m = newModel.(StatusModel)
+	if cmd == nil {
+		t.Error("confirming should return the amend command")
+	}
+	if m.commitMode {
+		t.Error("amending without editing should not open the commit input")
+	}
+}
+
 func TestStatusModelStashMode(t *testing.T) {
 	m := NewStatusModel()
 	m.items = []StatusItem{
@@ -284,6 +626,101 @@ func TestStatusModelStashMode(t *testing.T) {
 	}
 }
 
+func TestStatusModelStashOptions(t *testing.T) {
+	m := NewStatusModel()
+	m.status = &git.StatusResult{
+		Unstaged:  []git.FileStatus{{Path: "file1.txt", WorkStatus: 'M'}},
+		Untracked: []git.FileStatus{{Path: "new.txt"}},
+	}
+	m.items = buildItems(m.status)
+	m.branchStatus = git.BranchStatus{Name: "main"}
+
+	// Untracked files are included by default so they aren't left behind
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'S'}})
+	m = newModel.(StatusModel)
+	if !m.stashOpts.IncludeUntracked {
+		t.Error("stash all should include untracked files when there are some")
+	}
+	if view := m.View(); !strings.Contains(view, "[x] untracked (u)") || !strings.Contains(view, "[ ] keep index (k)") {
+		t.Errorf("view should show the stash options, got:\n%s", view)
+	}
+
+	// Option keys are typed into the message until tab moves to the options
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
+	m = newModel.(StatusModel)
+	if m.stashOpts.KeepIndex || m.stashInput.Value() != "k" {
+		t.Error("k should be typed into the message")
+	}
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = newModel.(StatusModel)
+	if !m.stashOptsFocus {
+		t.Fatal("tab should move to the stash options")
+	}
+
+	// The options toggle independently
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
+	m = newModel.(StatusModel)
+	if !m.stashOpts.KeepIndex || !m.stashOpts.IncludeUntracked {
+		t.Error("k should turn on keep index alongside untracked")
+	}
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+	m = newModel.(StatusModel)
+	if m.stashOpts != (git.StashOptions{KeepIndex: true}) {
+		t.Errorf("u should turn off untracked only, got %+v", m.stashOpts)
+	}
+	if m.stashInput.Value() != "k" {
+		t.Error("toggle keys should not be typed into the message")
+	}
+
+	// Staged only can't be combined with the others
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	m = newModel.(StatusModel)
+	if m.stashOpts != (git.StashOptions{Staged: true}) {
+		t.Errorf("s should stash staged changes only, got %+v", m.stashOpts)
+	}
+
+	// The options are confirmed with the stash
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(StatusModel)
+	if view := m.View(); !strings.Contains(view, "Stash all changes (staged changes only)?") {
+		t.Errorf("confirmation should show the stash options, got:\n%s", view)
+	}
+
+	// Stashing tracked files only starts with no options
+	m = NewStatusModel()
+	m.items = []StatusItem{{File: git.FileStatus{Path: "file1.txt"}, Section: "unstaged"}}
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	m = newModel.(StatusModel)
+	if m.stashOpts != (git.StashOptions{}) {
+		t.Errorf("stashOpts = %+v, want no options", m.stashOpts)
+	}
+}
+
+func TestStashPaths(t *testing.T) {
+	items := []StatusItem{
+		{File: git.FileStatus{Path: "both.txt"}, Section: "staged"},
+		{File: git.FileStatus{Path: "both.txt"}, Section: "unstaged"},
+		{File: git.FileStatus{Path: "changed.txt"}, Section: "unstaged"},
+		{File: git.FileStatus{Path: "new.txt"}, Section: "untracked"},
+	}
+
+	tests := []struct {
+		opts git.StashOptions
+		want []string
+	}{
+		{git.StashOptions{}, []string{"both.txt", "changed.txt"}},
+		{git.StashOptions{KeepIndex: true}, []string{"both.txt", "changed.txt"}},
+		{git.StashOptions{IncludeUntracked: true}, []string{"both.txt", "changed.txt", "new.txt"}},
+		{git.StashOptions{Staged: true}, []string{"both.txt"}},
+	}
+	for _, tt := range tests {
+		got := stashPaths(items, tt.opts)
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("stashPaths(%+v) = %v, want %v", tt.opts, got, tt.want)
+		}
+	}
+}
+
 func TestStatusModelSelection(t *testing.T) {
 	m := NewStatusModel()
 	m.items = []StatusItem{
@@ -398,6 +835,14 @@ func TestBuildItems(t *testing.T) {
 			},
 			wantLen: 5,
 		},
+		{
+			name: "with conflicts",
+			status: &git.StatusResult{
+				Staged:     []git.FileStatus{{Path: "a.txt"}},
+				Conflicted: []git.FileStatus{{Path: "b.txt"}},
+			},
+			wantLen: 2,
+		},
 	}
 
 	for _, tt := range tests {
@@ -459,6 +904,24 @@ func TestStatusModelView(t *testing.T) {
 	}
 }
 
+func TestStatusModelViewSubmoduleState(t *testing.T) {
+	m := NewStatusModel()
+	lib := git.FileStatus{
+		Path:        "lib",
+		DisplayPath: "lib",
+		IndexStatus: ' ',
+		WorkStatus:  'M',
+		Submodule:   git.SubmoduleState{IsSubmodule: true, NewCommits: true, ModifiedContent: true},
+	}
+	m.status = &git.StatusResult{Unstaged: []git.FileStatus{lib}}
+	m.items = buildItems(m.status)
+	m.branchStatus = git.BranchStatus{Name: "main"}
+
+	if !strings.Contains(m.View(), "lib (new commits, modified content)") {
+		t.Error("view should show what changed inside the submodule")
+	}
+}
+
 func TestStatusModelViewLoading(t *testing.T) {
 	m := NewStatusModel()
 	m.status = nil
@@ -732,3 +1195,115 @@ func TestStatusModelHelpModeBlocksNavigation(t *testing.T) {
 		t.Error("esc should close help")
 	}
 }
+
+func TestStatusModelViewConflicted(t *testing.T) {
+	m := NewStatusModel()
+	m.status = &git.StatusResult{
+		Conflicted: []git.FileStatus{{Path: "both.txt", DisplayPath: "both.txt", IndexStatus: 'U', WorkStatus: 'U'}},
+	}
+	m.items = buildItems(m.status)
+	m.branchStatus = git.BranchStatus{Name: "main"}
+
+	view := m.View()
+
+	if !strings.Contains(view, "Unmerged paths:") {
+		t.Error("view should contain unmerged section header")
+	}
+	if !strings.Contains(view, "both modified:") {
+		t.Error("view should describe the conflict")
+	}
+	if m.items[0].Section != "conflicted" {
+		t.Errorf("section = %q, want conflicted", m.items[0].Section)
+	}
+}
+
+func TestStatusModelStageAllKeepsConflicts(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	git.ResetRepoRoot()
+	t.Cleanup(git.ResetRepoRoot)
+	run := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		if err != nil && args[0] != "merge" {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+		return string(out)
+	}
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Leave both.txt unmerged (UU) next to an ordinary unstaged change
+	run("init", "-b", "main")
+	run("config", "user.email", "test@example.com")
+	run("config", "user.name", "Test User")
+	write("both.txt", "base\n")
+	write("other.txt", "other\n")
+	run("add", ".")
+	run("commit", "-m", "initial")
+	run("checkout", "-b", "feature")
+	write("both.txt", "feature\n")
+	run("commit", "-am", "feature")
+	run("checkout", "main")
+	write("both.txt", "main\n")
+	run("commit", "-am", "main")
+	run("merge", "feature")
+	write("other.txt", "changed\n")
+
+	m := NewStatusModel()
+	newModel, _ := m.Update(refreshStatus())
+	m = newModel.(StatusModel)
+	if len(m.status.Conflicted) != 1 {
+		t.Fatalf("Conflicted = %v, want both.txt", m.status.Conflicted)
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(Keys.StageAll)})
+	if cmd == nil {
+		t.Fatal("stage-all should return a command")
+	}
+	if _, ok := cmd().(errMsg); !ok {
+		t.Error("stage-all should report the conflict markers left in both.txt")
+	}
+	if unmerged := run("ls-files", "-u", "--", "both.txt"); unmerged == "" {
+		t.Error("stage-all should leave both.txt unmerged")
+	}
+	if staged := run("diff", "--cached", "--name-only", "--", "other.txt"); staged == "" {
+		t.Error("stage-all should still stage other.txt")
+	}
+}
+
+func TestStatusModelUndoPrompt(t *testing.T) {
+	m := NewStatusModel()
+	m.status = &git.StatusResult{}
+	m.branchStatus = git.BranchStatus{Name: "main"}
+	m.undoPlan = git.UndoPlan{
+		Entry:  git.ReflogEntry{Action: "commit (amend)", Message: "Fix typo"},
+		Target: git.ReflogEntry{Hash: "abcdef1234567890"},
+		Mode:   git.ResetSoft,
+	}
+	m.confirmMode = confirmUndo
+
+	view := m.View()
+	if !strings.Contains(view, "Undo commit (amend) 'Fix typo'? This runs git reset --soft abcdef1 (y/n)") {
+		t.Errorf("view should show what the undo resets, got:\n%s", view)
+	}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	m = newModel.(StatusModel)
+	if m.confirmMode != confirmNone || cmd != nil {
+		t.Error("n should cancel the undo")
+	}
+
+	m.confirmMode = confirmUndo
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m = newModel.(StatusModel)
+	if m.confirmMode != confirmNone || cmd == nil {
+		t.Error("y should run the undo")
+	}
+}
@@ -3,16 +3,20 @@ package ui
 import (
 	"fmt"
 
+	"go-on-git/internal/git"
+
 	"github.com/charmbracelet/lipgloss"
 )
 
 var (
 	// Using ANSI color numbers for broad terminal compatibility
-	colorGreen  = lipgloss.Color("2") // Green
-	colorRed    = lipgloss.Color("1") // Red
-	colorYellow = lipgloss.Color("3") // Yellow
-	colorBlue   = lipgloss.Color("4") // Blue
-	colorGray   = lipgloss.Color("8") // Bright black / gray
+	colorGreen   = lipgloss.Color("2") // Green
+	colorRed     = lipgloss.Color("1") // Red
+	colorYellow  = lipgloss.Color("3") // Yellow
+	colorBlue    = lipgloss.Color("4") // Blue
+	colorMagenta = lipgloss.Color("5") // Magenta
+	colorCyan    = lipgloss.Color("6") // Cyan
+	colorGray    = lipgloss.Color("8") // Bright black / gray
 
 	// Base styles
 	StyleNormal = lipgloss.NewStyle()
@@ -22,6 +26,7 @@ var (
 	StyleStaged    = lipgloss.NewStyle().Foreground(colorGreen)
 	StyleUnstaged  = lipgloss.NewStyle().Foreground(colorRed)
 	StyleUntracked = lipgloss.NewStyle().Foreground(colorYellow)
+	StyleConflicted = lipgloss.NewStyle().Foreground(colorRed).Bold(true)
 
 	// Selection styles
 	StyleSelected = lipgloss.NewStyle().
@@ -46,6 +51,23 @@ var (
 	StyleHunkHeaderStaged   = lipgloss.NewStyle().Foreground(colorGreen)
 	StyleHunkHeaderUnstaged = lipgloss.NewStyle().Foreground(colorRed)
 
+	// Log styles (matching git's default decoration colors)
+	StyleCommitHash = lipgloss.NewStyle().Foreground(colorYellow)
+	StyleRefHead    = lipgloss.NewStyle().Foreground(colorCyan).Bold(true)
+	StyleRefBranch  = lipgloss.NewStyle().Foreground(colorGreen).Bold(true)
+	StyleRefRemote  = lipgloss.NewStyle().Foreground(colorRed).Bold(true)
+	StyleRefTag     = lipgloss.NewStyle().Foreground(colorYellow).Bold(true)
+
+	// Commit graph lane colors, cycled by lane (same order as git log --graph)
+	GraphLaneStyles = []lipgloss.Style{
+		lipgloss.NewStyle().Foreground(colorRed),
+		lipgloss.NewStyle().Foreground(colorGreen),
+		lipgloss.NewStyle().Foreground(colorYellow),
+		lipgloss.NewStyle().Foreground(colorBlue),
+		lipgloss.NewStyle().Foreground(colorMagenta),
+		lipgloss.NewStyle().Foreground(colorCyan),
+	}
+
 	// Help styles
 	StyleHelpKey   = lipgloss.NewStyle().Foreground(colorYellow)
 	StyleHelpDesc  = lipgloss.NewStyle().Foreground(colorGray)
@@ -57,6 +79,10 @@ var (
 	// Confirm dialog
 	StyleConfirm = lipgloss.NewStyle().Foreground(colorRed).Bold(true)
 
+	// Commit message line length (past 50 / past 72 characters)
+	StyleColumnWarn = lipgloss.NewStyle().Foreground(colorYellow)
+	StyleColumnOver = lipgloss.NewStyle().Foreground(colorRed)
+
 	// Empty state
 	StyleEmpty = lipgloss.NewStyle()
 )
@@ -78,6 +104,10 @@ func StatusCharStyled(indexStatus, workStatus byte, section string, extra lipglo
 	case "unstaged":
 		word = workStatusWord(workStatus)
 		style = StyleUnstaged
+	case "conflicted":
+		// Unmerged descriptions are longer, so pad them like git status does
+		word = git.ConflictDescription(indexStatus, workStatus) + ":"
+		return StyleConflicted.Inherit(extra).Render(fmt.Sprintf("%-17s", word))
 	case "untracked":
 		// No status prefix for untracked files (like git status)
 		return ""
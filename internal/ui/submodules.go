@@ -0,0 +1,388 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"go-on-git/internal/git"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// SubmodulesModel is the bubbletea model for the submodules view
+type SubmodulesModel struct {
+	submodules      []git.Submodule
+	loaded          bool
+	cursor          int
+	scrollOffset    int
+	running         string // action in progress, e.g. "Updating lib"
+	notice          string // result of the last action
+	showHelp        bool
+	showVerboseHelp bool
+	lastKey         string
+	err             error
+	width           int
+	height          int
+}
+
+// NewSubmodulesModel creates a new submodules model
+func NewSubmodulesModel() SubmodulesModel {
+	return NewSubmodulesModelWithOptions(false)
+}
+
+// NewSubmodulesModelWithOptions creates a new submodules model with options
+func NewSubmodulesModelWithOptions(showVerboseHelp bool) SubmodulesModel {
+	return SubmodulesModel{
+		showVerboseHelp: showVerboseHelp,
+	}
+}
+
+type submodulesMsg struct {
+	submodules []git.Submodule
+	notice     string
+}
+
+// Init initializes the model
+func (m SubmodulesModel) Init() tea.Cmd {
+	return refreshSubmodules
+}
+
+func refreshSubmodules() tea.Msg {
+	submodules, err := git.GetSubmodules()
+	if err != nil {
+		return errMsg{err}
+	}
+	return submodulesMsg{submodules: submodules}
+}
+
+// inPrompt returns true while the help is open or an action is running
+func (m SubmodulesModel) inPrompt() bool {
+	return m.showHelp || m.running != ""
+}
+
+// SelectedSubmodule returns the submodule under the cursor
+func (m SubmodulesModel) SelectedSubmodule() (git.Submodule, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.submodules) {
+		return git.Submodule{}, false
+	}
+	return m.submodules[m.cursor], true
+}
+
+// Update handles messages
+func (m SubmodulesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		key := msg.String()
+
+		// Handle help mode
+		if m.showHelp {
+			if key == Keys.Help || key == "esc" || key == Keys.Quit {
+				m.showHelp = false
+			}
+			return m, nil
+		}
+
+		// Ignore keys while an update or sync is running
+		if m.running != "" {
+			return m, nil
+		}
+
+		// Check for gg sequence
+		if m.lastKey == Keys.Top && key == Keys.Top {
+			m.lastKey = ""
+			m.cursor = 0
+			m.ensureCursorVisible()
+			return m, nil
+		}
+
+		if key == Keys.Top {
+			m.lastKey = Keys.Top
+			return m, nil
+		}
+		m.lastKey = ""
+
+		switch key {
+		case Keys.Help:
+			m.showHelp = true
+			return m, nil
+		case Keys.VerboseHelp:
+			m.showVerboseHelp = !m.showVerboseHelp
+			return m, nil
+		case Keys.Down, "down":
+			if len(m.submodules) > 0 {
+				m.cursor = min(m.cursor+1, len(m.submodules)-1)
+				m.ensureCursorVisible()
+			}
+			return m, nil
+		case Keys.Up, "up":
+			if len(m.submodules) > 0 {
+				m.cursor = max(m.cursor-1, 0)
+				m.ensureCursorVisible()
+			}
+			return m, nil
+		case Keys.Bottom:
+			if len(m.submodules) > 0 {
+				m.cursor = len(m.submodules) - 1
+				m.ensureCursorVisible()
+			}
+			return m, nil
+		case Keys.SubmoduleInit:
+			return m.run("Initializing", "Initialized", git.InitSubmodule)
+		case Keys.SubmoduleUpdate:
+			return m.run("Updating", "Updated", git.UpdateSubmodule)
+		case Keys.SubmoduleSync:
+			return m.run("Syncing", "Synced", git.SyncSubmodule)
+		case Keys.Right, "right", "enter":
+			sm, ok := m.SelectedSubmodule()
+			if !ok {
+				return m, nil
+			}
+			if !sm.Initialized {
+				m.err = fmt.Errorf("'%s' is not checked out, press %s to update it first", sm.Path, Keys.SubmoduleUpdate)
+				return m, nil
+			}
+			m.err = nil
+			m.notice = ""
+			return m, openNestedSession(filepath.Join(git.GetRepoRoot(), sm.Path))
+		}
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case submodulesMsg:
+		m.submodules = msg.submodules
+		m.loaded = true
+		m.running = ""
+		m.err = nil
+		m.notice = msg.notice
+		if m.cursor >= len(m.submodules) {
+			m.cursor = max(0, len(m.submodules)-1)
+		}
+		m.ensureCursorVisible()
+		return m, nil
+
+	case errMsg:
+		m.running = ""
+		m.err = msg.err
+		m.notice = ""
+		return m, nil
+	}
+
+	return m, nil
+}
+
+// run starts an action on the selected submodule, showing progress while
+// it runs since update may have to clone
+func (m SubmodulesModel) run(progress, done string, action func(path string) error) (tea.Model, tea.Cmd) {
+	sm, ok := m.SelectedSubmodule()
+	if !ok {
+		return m, nil
+	}
+	m.err = nil
+	m.notice = ""
+	m.running = fmt.Sprintf("%s '%s'...", progress, sm.Path)
+	return m, func() tea.Msg {
+		if err := action(sm.Path); err != nil {
+			return errMsg{err}
+		}
+		msg := refreshSubmodules()
+		if submodules, ok := msg.(submodulesMsg); ok {
+			submodules.notice = fmt.Sprintf("%s '%s'", done, sm.Path)
+			return submodules
+		}
+		return msg
+	}
+}
+
+// openNestedSession runs another go-on-git rooted in dir, with the same
+// options as this one, and reloads the submodules when it exits
+func openNestedSession(dir string) tea.Cmd {
+	exe, err := os.Executable()
+	if err != nil {
+		return func() tea.Msg { return errMsg{fmt.Errorf("open nested session: %w", err)} }
+	}
+	c := exec.Command(exe, os.Args[1:]...)
+	c.Dir = dir
+	return tea.ExecProcess(c, func(err error) tea.Msg {
+		if err != nil {
+			return errMsg{fmt.Errorf("nested session in %s: %w", dir, err)}
+		}
+		return refreshSubmodules()
+	})
+}
+
+// visibleLines returns the number of submodule lines that can be displayed
+func (m SubmodulesModel) visibleLines() int {
+	// Reserve lines for: header (~3), help bar (~3 if shown), notices, and buffer
+	reserved := 8
+	if m.showVerboseHelp {
+		reserved += 3
+	}
+	if m.height <= reserved {
+		return 10 // fallback minimum
+	}
+	return m.height - reserved
+}
+
+// ensureCursorVisible adjusts scrollOffset to keep cursor in view
+func (m *SubmodulesModel) ensureCursorVisible() {
+	visible := m.visibleLines()
+	if m.cursor < m.scrollOffset {
+		m.scrollOffset = m.cursor
+	}
+	if m.cursor >= m.scrollOffset+visible {
+		m.scrollOffset = m.cursor - visible + 1
+	}
+	m.scrollOffset = max(0, min(m.scrollOffset, len(m.submodules)-visible))
+}
+
+// View renders the model
+func (m SubmodulesModel) View() string {
+	if m.showHelp {
+		return m.renderHelp()
+	}
+
+	var sb strings.Builder
+
+	if m.err != nil {
+		sb.WriteString(StyleUnstaged.Render(fmt.Sprintf("Error: %v", m.err)))
+		sb.WriteString("\n\n")
+	}
+
+	sb.WriteString(m.renderHeader())
+	sb.WriteString("\n\n")
+
+	switch {
+	case !m.loaded:
+		sb.WriteString(StyleMuted.Render("Loading..."))
+		sb.WriteString("\n")
+	case len(m.submodules) == 0:
+		sb.WriteString(StyleEmpty.Render("No submodules"))
+		sb.WriteString("\n")
+	}
+
+	visibleEnd := min(m.scrollOffset+m.visibleLines(), len(m.submodules))
+	if m.scrollOffset > 0 {
+		sb.WriteString(StyleMuted.Render(fmt.Sprintf("  ↑ %d more above", m.scrollOffset)))
+		sb.WriteString("\n")
+	}
+
+	for i := m.scrollOffset; i < visibleEnd; i++ {
+		sm := m.submodules[i]
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+		}
+
+		sb.WriteString(prefix)
+		sb.WriteString(sm.Path)
+		sb.WriteString(" ")
+		sb.WriteString(StyleCommitHash.Render(git.ShortHash(sm.Commit)))
+		if sm.Describe != "" {
+			sb.WriteString(StyleMuted.Render(" (" + sm.Describe + ")"))
+		}
+		switch {
+		case !sm.Initialized:
+			sb.WriteString(StyleUntracked.Render(" [not initialized]"))
+		case sm.Conflicted:
+			sb.WriteString(StyleConflicted.Render(" [conflicted]"))
+		case sm.OutOfSync:
+			sb.WriteString(StyleUnstaged.Render(" [differs from the recorded commit]"))
+		}
+		sb.WriteString("\n")
+	}
+
+	if visibleEnd < len(m.submodules) {
+		sb.WriteString(StyleMuted.Render(fmt.Sprintf("  ↓ %d more below", len(m.submodules)-visibleEnd)))
+		sb.WriteString("\n")
+	}
+
+	switch {
+	case m.running != "":
+		sb.WriteString("\n")
+		sb.WriteString(StyleMuted.Render(m.running))
+		sb.WriteString("\n")
+	case m.notice != "":
+		sb.WriteString("\n")
+		sb.WriteString(StyleMuted.Render(m.notice))
+		sb.WriteString("\n")
+	}
+
+	// Help bar (only show when showVerboseHelp is on)
+	if m.showVerboseHelp {
+		sb.WriteString("\n\n")
+		sb.WriteString(m.renderHelpBar())
+	}
+
+	return sb.String()
+}
+
+func (m SubmodulesModel) renderHeader() string {
+	return StyleMuted.Render("> git submodule status") + "  " + StyleMuted.Render("(esc to go back)") + "\n" + StyleMuted.Render("───────────────────────────────────────────────────────────────")
+}
+
+func (m SubmodulesModel) renderHelpBar() string {
+	var sb strings.Builder
+
+	sb.WriteString(StyleMuted.Render("───────────────────────────────────────────────────────────────"))
+	sb.WriteString("\n")
+
+	items := []struct{ key, desc string }{
+		{formatKeyList(Keys.Down, Keys.Up), "navigate"},
+		{formatKeyList(Keys.Right, "Enter"), "open"},
+		{Keys.SubmoduleInit, "init"},
+		{Keys.SubmoduleUpdate, "update"},
+		{Keys.SubmoduleSync, "sync"},
+		{Keys.Help, "help"},
+		{formatKeyList(Keys.Left, "ESC"), "back"},
+	}
+
+	for _, item := range items {
+		sb.WriteString(StyleHelpKey.Render(item.key))
+		sb.WriteString(" ")
+		sb.WriteString(StyleHelpDesc.Render(item.desc))
+		sb.WriteString("  ")
+	}
+
+	return sb.String()
+}
+
+func (m SubmodulesModel) renderHelp() string {
+	var sb strings.Builder
+
+	sb.WriteString(StyleHelpTitle.Render("Submodules Shortcuts"))
+	sb.WriteString("\n\n")
+
+	moveKeys := formatKeyList(Keys.Down, Keys.Up, "↓", "↑")
+	topKey := formatDoubleKey(Keys.Top)
+	openKeys := formatKeyList(Keys.Right, "→", "Enter")
+	backKeys := formatKeyList(Keys.Left, "←", "ESC")
+
+	help := []struct {
+		key  string
+		desc string
+	}{
+		{moveKeys, "Move down/up"},
+		{topKey, "Go to top"},
+		{Keys.Bottom, "Go to bottom"},
+		{openKeys, "Open go-on-git inside the submodule"},
+		{Keys.SubmoduleInit, "Init submodule (register its URL)"},
+		{Keys.SubmoduleUpdate, "Update submodule (clone and check out the recorded commit)"},
+		{Keys.SubmoduleSync, "Sync submodule URL from .gitmodules"},
+		{Keys.Help, "Toggle help"},
+		{backKeys, "Go back"},
+	}
+
+	for _, h := range help {
+		sb.WriteString(fmt.Sprintf("  %s  %s\n",
+			StyleHelpKey.Render(fmt.Sprintf("%-8s", h.key)),
+			StyleHelpDesc.Render(h.desc)))
+	}
+
+	return sb.String()
+}
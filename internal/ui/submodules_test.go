@@ -0,0 +1,144 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"go-on-git/internal/git"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func makeSubmodules() []git.Submodule {
+	return []git.Submodule{
+		{Path: "lib", Commit: "1111111111111111111111111111111111111111", Describe: "heads/main", Initialized: true},
+		{Path: "vendor/other", Commit: "2222222222222222222222222222222222222222"},
+		{Path: "third", Commit: "3333333333333333333333333333333333333333", Initialized: true, OutOfSync: true},
+	}
+}
+
+func TestNewSubmodulesModel(t *testing.T) {
+	m := NewSubmodulesModel()
+
+	if m.cursor != 0 {
+		t.Errorf("cursor = %d, want 0", m.cursor)
+	}
+	if m.loaded {
+		t.Error("loaded should be false initially")
+	}
+	if m.Init() == nil {
+		t.Error("Init() should return a command")
+	}
+}
+
+func TestSubmodulesModelView(t *testing.T) {
+	m := NewSubmodulesModel()
+	m.submodules = makeSubmodules()
+	m.loaded = true
+
+	view := m.View()
+
+	if !strings.Contains(view, "> git submodule status") {
+		t.Error("view should contain the header")
+	}
+	for _, want := range []string{"lib", "1111111", "(heads/main)", "[not initialized]", "[differs from the recorded commit]"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q", want)
+		}
+	}
+}
+
+func TestSubmodulesModelViewEmpty(t *testing.T) {
+	m := NewSubmodulesModel()
+
+	newModel, _ := m.Update(submodulesMsg{})
+	m = newModel.(SubmodulesModel)
+
+	if !strings.Contains(m.View(), "No submodules") {
+		t.Error("view should show 'No submodules' when there are none")
+	}
+}
+
+func TestSubmodulesModelActions(t *testing.T) {
+	tests := []struct {
+		key      rune
+		progress string
+	}{
+		{'i', "Initializing 'lib'..."},
+		{'u', "Updating 'lib'..."},
+		{'s', "Syncing 'lib'..."},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.key), func(t *testing.T) {
+			m := NewSubmodulesModel()
+			m.submodules = makeSubmodules()
+			m.loaded = true
+
+			newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{tt.key}})
+			m = newModel.(SubmodulesModel)
+			if cmd == nil {
+				t.Fatal("expected a command")
+			}
+			if m.running != tt.progress {
+				t.Errorf("running = %q, want %q", m.running, tt.progress)
+			}
+			if !m.inPrompt() {
+				t.Error("keys should be ignored while the action runs")
+			}
+
+			// The result clears the progress
+			newModel, _ = m.Update(submodulesMsg{submodules: m.submodules, notice: "Updated 'lib'"})
+			m = newModel.(SubmodulesModel)
+			if m.running != "" || !strings.Contains(m.View(), "Updated 'lib'") {
+				t.Error("the result should replace the progress")
+			}
+		})
+	}
+}
+
+func TestSubmodulesModelActionError(t *testing.T) {
+	m := NewSubmodulesModel()
+	m.submodules = makeSubmodules()
+	m.loaded = true
+	m.running = "Updating 'lib'..."
+
+	newModel, _ := m.Update(errMsg{errors.New("clone failed")})
+	m = newModel.(SubmodulesModel)
+
+	if m.running != "" {
+		t.Error("an error should clear the progress")
+	}
+	if !strings.Contains(m.View(), "Error: clone failed") {
+		t.Error("view should show the error")
+	}
+}
+
+func TestSubmodulesModelOpenUninitialized(t *testing.T) {
+	m := NewSubmodulesModel()
+	m.submodules = makeSubmodules()
+	m.loaded = true
+	m.cursor = 1
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(SubmodulesModel)
+
+	if cmd != nil {
+		t.Error("an uninitialized submodule should not be opened")
+	}
+	if m.err == nil || !strings.Contains(m.err.Error(), "not checked out") {
+		t.Errorf("expected a 'not checked out' error, got %v", m.err)
+	}
+}
+
+func TestSubmodulesModelOpenNestedSession(t *testing.T) {
+	m := NewSubmodulesModel()
+	m.submodules = makeSubmodules()
+	m.loaded = true
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Error("enter should open a nested session")
+	}
+}
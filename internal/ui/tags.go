@@ -0,0 +1,506 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go-on-git/internal/git"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TagsModel is the bubbletea model for the tags view
+type TagsModel struct {
+	tags            []git.Tag
+	loaded          bool
+	cursor          int
+	scrollOffset    int
+	nameMode        bool // typing the name of a new tag
+	messageMode     bool // typing the message of a new tag (empty for lightweight)
+	target          git.CommitInfo
+	pendingName     string
+	nameInput       textinput.Model
+	messageInput    textinput.Model
+	deleteMode      bool
+	pushMode        bool
+	remotePicker    picker
+	notice          string // result of the last action
+	showHelp        bool
+	showVerboseHelp bool
+	lastKey         string
+	err             error
+	width           int
+	height          int
+}
+
+// NewTagsModel creates a new tags model
+func NewTagsModel() TagsModel {
+	return NewTagsModelWithOptions(false)
+}
+
+// NewTagsModelWithOptions creates a new tags model with options
+func NewTagsModelWithOptions(showVerboseHelp bool) TagsModel {
+	ni := textinput.New()
+	ni.Placeholder = "Tag name"
+	ni.CharLimit = 100
+	ni.Width = 40
+
+	mi := textinput.New()
+	mi.Placeholder = "Empty for a lightweight tag"
+	mi.CharLimit = 500
+	mi.Width = 60
+
+	return TagsModel{
+		nameInput:       ni,
+		messageInput:    mi,
+		showVerboseHelp: showVerboseHelp,
+	}
+}
+
+type tagsMsg struct {
+	tags   []git.Tag
+	notice string
+}
+
+// Init initializes the model
+func (m TagsModel) Init() tea.Cmd {
+	return refreshTags
+}
+
+func refreshTags() tea.Msg {
+	tags, err := git.GetTags()
+	if err != nil {
+		return errMsg{err}
+	}
+	return tagsMsg{tags: tags}
+}
+
+// inPrompt returns true while an input, confirmation or picker is open
+func (m TagsModel) inPrompt() bool {
+	return m.showHelp || m.nameMode || m.messageMode || m.deleteMode || m.pushMode
+}
+
+// startCreate opens the name prompt for a tag on target (HEAD if empty)
+func (m *TagsModel) startCreate(target git.CommitInfo) tea.Cmd {
+	m.target = target
+	m.nameMode = true
+	m.err = nil
+	m.notice = ""
+	m.nameInput.Reset()
+	m.nameInput.Focus()
+	return textinput.Blink
+}
+
+// Update handles messages
+func (m TagsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		key := msg.String()
+
+		// Handle help mode
+		if m.showHelp {
+			if key == Keys.Help || key == "esc" || key == Keys.Quit {
+				m.showHelp = false
+			}
+			return m, nil
+		}
+
+		// Handle the name prompt
+		if m.nameMode {
+			switch key {
+			case "enter":
+				name := strings.TrimSpace(m.nameInput.Value())
+				m.nameMode = false
+				m.nameInput.Blur()
+				if name == "" {
+					return m, nil
+				}
+				m.pendingName = name
+				m.messageMode = true
+				m.messageInput.Reset()
+				m.messageInput.Focus()
+				return m, textinput.Blink
+			case "esc":
+				m.nameMode = false
+				m.nameInput.Blur()
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.nameInput, cmd = m.nameInput.Update(msg)
+			return m, cmd
+		}
+
+		// Handle the message prompt
+		if m.messageMode {
+			switch key {
+			case "enter":
+				message := strings.TrimSpace(m.messageInput.Value())
+				m.messageMode = false
+				m.messageInput.Blur()
+				return m, m.doCreateTag(m.pendingName, m.target.Hash, message)
+			case "esc":
+				m.messageMode = false
+				m.messageInput.Blur()
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.messageInput, cmd = m.messageInput.Update(msg)
+			return m, cmd
+		}
+
+		// Handle delete confirmation
+		if m.deleteMode {
+			switch key {
+			case "y", "Y":
+				m.deleteMode = false
+				return m, m.doDeleteTag()
+			case "n", "N", "esc":
+				m.deleteMode = false
+			}
+			return m, nil
+		}
+
+		// Handle the remote picker for pushing
+		if m.pushMode {
+			if m.remotePicker.move(key) {
+				return m, nil
+			}
+			switch key {
+			case "enter":
+				m.pushMode = false
+				return m, m.doPushTag(m.remotePicker.selected())
+			case "esc", Keys.Quit:
+				m.pushMode = false
+			}
+			return m, nil
+		}
+
+		// Check for gg sequence
+		if m.lastKey == Keys.Top && key == Keys.Top {
+			m.lastKey = ""
+			m.cursor = 0
+			m.ensureCursorVisible()
+			return m, nil
+		}
+
+		if key == Keys.Top {
+			m.lastKey = Keys.Top
+			return m, nil
+		}
+		m.lastKey = ""
+
+		switch key {
+		case Keys.Help:
+			m.showHelp = true
+			return m, nil
+		case Keys.VerboseHelp:
+			m.showVerboseHelp = !m.showVerboseHelp
+			return m, nil
+		case Keys.Down, "down":
+			if len(m.tags) > 0 {
+				m.cursor = min(m.cursor+1, len(m.tags)-1)
+				m.ensureCursorVisible()
+			}
+			return m, nil
+		case Keys.Up, "up":
+			if len(m.tags) > 0 {
+				m.cursor = max(m.cursor-1, 0)
+				m.ensureCursorVisible()
+			}
+			return m, nil
+		case Keys.Bottom:
+			if len(m.tags) > 0 {
+				m.cursor = len(m.tags) - 1
+				m.ensureCursorVisible()
+			}
+			return m, nil
+		case Keys.NewTag:
+			// New tag on HEAD
+			cmd := m.startCreate(git.CommitInfo{})
+			return m, cmd
+		case Keys.Delete:
+			if m.cursor < len(m.tags) {
+				m.notice = ""
+				m.deleteMode = true
+			}
+			return m, nil
+		case Keys.Push:
+			if m.cursor >= len(m.tags) {
+				return m, nil
+			}
+			remotes, err := git.GetRemotes()
+			if err != nil {
+				m.err = err
+				return m, nil
+			}
+			if len(remotes) == 0 {
+				m.err = fmt.Errorf("no remotes configured")
+				return m, nil
+			}
+			m.err = nil
+			m.notice = ""
+			m.pushMode = true
+			m.remotePicker = newPicker(fmt.Sprintf("Push '%s' to:", m.tags[m.cursor].Name), remotes, 0)
+			return m, nil
+		}
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tagsMsg:
+		m.tags = msg.tags
+		m.loaded = true
+		m.err = nil
+		m.notice = msg.notice
+		if m.cursor >= len(m.tags) {
+			m.cursor = max(0, len(m.tags)-1)
+		}
+		m.ensureCursorVisible()
+		return m, nil
+
+	case errMsg:
+		m.err = msg.err
+		m.notice = ""
+		return m, nil
+	}
+
+	return m, nil
+}
+
+func (m TagsModel) doCreateTag(name, target, message string) tea.Cmd {
+	return func() tea.Msg {
+		if err := git.CreateTag(name, target, message); err != nil {
+			return errMsg{err}
+		}
+		return withTagsNotice(refreshTags(), fmt.Sprintf("Created tag '%s'", name))
+	}
+}
+
+func (m TagsModel) doDeleteTag() tea.Cmd {
+	if m.cursor >= len(m.tags) {
+		return nil
+	}
+	name := m.tags[m.cursor].Name
+	return func() tea.Msg {
+		if err := git.DeleteTag(name); err != nil {
+			return errMsg{err}
+		}
+		return withTagsNotice(refreshTags(), fmt.Sprintf("Deleted tag '%s'", name))
+	}
+}
+
+func (m TagsModel) doPushTag(remote string) tea.Cmd {
+	if m.cursor >= len(m.tags) {
+		return nil
+	}
+	name := m.tags[m.cursor].Name
+	return func() tea.Msg {
+		if err := git.PushTag(remote, name); err != nil {
+			return errMsg{err}
+		}
+		return withTagsNotice(refreshTags(), fmt.Sprintf("Pushed tag '%s' to '%s'", name, remote))
+	}
+}
+
+// withTagsNotice adds a notice to a refreshed tag list
+func withTagsNotice(msg tea.Msg, notice string) tea.Msg {
+	if tags, ok := msg.(tagsMsg); ok {
+		tags.notice = notice
+		return tags
+	}
+	return msg
+}
+
+// visibleLines returns the number of tag lines that can be displayed
+func (m TagsModel) visibleLines() int {
+	// Reserve lines for: header (~3), help bar (~3 if shown), prompts, and buffer
+	reserved := 8
+	if m.showVerboseHelp {
+		reserved += 3
+	}
+	if m.height <= reserved {
+		return 10 // fallback minimum
+	}
+	return m.height - reserved
+}
+
+// ensureCursorVisible adjusts scrollOffset to keep cursor in view
+func (m *TagsModel) ensureCursorVisible() {
+	visible := m.visibleLines()
+	if m.cursor < m.scrollOffset {
+		m.scrollOffset = m.cursor
+	}
+	if m.cursor >= m.scrollOffset+visible {
+		m.scrollOffset = m.cursor - visible + 1
+	}
+	m.scrollOffset = max(0, min(m.scrollOffset, len(m.tags)-visible))
+}
+
+// View renders the model
+func (m TagsModel) View() string {
+	if m.showHelp {
+		return m.renderHelp()
+	}
+
+	var sb strings.Builder
+
+	if m.err != nil {
+		sb.WriteString(StyleUnstaged.Render(fmt.Sprintf("Error: %v", m.err)))
+		sb.WriteString("\n\n")
+	}
+
+	sb.WriteString(m.renderHeader())
+	sb.WriteString("\n\n")
+
+	switch {
+	case !m.loaded:
+		sb.WriteString(StyleMuted.Render("Loading..."))
+		sb.WriteString("\n")
+	case len(m.tags) == 0:
+		sb.WriteString(StyleEmpty.Render("No tags"))
+		sb.WriteString("\n")
+	}
+
+	visibleEnd := min(m.scrollOffset+m.visibleLines(), len(m.tags))
+	if m.scrollOffset > 0 {
+		sb.WriteString(StyleMuted.Render(fmt.Sprintf("  ↑ %d more above", m.scrollOffset)))
+		sb.WriteString("\n")
+	}
+
+	now := time.Now()
+	for i := m.scrollOffset; i < visibleEnd; i++ {
+		tag := m.tags[i]
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+		}
+
+		sb.WriteString(prefix)
+		sb.WriteString(StyleRefTag.Render(tag.Name))
+		sb.WriteString(" ")
+		sb.WriteString(StyleCommitHash.Render(git.ShortHash(tag.Target)))
+		if tag.Annotated {
+			if subject := tag.Subject(); subject != "" {
+				sb.WriteString(" ")
+				sb.WriteString(subject)
+			}
+			sb.WriteString(StyleMuted.Render(fmt.Sprintf(" - %s, %s", tag.Tagger, formatRelativeTime(tag.Date, now))))
+		} else {
+			sb.WriteString(StyleMuted.Render(fmt.Sprintf(" (lightweight) - %s", formatRelativeTime(tag.Date, now))))
+		}
+		sb.WriteString("\n")
+	}
+
+	if visibleEnd < len(m.tags) {
+		sb.WriteString(StyleMuted.Render(fmt.Sprintf("  ↓ %d more below", len(m.tags)-visibleEnd)))
+		sb.WriteString("\n")
+	}
+
+	if m.notice != "" {
+		sb.WriteString("\n")
+		sb.WriteString(StyleMuted.Render(m.notice))
+		sb.WriteString("\n")
+	}
+
+	// Prompts
+	switch {
+	case m.nameMode:
+		sb.WriteString("\n")
+		sb.WriteString(fmt.Sprintf("New tag on %s: ", m.targetLabel()))
+		sb.WriteString(m.nameInput.View())
+		sb.WriteString(StyleMuted.Render("  (enter to continue, esc to cancel)"))
+	case m.messageMode:
+		sb.WriteString("\n")
+		sb.WriteString(fmt.Sprintf("Message for '%s': ", m.pendingName))
+		sb.WriteString(m.messageInput.View())
+		sb.WriteString(StyleMuted.Render("  (enter to create, esc to cancel)"))
+	case m.deleteMode && m.cursor < len(m.tags):
+		sb.WriteString("\n")
+		sb.WriteString(StyleConfirm.Render(fmt.Sprintf("Delete tag '%s'? (y/n) ", m.tags[m.cursor].Name)))
+	case m.pushMode:
+		sb.WriteString("\n")
+		sb.WriteString(m.remotePicker.View())
+	}
+
+	// Help bar (only show when showVerboseHelp is on and not in a prompt)
+	if m.showVerboseHelp && !m.inPrompt() {
+		sb.WriteString("\n\n")
+		sb.WriteString(m.renderHelpBar())
+	}
+
+	return sb.String()
+}
+
+// targetLabel describes the commit a new tag goes on
+func (m TagsModel) targetLabel() string {
+	if m.target.Hash == "" {
+		return "HEAD"
+	}
+	return m.target.ShortHash
+}
+
+func (m TagsModel) renderHeader() string {
+	return StyleMuted.Render("> git tag") + "  " + StyleMuted.Render("(esc to go back)") + "\n" + StyleMuted.Render("───────────────────────────────────────────────────────────────")
+}
+
+func (m TagsModel) renderHelpBar() string {
+	var sb strings.Builder
+
+	sb.WriteString(StyleMuted.Render("───────────────────────────────────────────────────────────────"))
+	sb.WriteString("\n")
+
+	items := []struct{ key, desc string }{
+		{formatKeyList(Keys.Down, Keys.Up), "navigate"},
+		{Keys.NewTag, "new"},
+		{Keys.Delete, "delete"},
+		{Keys.Push, "push"},
+		{Keys.Help, "help"},
+		{formatKeyList(Keys.Left, "ESC"), "back"},
+	}
+
+	for _, item := range items {
+		sb.WriteString(StyleHelpKey.Render(item.key))
+		sb.WriteString(" ")
+		sb.WriteString(StyleHelpDesc.Render(item.desc))
+		sb.WriteString("  ")
+	}
+
+	return sb.String()
+}
+
+func (m TagsModel) renderHelp() string {
+	var sb strings.Builder
+
+	sb.WriteString(StyleHelpTitle.Render("Tags Shortcuts"))
+	sb.WriteString("\n\n")
+
+	moveKeys := formatKeyList(Keys.Down, Keys.Up, "↓", "↑")
+	topKey := formatDoubleKey(Keys.Top)
+	backKeys := formatKeyList(Keys.Left, "←", "ESC")
+
+	help := []struct {
+		key  string
+		desc string
+	}{
+		{moveKeys, "Move down/up"},
+		{topKey, "Go to top"},
+		{Keys.Bottom, "Go to bottom"},
+		{Keys.NewTag, "New tag on HEAD (empty message for lightweight)"},
+		{Keys.Delete, "Delete tag"},
+		{Keys.Push, "Push tag to a remote"},
+		{Keys.Help, "Toggle help"},
+		{backKeys, "Go back"},
+	}
+
+	for _, h := range help {
+		sb.WriteString(fmt.Sprintf("  %s  %s\n",
+			StyleHelpKey.Render(fmt.Sprintf("%-8s", h.key)),
+			StyleHelpDesc.Render(h.desc)))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,267 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go-on-git/internal/git"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func makeTags() []git.Tag {
+	return []git.Tag{
+		{
+			Name:      "v1.1.0",
+			Target:    "1111111111111111111111111111111111111111",
+			Annotated: true,
+			Tagger:    "Test User",
+			Date:      time.Now().Add(-2 * time.Hour),
+			Message:   "Release 1.1.0\n\nMore details",
+		},
+		{
+			Name:   "v1.0.0",
+			Target: "2222222222222222222222222222222222222222",
+			Date:   time.Now().Add(-48 * time.Hour),
+		},
+	}
+}
+
+func TestNewTagsModel(t *testing.T) {
+	m := NewTagsModel()
+
+	if m.cursor != 0 {
+		t.Errorf("cursor = %d, want 0", m.cursor)
+	}
+	if m.loaded {
+		t.Error("loaded should be false initially")
+	}
+	if m.inPrompt() {
+		t.Error("inPrompt should be false initially")
+	}
+}
+
+func TestTagsModelInit(t *testing.T) {
+	m := NewTagsModel()
+	if cmd := m.Init(); cmd == nil {
+		t.Error("Init() should return a command")
+	}
+}
+
+func TestTagsModelTagsMsg(t *testing.T) {
+	m := NewTagsModel()
+	m.cursor = 5
+
+	newModel, _ := m.Update(tagsMsg{tags: makeTags(), notice: "Created tag 'v1.1.0'"})
+	m = newModel.(TagsModel)
+
+	if !m.loaded {
+		t.Error("loaded should be true after tagsMsg")
+	}
+	if m.cursor != 1 {
+		t.Errorf("cursor = %d, want 1 (clamped)", m.cursor)
+	}
+	if m.notice != "Created tag 'v1.1.0'" {
+		t.Errorf("notice = %q", m.notice)
+	}
+}
+
+func TestTagsModelNavigation(t *testing.T) {
+	m := NewTagsModel()
+	m.tags = makeTags()
+	m.loaded = true
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m = newModel.(TagsModel)
+	if m.cursor != 1 {
+		t.Errorf("after 'j', cursor = %d, want 1", m.cursor)
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m = newModel.(TagsModel)
+	if m.cursor != 1 {
+		t.Errorf("cursor should stay on the last tag, got %d", m.cursor)
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
+	m = newModel.(TagsModel)
+	if m.cursor != 0 {
+		t.Errorf("after 'k', cursor = %d, want 0", m.cursor)
+	}
+}
+
+func TestTagsModelCreateFlow(t *testing.T) {
+	m := NewTagsModel()
+	m.tags = makeTags()
+	m.loaded = true
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	m = newModel.(TagsModel)
+	if !m.nameMode {
+		t.Fatal("'n' should open the name prompt")
+	}
+	if !strings.Contains(m.View(), "New tag on HEAD:") {
+		t.Error("view should show the name prompt for HEAD")
+	}
+
+	for _, r := range "v2.0.0" {
+		newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = newModel.(TagsModel)
+	}
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(TagsModel)
+	if m.nameMode || !m.messageMode {
+		t.Fatal("enter should move from the name prompt to the message prompt")
+	}
+	if m.pendingName != "v2.0.0" {
+		t.Errorf("pendingName = %q, want 'v2.0.0'", m.pendingName)
+	}
+	if !strings.Contains(m.View(), "Message for 'v2.0.0':") {
+		t.Error("view should show the message prompt")
+	}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(TagsModel)
+	if m.messageMode {
+		t.Error("enter should close the message prompt")
+	}
+	if cmd == nil {
+		t.Error("enter in the message prompt should return a create command")
+	}
+}
+
+func TestTagsModelCreateEmptyNameCancels(t *testing.T) {
+	m := NewTagsModel()
+	m.loaded = true
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	m = newModel.(TagsModel)
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(TagsModel)
+
+	if m.nameMode || m.messageMode {
+		t.Error("an empty name should cancel the new tag")
+	}
+	if cmd != nil {
+		t.Error("an empty name should not return a command")
+	}
+}
+
+func TestTagsModelStartCreateOnCommit(t *testing.T) {
+	m := NewTagsModel()
+	commit := makeCommits(1)[0]
+
+	m.startCreate(commit)
+
+	if !m.nameMode {
+		t.Error("startCreate should open the name prompt")
+	}
+	if !strings.Contains(m.View(), "New tag on "+commit.ShortHash+":") {
+		t.Error("view should name the target commit")
+	}
+}
+
+func TestTagsModelDeleteConfirm(t *testing.T) {
+	m := NewTagsModel()
+	m.tags = makeTags()
+	m.loaded = true
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	m = newModel.(TagsModel)
+	if !m.deleteMode {
+		t.Fatal("'d' should open the delete confirmation")
+	}
+	if !strings.Contains(m.View(), "Delete tag 'v1.1.0'? (y/n)") {
+		t.Error("view should show the delete confirmation")
+	}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	m = newModel.(TagsModel)
+	if m.deleteMode || cmd != nil {
+		t.Error("'n' should cancel the delete")
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	m = newModel.(TagsModel)
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m = newModel.(TagsModel)
+	if m.deleteMode {
+		t.Error("'y' should close the delete confirmation")
+	}
+	if cmd == nil {
+		t.Error("'y' should return a delete command")
+	}
+}
+
+func TestTagsModelPushPicker(t *testing.T) {
+	m := NewTagsModel()
+	m.tags = makeTags()
+	m.loaded = true
+	m.pushMode = true
+	m.remotePicker = newPicker("Push 'v1.1.0' to:", []string{"origin", "upstream"}, 0)
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'l'}})
+	m = newModel.(TagsModel)
+	if m.remotePicker.selected() != "upstream" {
+		t.Errorf("selected = %q, want 'upstream'", m.remotePicker.selected())
+	}
+	if !strings.Contains(m.View(), "Push 'v1.1.0' to:") {
+		t.Error("view should show the remote picker")
+	}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(TagsModel)
+	if m.pushMode {
+		t.Error("enter should close the remote picker")
+	}
+	if cmd == nil {
+		t.Error("enter should return a push command")
+	}
+}
+
+func TestTagsModelView(t *testing.T) {
+	m := NewTagsModel()
+	m.tags = makeTags()
+	m.loaded = true
+
+	view := m.View()
+
+	if !strings.Contains(view, "> git tag") {
+		t.Error("view should contain the header")
+	}
+	if !strings.Contains(view, "v1.1.0") || !strings.Contains(view, "1111111") {
+		t.Error("view should show the tag name and short target")
+	}
+	if !strings.Contains(view, "Release 1.1.0") || strings.Contains(view, "More details") {
+		t.Error("view should show only the subject of an annotated tag")
+	}
+	if !strings.Contains(view, "Test User") {
+		t.Error("view should show the tagger")
+	}
+	if !strings.Contains(view, "(lightweight)") {
+		t.Error("view should mark lightweight tags")
+	}
+}
+
+func TestTagsModelViewEmpty(t *testing.T) {
+	m := NewTagsModel()
+	m.loaded = true
+
+	if !strings.Contains(m.View(), "No tags") {
+		t.Error("view should show 'No tags' when there are none")
+	}
+}
+
+func TestTagsModelViewWithError(t *testing.T) {
+	m := NewTagsModel()
+	m.loaded = true
+
+	newModel, _ := m.Update(errMsg{errors.New("tag already exists")})
+	m = newModel.(TagsModel)
+
+	if !strings.Contains(m.View(), "Error: tag already exists") {
+		t.Error("view should show the error")
+	}
+}
@@ -0,0 +1,527 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"go-on-git/internal/git"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// WorktreesModel is the bubbletea model for the worktrees view
+type WorktreesModel struct {
+	worktrees       []git.Worktree
+	loaded          bool
+	cursor          int
+	scrollOffset    int
+	branchMode      bool // typing the branch of a new worktree
+	pathMode        bool // typing the path of a new worktree
+	pendingBranch   string
+	branchInput     textinput.Model
+	pathInput       textinput.Model
+	removeMode      bool
+	notice          string // result of the last action
+	showHelp        bool
+	showVerboseHelp bool
+	lastKey         string
+	err             error
+	width           int
+	height          int
+}
+
+// NewWorktreesModel creates a new worktrees model
+func NewWorktreesModel() WorktreesModel {
+	return NewWorktreesModelWithOptions(false)
+}
+
+// NewWorktreesModelWithOptions creates a new worktrees model with options
+func NewWorktreesModelWithOptions(showVerboseHelp bool) WorktreesModel {
+	bi := textinput.New()
+	bi.Placeholder = "Existing or new branch"
+	bi.CharLimit = 100
+	bi.Width = 40
+
+	pi := textinput.New()
+	pi.Placeholder = "Directory"
+	pi.CharLimit = 500
+	pi.Width = 60
+
+	return WorktreesModel{
+		branchInput:     bi,
+		pathInput:       pi,
+		showVerboseHelp: showVerboseHelp,
+	}
+}
+
+type worktreesMsg struct {
+	worktrees []git.Worktree
+	notice    string
+}
+
+// worktreeSwitchedMsg is sent once the process has moved to another worktree
+type worktreeSwitchedMsg struct {
+	path string
+}
+
+// Init initializes the model
+func (m WorktreesModel) Init() tea.Cmd {
+	return refreshWorktrees
+}
+
+func refreshWorktrees() tea.Msg {
+	worktrees, err := git.GetWorktrees()
+	if err != nil {
+		return errMsg{err}
+	}
+	return worktreesMsg{worktrees: worktrees}
+}
+
+// inPrompt returns true while an input or confirmation is open
+func (m WorktreesModel) inPrompt() bool {
+	return m.showHelp || m.branchMode || m.pathMode || m.removeMode
+}
+
+// SelectedWorktree returns the worktree under the cursor
+func (m WorktreesModel) SelectedWorktree() (git.Worktree, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.worktrees) {
+		return git.Worktree{}, false
+	}
+	return m.worktrees[m.cursor], true
+}
+
+// Update handles messages
+func (m WorktreesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		key := msg.String()
+
+		// Handle help mode
+		if m.showHelp {
+			if key == Keys.Help || key == "esc" || key == Keys.Quit {
+				m.showHelp = false
+			}
+			return m, nil
+		}
+
+		// Handle the branch prompt
+		if m.branchMode {
+			switch key {
+			case "enter":
+				branch := strings.TrimSpace(m.branchInput.Value())
+				m.branchMode = false
+				m.branchInput.Blur()
+				if branch == "" {
+					return m, nil
+				}
+				m.pendingBranch = branch
+				m.pathMode = true
+				m.pathInput.SetValue(git.DefaultWorktreePath(branch))
+				m.pathInput.CursorEnd()
+				m.pathInput.Focus()
+				return m, textinput.Blink
+			case "esc":
+				m.branchMode = false
+				m.branchInput.Blur()
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.branchInput, cmd = m.branchInput.Update(msg)
+			return m, cmd
+		}
+
+		// Handle the path prompt
+		if m.pathMode {
+			switch key {
+			case "enter":
+				path := strings.TrimSpace(m.pathInput.Value())
+				m.pathMode = false
+				m.pathInput.Blur()
+				if path == "" {
+					return m, nil
+				}
+				return m, m.doAddWorktree(path, m.pendingBranch)
+			case "esc":
+				m.pathMode = false
+				m.pathInput.Blur()
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.pathInput, cmd = m.pathInput.Update(msg)
+			return m, cmd
+		}
+
+		// Handle remove confirmation
+		if m.removeMode {
+			switch key {
+			case "y", "Y":
+				m.removeMode = false
+				return m, m.doRemoveWorktree(false)
+			case "f", "F":
+				m.removeMode = false
+				return m, m.doRemoveWorktree(true)
+			case "n", "N", "esc":
+				m.removeMode = false
+			}
+			return m, nil
+		}
+
+		// Check for gg sequence
+		if m.lastKey == Keys.Top && key == Keys.Top {
+			m.lastKey = ""
+			m.cursor = 0
+			m.ensureCursorVisible()
+			return m, nil
+		}
+
+		if key == Keys.Top {
+			m.lastKey = Keys.Top
+			return m, nil
+		}
+		m.lastKey = ""
+
+		switch key {
+		case Keys.Help:
+			m.showHelp = true
+			return m, nil
+		case Keys.VerboseHelp:
+			m.showVerboseHelp = !m.showVerboseHelp
+			return m, nil
+		case Keys.Down, "down":
+			if len(m.worktrees) > 0 {
+				m.cursor = min(m.cursor+1, len(m.worktrees)-1)
+				m.ensureCursorVisible()
+			}
+			return m, nil
+		case Keys.Up, "up":
+			if len(m.worktrees) > 0 {
+				m.cursor = max(m.cursor-1, 0)
+				m.ensureCursorVisible()
+			}
+			return m, nil
+		case Keys.Bottom:
+			if len(m.worktrees) > 0 {
+				m.cursor = len(m.worktrees) - 1
+				m.ensureCursorVisible()
+			}
+			return m, nil
+		case Keys.NewBranch:
+			m.err = nil
+			m.notice = ""
+			m.branchMode = true
+			m.branchInput.Reset()
+			m.branchInput.Focus()
+			return m, textinput.Blink
+		case Keys.Delete:
+			wt, ok := m.SelectedWorktree()
+			if !ok {
+				return m, nil
+			}
+			switch {
+			case m.cursor == 0:
+				m.err = fmt.Errorf("the main worktree can't be removed")
+			case wt.Current:
+				m.err = fmt.Errorf("can't remove the worktree go-on-git is running in, switch to another one first")
+			default:
+				m.err = nil
+				m.notice = ""
+				m.removeMode = true
+			}
+			return m, nil
+		case Keys.Prune:
+			m.err = nil
+			m.notice = ""
+			return m, doPruneWorktrees
+		case Keys.Right, "right", "enter":
+			wt, ok := m.SelectedWorktree()
+			if !ok {
+				return m, nil
+			}
+			if wt.Bare || wt.Prunable {
+				m.err = fmt.Errorf("can't switch to %s", wt.Path)
+				return m, nil
+			}
+			return m, doSwitchWorktree(wt.Path)
+		}
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case worktreesMsg:
+		m.worktrees = msg.worktrees
+		m.loaded = true
+		m.err = nil
+		m.notice = msg.notice
+		if m.cursor >= len(m.worktrees) {
+			m.cursor = max(0, len(m.worktrees)-1)
+		}
+		m.ensureCursorVisible()
+		return m, nil
+
+	case errMsg:
+		m.err = msg.err
+		m.notice = ""
+		return m, nil
+	}
+
+	return m, nil
+}
+
+func (m WorktreesModel) doAddWorktree(path, branch string) tea.Cmd {
+	return func() tea.Msg {
+		branches, err := git.GetBranches()
+		if err != nil {
+			return errMsg{err}
+		}
+		create := true
+		for _, b := range branches {
+			if b.Name == branch {
+				create = false
+				break
+			}
+		}
+		if err := git.AddWorktree(path, branch, create); err != nil {
+			return errMsg{err}
+		}
+		return withWorktreesNotice(refreshWorktrees(), fmt.Sprintf("Added worktree for '%s' at %s", branch, path))
+	}
+}
+
+func (m WorktreesModel) doRemoveWorktree(force bool) tea.Cmd {
+	wt, ok := m.SelectedWorktree()
+	if !ok {
+		return nil
+	}
+	return func() tea.Msg {
+		if err := git.RemoveWorktree(wt.Path, force); err != nil {
+			return errMsg{err}
+		}
+		return withWorktreesNotice(refreshWorktrees(), fmt.Sprintf("Removed worktree %s", wt.Path))
+	}
+}
+
+func doPruneWorktrees() tea.Msg {
+	if err := git.PruneWorktrees(); err != nil {
+		return errMsg{err}
+	}
+	return withWorktreesNotice(refreshWorktrees(), "Pruned stale worktrees")
+}
+
+func doSwitchWorktree(path string) tea.Cmd {
+	return func() tea.Msg {
+		if err := git.SwitchWorktree(path); err != nil {
+			return errMsg{err}
+		}
+		return worktreeSwitchedMsg{path: path}
+	}
+}
+
+// withWorktreesNotice adds a notice to a refreshed worktree list
+func withWorktreesNotice(msg tea.Msg, notice string) tea.Msg {
+	if worktrees, ok := msg.(worktreesMsg); ok {
+		worktrees.notice = notice
+		return worktrees
+	}
+	return msg
+}
+
+// visibleLines returns the number of worktree lines that can be displayed
+func (m WorktreesModel) visibleLines() int {
+	// Reserve lines for: header (~3), help bar (~3 if shown), prompts, and buffer
+	reserved := 8
+	if m.showVerboseHelp {
+		reserved += 3
+	}
+	if m.height <= reserved {
+		return 10 // fallback minimum
+	}
+	return m.height - reserved
+}
+
+// ensureCursorVisible adjusts scrollOffset to keep cursor in view
+func (m *WorktreesModel) ensureCursorVisible() {
+	visible := m.visibleLines()
+	if m.cursor < m.scrollOffset {
+		m.scrollOffset = m.cursor
+	}
+	if m.cursor >= m.scrollOffset+visible {
+		m.scrollOffset = m.cursor - visible + 1
+	}
+	m.scrollOffset = max(0, min(m.scrollOffset, len(m.worktrees)-visible))
+}
+
+// View renders the model
+func (m WorktreesModel) View() string {
+	if m.showHelp {
+		return m.renderHelp()
+	}
+
+	var sb strings.Builder
+
+	if m.err != nil {
+		sb.WriteString(StyleUnstaged.Render(fmt.Sprintf("Error: %v", m.err)))
+		sb.WriteString("\n\n")
+	}
+
+	sb.WriteString(m.renderHeader())
+	sb.WriteString("\n\n")
+
+	if !m.loaded {
+		sb.WriteString(StyleMuted.Render("Loading..."))
+		sb.WriteString("\n")
+	}
+
+	visibleEnd := min(m.scrollOffset+m.visibleLines(), len(m.worktrees))
+	if m.scrollOffset > 0 {
+		sb.WriteString(StyleMuted.Render(fmt.Sprintf("  ↑ %d more above", m.scrollOffset)))
+		sb.WriteString("\n")
+	}
+
+	for i := m.scrollOffset; i < visibleEnd; i++ {
+		wt := m.worktrees[i]
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+		}
+
+		sb.WriteString(prefix)
+		if wt.Current {
+			sb.WriteString(StyleRefHead.Render("* "))
+		} else {
+			sb.WriteString("  ")
+		}
+		sb.WriteString(wt.Path)
+		switch {
+		case wt.Bare:
+			sb.WriteString(StyleMuted.Render(" (bare)"))
+		case wt.Detached:
+			sb.WriteString(" ")
+			sb.WriteString(StyleCommitHash.Render(git.ShortHash(wt.Head)))
+			sb.WriteString(StyleMuted.Render(" (detached)"))
+		default:
+			sb.WriteString(" ")
+			sb.WriteString(StyleRefBranch.Render(wt.Branch))
+			sb.WriteString(" ")
+			sb.WriteString(StyleCommitHash.Render(git.ShortHash(wt.Head)))
+		}
+		if wt.Locked {
+			sb.WriteString(StyleUntracked.Render(" [locked" + reasonSuffix(wt.LockReason) + "]"))
+		}
+		if wt.Prunable {
+			sb.WriteString(StyleUnstaged.Render(" [prunable" + reasonSuffix(wt.PrunableReason) + "]"))
+		}
+		sb.WriteString("\n")
+	}
+
+	if visibleEnd < len(m.worktrees) {
+		sb.WriteString(StyleMuted.Render(fmt.Sprintf("  ↓ %d more below", len(m.worktrees)-visibleEnd)))
+		sb.WriteString("\n")
+	}
+
+	if m.notice != "" {
+		sb.WriteString("\n")
+		sb.WriteString(StyleMuted.Render(m.notice))
+		sb.WriteString("\n")
+	}
+
+	// Prompts
+	switch {
+	case m.branchMode:
+		sb.WriteString("\n")
+		sb.WriteString("Branch for the new worktree: ")
+		sb.WriteString(m.branchInput.View())
+		sb.WriteString(StyleMuted.Render("  (enter to continue, esc to cancel)"))
+	case m.pathMode:
+		sb.WriteString("\n")
+		sb.WriteString(fmt.Sprintf("Path for '%s': ", m.pendingBranch))
+		sb.WriteString(m.pathInput.View())
+		sb.WriteString(StyleMuted.Render("  (enter to add, esc to cancel)"))
+	case m.removeMode && m.cursor < len(m.worktrees):
+		sb.WriteString("\n")
+		sb.WriteString(StyleConfirm.Render(fmt.Sprintf("Remove worktree %s? (y/n, f to force) ", m.worktrees[m.cursor].Path)))
+	}
+
+	// Help bar (only show when showVerboseHelp is on and not in a prompt)
+	if m.showVerboseHelp && !m.inPrompt() {
+		sb.WriteString("\n\n")
+		sb.WriteString(m.renderHelpBar())
+	}
+
+	return sb.String()
+}
+
+// reasonSuffix formats an optional lock or prune reason
+func reasonSuffix(reason string) string {
+	if reason == "" {
+		return ""
+	}
+	return ": " + reason
+}
+
+func (m WorktreesModel) renderHeader() string {
+	return StyleMuted.Render("> git worktree list") + "  " + StyleMuted.Render("(esc to go back)") + "\n" + StyleMuted.Render("───────────────────────────────────────────────────────────────")
+}
+
+func (m WorktreesModel) renderHelpBar() string {
+	var sb strings.Builder
+
+	sb.WriteString(StyleMuted.Render("───────────────────────────────────────────────────────────────"))
+	sb.WriteString("\n")
+
+	items := []struct{ key, desc string }{
+		{formatKeyList(Keys.Down, Keys.Up), "navigate"},
+		{formatKeyList(Keys.Right, "Enter"), "switch"},
+		{Keys.NewBranch, "add"},
+		{Keys.Delete, "remove"},
+		{Keys.Prune, "prune"},
+		{Keys.Help, "help"},
+		{formatKeyList(Keys.Left, "ESC"), "back"},
+	}
+
+	for _, item := range items {
+		sb.WriteString(StyleHelpKey.Render(item.key))
+		sb.WriteString(" ")
+		sb.WriteString(StyleHelpDesc.Render(item.desc))
+		sb.WriteString("  ")
+	}
+
+	return sb.String()
+}
+
+func (m WorktreesModel) renderHelp() string {
+	var sb strings.Builder
+
+	sb.WriteString(StyleHelpTitle.Render("Worktrees Shortcuts"))
+	sb.WriteString("\n\n")
+
+	moveKeys := formatKeyList(Keys.Down, Keys.Up, "↓", "↑")
+	topKey := formatDoubleKey(Keys.Top)
+	switchKeys := formatKeyList(Keys.Right, "→", "Enter")
+	backKeys := formatKeyList(Keys.Left, "←", "ESC")
+
+	help := []struct {
+		key  string
+		desc string
+	}{
+		{moveKeys, "Move down/up"},
+		{topKey, "Go to top"},
+		{Keys.Bottom, "Go to bottom"},
+		{switchKeys, "Switch to worktree"},
+		{Keys.NewBranch, "Add worktree for an existing or new branch"},
+		{Keys.Delete, "Remove worktree"},
+		{Keys.Prune, "Prune worktrees whose directory is gone"},
+		{Keys.Help, "Toggle help"},
+		{backKeys, "Go back"},
+	}
+
+	for _, h := range help {
+		sb.WriteString(fmt.Sprintf("  %s  %s\n",
+			StyleHelpKey.Render(fmt.Sprintf("%-8s", h.key)),
+			StyleHelpDesc.Render(h.desc)))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,222 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"go-on-git/internal/git"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func makeWorktrees() []git.Worktree {
+	return []git.Worktree{
+		{Path: "/repo", Head: "1111111111111111111111111111111111111111", Branch: "main", Current: true},
+		{Path: "/repo-feature", Head: "2222222222222222222222222222222222222222", Branch: "feature", Locked: true, LockReason: "on a usb disk"},
+		{Path: "/repo-gone", Head: "3333333333333333333333333333333333333333", Detached: true, Prunable: true},
+	}
+}
+
+func TestNewWorktreesModel(t *testing.T) {
+	m := NewWorktreesModel()
+
+	if m.cursor != 0 {
+		t.Errorf("cursor = %d, want 0", m.cursor)
+	}
+	if m.loaded {
+		t.Error("loaded should be false initially")
+	}
+	if m.Init() == nil {
+		t.Error("Init() should return a command")
+	}
+}
+
+func TestWorktreesModelWorktreesMsg(t *testing.T) {
+	m := NewWorktreesModel()
+	m.cursor = 10
+
+	newModel, _ := m.Update(worktreesMsg{worktrees: makeWorktrees(), notice: "Pruned stale worktrees"})
+	m = newModel.(WorktreesModel)
+
+	if !m.loaded {
+		t.Error("loaded should be true after worktreesMsg")
+	}
+	if m.cursor != 2 {
+		t.Errorf("cursor = %d, want 2 (clamped)", m.cursor)
+	}
+	if !strings.Contains(m.View(), "Pruned stale worktrees") {
+		t.Error("view should show the notice")
+	}
+}
+
+func TestWorktreesModelView(t *testing.T) {
+	m := NewWorktreesModel()
+	m.worktrees = makeWorktrees()
+	m.loaded = true
+
+	view := m.View()
+
+	if !strings.Contains(view, "> git worktree list") {
+		t.Error("view should contain the header")
+	}
+	for _, want := range []string{"/repo", "main", "1111111", "feature", "[locked: on a usb disk]", "(detached)", "[prunable]"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q", want)
+		}
+	}
+}
+
+func TestWorktreesModelAddFlow(t *testing.T) {
+	m := NewWorktreesModel()
+	m.worktrees = makeWorktrees()
+	m.loaded = true
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	m = newModel.(WorktreesModel)
+	if !m.branchMode {
+		t.Fatal("'n' should open the branch prompt")
+	}
+
+	for _, r := range "feature/x" {
+		newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = newModel.(WorktreesModel)
+	}
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(WorktreesModel)
+	if m.branchMode || !m.pathMode {
+		t.Fatal("enter should move from the branch prompt to the path prompt")
+	}
+	if !strings.Contains(m.View(), "Path for 'feature/x':") {
+		t.Error("view should show the path prompt")
+	}
+
+	m.pathInput.SetValue("/tmp/feature-x")
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(WorktreesModel)
+	if m.pathMode {
+		t.Error("enter should close the path prompt")
+	}
+	if cmd == nil {
+		t.Error("enter in the path prompt should return an add command")
+	}
+}
+
+func TestWorktreesModelAddEmptyBranchCancels(t *testing.T) {
+	m := NewWorktreesModel()
+	m.loaded = true
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	m = newModel.(WorktreesModel)
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(WorktreesModel)
+
+	if m.branchMode || m.pathMode || cmd != nil {
+		t.Error("an empty branch should cancel the new worktree")
+	}
+}
+
+func TestWorktreesModelRemove(t *testing.T) {
+	m := NewWorktreesModel()
+	m.worktrees = makeWorktrees()
+	m.loaded = true
+
+	// The main worktree can't be removed
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	m = newModel.(WorktreesModel)
+	if m.removeMode || m.err == nil {
+		t.Error("removing the main worktree should be refused")
+	}
+
+	m.cursor = 1
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	m = newModel.(WorktreesModel)
+	if !m.removeMode {
+		t.Fatal("'d' should open the remove confirmation")
+	}
+	if !strings.Contains(m.View(), "Remove worktree /repo-feature? (y/n, f to force)") {
+		t.Error("view should show the remove confirmation")
+	}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	m = newModel.(WorktreesModel)
+	if m.removeMode || cmd != nil {
+		t.Error("'n' should cancel the remove")
+	}
+
+	for _, key := range []rune{'y', 'f'} {
+		newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+		m = newModel.(WorktreesModel)
+		newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{key}})
+		m = newModel.(WorktreesModel)
+		if m.removeMode || cmd == nil {
+			t.Errorf("'%c' should return a remove command", key)
+		}
+	}
+}
+
+func TestWorktreesModelRemoveCurrentRefused(t *testing.T) {
+	m := NewWorktreesModel()
+	m.worktrees = makeWorktrees()
+	m.worktrees[0].Current = false
+	m.worktrees[1].Current = true
+	m.cursor = 1
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	m = newModel.(WorktreesModel)
+	if m.removeMode || m.err == nil {
+		t.Error("removing the current worktree should be refused")
+	}
+}
+
+func TestWorktreesModelSwitch(t *testing.T) {
+	m := NewWorktreesModel()
+	m.worktrees = makeWorktrees()
+	m.loaded = true
+	m.cursor = 1
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Error("enter should return a switch command")
+	}
+
+	// A prunable worktree has no directory to switch to
+	m.cursor = 2
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(WorktreesModel)
+	if cmd != nil || m.err == nil {
+		t.Error("switching to a prunable worktree should be refused")
+	}
+}
+
+func TestWorktreesModelPrune(t *testing.T) {
+	m := NewWorktreesModel()
+	m.worktrees = makeWorktrees()
+	m.loaded = true
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'P'}})
+	if cmd == nil {
+		t.Error("'P' should return a prune command")
+	}
+}
+
+func TestWorktreesModelInPrompt(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(*WorktreesModel)
+	}{
+		{name: "showHelp", setup: func(m *WorktreesModel) { m.showHelp = true }},
+		{name: "branchMode", setup: func(m *WorktreesModel) { m.branchMode = true }},
+		{name: "pathMode", setup: func(m *WorktreesModel) { m.pathMode = true }},
+		{name: "removeMode", setup: func(m *WorktreesModel) { m.removeMode = true }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewWorktreesModel()
+			tt.setup(&m)
+			if !m.inPrompt() {
+				t.Errorf("inPrompt should be true in %s", tt.name)
+			}
+		})
+	}
+}
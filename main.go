@@ -97,6 +97,11 @@ Navigation:
   b           View branches
   t           View stashes
   L           View commit log
+  T           View tags (from the log: tag the selected commit)
+  W           View worktrees
+  O           View submodules
+  H           View reflog
+  D           View recently discarded changes
   h/←/ESC     Go back
 
 Key Bindings:
@@ -107,11 +112,35 @@ Key Bindings:
   SPACE       Stage/unstage file or hunk
   a/A         Stage file(s) / Stage all
   u/U         Unstage file(s) / Unstage all
-  s/S         Stash file(s) / Stash all
+  s/S         Stash file(s) / Stash all (TAB toggles untracked, keep index, staged)
   d           Discard/delete (with confirmation)
   c/C         Commit inline / with editor
+  m/M         Amend last commit / without editing its message
   p           Push commits
-  n           Create new branch (in branches view)
+  F/P         Fetch from a remote / Pull the upstream branch
+  z           Undo the last git operation (with confirmation)
+  n           Create new branch, tag or worktree (in branches, tags or worktrees view)
+  TAB         Show/hide remote branches (in branches view)
+  R           Rename branch (in branches view)
+  u/U         Set / unset upstream (in branches view)
+  M/r         Merge branch into HEAD / Rebase HEAD onto branch (in branches view)
+  c/R/x       Cherry-pick / revert / reset to commit(s) (in log view)
+  r           Plan an interactive rebase onto the commit (in log view)
+  K/J         Move commit up/down (in rebase planner)
+  p/r/e       Pick / reword / edit commit (in rebase planner)
+  s/f/d       Squash / fixup / drop commit (in rebase planner)
+  +/>/X       Continue / skip / abort a merge, rebase, cherry-pick, revert or bisect
+  l/d/P       Switch to / remove / prune worktrees (in worktrees view)
+  l/i/u/s     Open nested session / init / update / sync (in submodules view)
+  l/x/n       Check out / reset to / branch from an entry (in reflog view)
+  r           Restore into the working tree (in recently discarded view)
+  a/p/d       Apply / pop / drop stash (in stashes view)
+  n/R/c       Branch from / rename / compare stash with working tree (in stashes view)
+  a/A         Apply selected hunks / whole file (in stash diff)
+  o/t/b       Keep ours/theirs/both (in conflict view)
+  s           Split hunk (in diff view)
+  E           Edit hunk in $EDITOR before staging (in diff view)
+  S           Stash the selected hunks (in diff view)
   ?           Toggle quick help
   /           Toggle verbose help
   q/ESC       Quit
@@ -124,7 +153,17 @@ Keymap Overrides:
   Available actions:
     up, down, left, right, top, bottom, select, back, quit,
     stage, stage-all, unstage, unstage-all, discard,
-    commit, commit-edit, push, stash, stash-all,
-    file-diff, all-diffs, branches, stashes, log,
-    visual, edit, help, verbose-help, new-branch, delete`)
+    commit, commit-edit, amend, amend-no-edit, push, fetch, pull,
+    stash, stash-all,
+    file-diff, all-diffs, branches, stashes, log, tags, worktrees, submodules,
+    reflog, discarded,
+    visual, edit, help, verbose-help, new-branch, delete,
+    remote-branches, rename-branch, set-upstream, unset-upstream,
+    merge, rebase, cherry-pick, revert, reset, undo, restore,
+    move-up, move-down, pick, reword, edit-commit, squash, fixup, drop,
+    continue, skip, abort,
+    stash-apply, stash-apply-file, stash-pop, stash-drop, stash-rename, stash-compare,
+    new-tag, prune,
+    submodule-init, submodule-update, submodule-sync,
+    ours, theirs, both, split, edit-hunk, stash-hunks`)
 }